@@ -12,6 +12,14 @@ import (
 // Config stores the application-wide configurations
 var Config appConfig
 
+// SampleJWTKey is the JWT key of the sample configuration files. As it is public, the JWT
+// authentication is disabled when it is configured
+const SampleJWTKey = "QfCAH04Cob7b71QCqy738vw5XGSnFZ9d"
+
+// secretKeys are the configuration keys of the secrets, which can be set with environment
+// variables even when they are absent from the configuration file
+var secretKeys = []string{"jwt_signing_key", "jwt_verification_key", "admin_api_key", "api_key_encryption_key"}
+
 var logger = utils.Logger
 
 // var logger = utils.NoopLogger
@@ -41,10 +49,19 @@ type appConfig struct {
 
 	// the signing method for JWT. Defaults to "HS256"
 	JWTSigningMethod string `mapstructure:"jwt_signing_method"`
-	// JWT signing key. required.
+	// JWT signing key. The JWT authentication is disabled if it is not set
 	JWTSigningKey string `mapstructure:"jwt_signing_key"`
-	// JWT verification key. required.
+	// JWT verification key. The JWT authentication is disabled if it is not set
 	JWTVerificationKey string `mapstructure:"jwt_verification_key"`
+	// AdminAPIKey authenticates the requests to the admin endpoints. The admin
+	// endpoints are disabled if it is not set
+	AdminAPIKey string `mapstructure:"admin_api_key"`
+	// APIKeyEncryptionKey encrypts the secrets of the API keys stored in the database. The API
+	// keys are disabled if it is not set
+	APIKeyEncryptionKey string `mapstructure:"api_key_encryption_key"`
+	// Auth holds the lifetimes of the login challenges and of the issued JWTs, and the
+	// comma-separated addresses granted the admin role on login
+	Auth map[string]string `mapstructure:"auth"`
 	// TickDuration is user by tick streaming cron
 	TickDuration map[string][]int64 `mapstructure:"tick_duration"`
 
@@ -52,6 +69,18 @@ type appConfig struct {
 
 	Ethereum map[string]string `mapstructure:"ethereum"`
 
+	// GasTopUp holds the operator wallets funding policy (amounts in wei)
+	GasTopUp map[string]string `mapstructure:"gas_top_up"`
+
+	// Reconciliation holds the schedule of the fill reconciliation with the exchange contract
+	Reconciliation map[string]string `mapstructure:"reconciliation"`
+
+	// LockedBalances holds the schedule of the full recomputation of the locked balances
+	LockedBalances map[string]string `mapstructure:"locked_balances"`
+
+	// Referrals holds the share of the taker fees rebated to the referrers, in basis points
+	Referrals map[string]string `mapstructure:"referrals"`
+
 	Deposit *config.Config `mapstructure:"deposit"`
 }
 
@@ -61,9 +90,18 @@ func (config appConfig) Validate() error {
 	)
 }
 
+// JWTEnabled returns true if the JWT keys are configured and are not the public sample key
+func (config appConfig) JWTEnabled() bool {
+	if config.JWTSigningKey == "" || config.JWTVerificationKey == "" {
+		return false
+	}
+
+	return config.JWTSigningKey != SampleJWTKey && config.JWTVerificationKey != SampleJWTKey
+}
+
 // LoadConfig loads configuration from the given list of paths and populates it into the Config variable.
 // The configuration file(s) should be named as app.yaml.
-// Environment variables with the prefix "RESTFUL_" in their names are also read automatically.
+// Environment variables with the prefix "TOMO_" in their names are also read automatically.
 func LoadConfig(configPath string, env string) error {
 	v := viper.New()
 
@@ -82,6 +120,13 @@ func LoadConfig(configPath string, env string) error {
 	v.SetEnvPrefix("tomo")
 	v.AutomaticEnv()
 
+	for _, key := range secretKeys {
+		err = v.BindEnv(key)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = v.Unmarshal(&Config)
 	if err != nil {
 		return err
@@ -97,5 +142,14 @@ func LoadConfig(configPath string, env string) error {
 	logger.Infof("RabbitMQ url: %v", Config.RabbitMQURL)
 	logger.Infof("Exchange contract address: %v", Config.Ethereum["exchange_address"])
 	logger.Infof("Fee Account: %v", Config.Ethereum["fee_account"])
+
+	if !Config.JWTEnabled() {
+		logger.Warning("JWT authentication is disabled: set TOMO_JWT_SIGNING_KEY and TOMO_JWT_VERIFICATION_KEY to enable it")
+	}
+
+	if Config.APIKeyEncryptionKey == "" {
+		logger.Warning("API keys are disabled: set TOMO_API_KEY_ENCRYPTION_KEY to enable them")
+	}
+
 	return Config.Validate()
 }
@@ -136,6 +136,219 @@ func (e *Exchange) Operator(a common.Address) (bool, error) {
 	return isOperator, nil
 }
 
+// SetOwner transfers the ownership of the exchange contract to the given address
+func (e *Exchange) SetOwner(a common.Address, txOpts *bind.TransactOpts) (*eth.Transaction, error) {
+	tx, err := e.Interface.SetOwner(txOpts, a)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// SetWethToken sets the address of the WETH token used by the exchange contract
+func (e *Exchange) SetWethToken(a common.Address, txOpts *bind.TransactOpts) (*eth.Transaction, error) {
+	tx, err := e.Interface.SetWethToken(txOpts, a)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// RegisterPair registers a token pair and its pricepoint multiplier on the exchange contract
+func (e *Exchange) RegisterPair(bt, qt common.Address, multiplier *big.Int, txOpts *bind.TransactOpts) (*eth.Transaction, error) {
+	tx, err := e.Interface.RegisterPair(txOpts, bt, qt, multiplier)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// Owner returns the owner of the exchange contract
+func (e *Exchange) Owner() (common.Address, error) {
+	callOptions := e.GetTxCallOptions()
+
+	owner, err := e.Interface.Owner(callOptions)
+	if err != nil {
+		logger.Error(err)
+		return common.Address{}, err
+	}
+
+	return owner, nil
+}
+
+// WethToken returns the address of the WETH token used by the exchange contract
+func (e *Exchange) WethToken() (common.Address, error) {
+	callOptions := e.GetTxCallOptions()
+
+	weth, err := e.Interface.WethToken(callOptions)
+	if err != nil {
+		logger.Error(err)
+		return common.Address{}, err
+	}
+
+	return weth, nil
+}
+
+// Version returns the version of the exchange contract
+func (e *Exchange) Version() (string, error) {
+	callOptions := e.GetTxCallOptions()
+
+	version, err := e.Interface.VERSION(callOptions)
+	if err != nil {
+		logger.Error(err)
+		return "", err
+	}
+
+	return version, nil
+}
+
+// RewardAccount returns the reward account of the exchange contract
+func (e *Exchange) RewardAccount() (common.Address, error) {
+	callOptions := e.GetTxCallOptions()
+
+	account, err := e.Interface.RewardAccount(callOptions)
+	if err != nil {
+		logger.Error(err)
+		return common.Address{}, err
+	}
+
+	return account, nil
+}
+
+// PairIsRegistered returns true if the given token pair is registered on the exchange contract
+func (e *Exchange) PairIsRegistered(bt, qt common.Address) (bool, error) {
+	callOptions := e.GetTxCallOptions()
+
+	registered, err := e.Interface.PairIsRegistered(callOptions, bt, qt)
+	if err != nil {
+		logger.Error(err)
+		return false, err
+	}
+
+	return registered, nil
+}
+
+// GetPairPricepointMultiplier returns the pricepoint multiplier of a token pair registered on the exchange contract
+func (e *Exchange) GetPairPricepointMultiplier(bt, qt common.Address) (*big.Int, error) {
+	callOptions := e.GetTxCallOptions()
+
+	multiplier, err := e.Interface.GetPairPricepointMultiplier(callOptions, bt, qt)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return multiplier, nil
+}
+
+// Filled returns the amount of an order that has been filled (or cancelled) on the exchange contract
+func (e *Exchange) Filled(h common.Hash) (*big.Int, error) {
+	callOptions := e.GetTxCallOptions()
+
+	filled, err := e.Interface.Filled(callOptions, h)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return filled, nil
+}
+
+// Traded returns true if the trade corresponding to the given hash has been settled on the exchange contract
+func (e *Exchange) Traded(h common.Hash) (bool, error) {
+	callOptions := e.GetTxCallOptions()
+
+	traded, err := e.Interface.Traded(callOptions, h)
+	if err != nil {
+		logger.Error(err)
+		return false, err
+	}
+
+	return traded, nil
+}
+
+// IsValidSignature returns true if the given signature of a hash was produced by the given signer,
+// as verified by the exchange contract during settlement
+func (e *Exchange) IsValidSignature(signer common.Address, h common.Hash, sig *types.Signature) (bool, error) {
+	callOptions := e.GetTxCallOptions()
+
+	valid, err := e.Interface.IsValidSignature(callOptions, signer, h, sig.V, sig.R, sig.S)
+	if err != nil {
+		logger.Error(err)
+		return false, err
+	}
+
+	return valid, nil
+}
+
+// IsRoundingError returns true if the rounding error of numerator * target / denominator is
+// too large to be accepted by the exchange contract
+func (e *Exchange) IsRoundingError(numerator, denominator, target *big.Int) (bool, error) {
+	callOptions := e.GetTxCallOptions()
+
+	isRoundingError, err := e.Interface.IsRoundingError(callOptions, numerator, denominator, target)
+	if err != nil {
+		logger.Error(err)
+		return false, err
+	}
+
+	return isRoundingError, nil
+}
+
+// CancelOrder cancels an order on the exchange contract. The order needs to be signed by its maker.
+func (e *Exchange) CancelOrder(o *types.Order, txOpts *bind.TransactOpts) (*eth.Transaction, error) {
+	if o.Signature == nil {
+		return nil, errors.New("Order is not signed")
+	}
+
+	orderValues := [6]*big.Int{o.Amount, o.PricePoint, o.EncodedSide(), o.Nonce, o.TakeFee, o.MakeFee}
+	orderAddresses := [3]common.Address{o.UserAddress, o.BaseToken, o.QuoteToken}
+
+	tx, err := e.Interface.CancelOrder(txOpts, orderValues, orderAddresses, o.Signature.V, o.Signature.R, o.Signature.S)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// BatchCancelOrders cancels several orders on the exchange contract in a single transaction.
+// The orders need to be signed by their respective makers.
+func (e *Exchange) BatchCancelOrders(orders []*types.Order, txOpts *bind.TransactOpts) (*eth.Transaction, error) {
+	orderValues := [][6]*big.Int{}
+	orderAddresses := [][3]common.Address{}
+	vValues := []uint8{}
+	rValues := [][32]byte{}
+	sValues := [][32]byte{}
+
+	for _, o := range orders {
+		if o.Signature == nil {
+			return nil, errors.New("Order is not signed")
+		}
+
+		orderValues = append(orderValues, [6]*big.Int{o.Amount, o.PricePoint, o.EncodedSide(), o.Nonce, o.TakeFee, o.MakeFee})
+		orderAddresses = append(orderAddresses, [3]common.Address{o.UserAddress, o.BaseToken, o.QuoteToken})
+		vValues = append(vValues, o.Signature.V)
+		rValues = append(rValues, o.Signature.R)
+		sValues = append(sValues, o.Signature.S)
+	}
+
+	tx, err := e.Interface.BatchCancelOrders(txOpts, orderValues, orderAddresses, vValues, rValues, sValues)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return tx, nil
+}
+
 func (e *Exchange) ExecuteBatchTrades(matches *types.Matches, txOpts *bind.TransactOpts) (*eth.Transaction, error) {
 	orderValues := [][10]*big.Int{}
 	orderAddresses := [][4]common.Address{}
@@ -309,6 +522,20 @@ func (e *Exchange) ListenToErrors() (chan *contractsinterfaces.ExchangeLogError,
 	return events, nil
 }
 
+// ListenToCancelOrders returns a channel that receives the order cancellation logs of the exchange smart contract
+func (e *Exchange) ListenToCancelOrders() (chan *contractsinterfaces.ExchangeLogCancelOrder, error) {
+	events := make(chan *contractsinterfaces.ExchangeLogCancelOrder)
+	opts := &bind.WatchOpts{nil, nil}
+
+	_, err := e.Interface.WatchLogCancelOrder(opts, events)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return events, nil
+}
+
 // ListenToTrades returns a channel that receivs trade logs (events) from the underlying exchange smart contract
 func (e *Exchange) ListenToTrades() (chan *contractsinterfaces.ExchangeLogTrade, error) {
 	events := make(chan *contractsinterfaces.ExchangeLogTrade)
@@ -336,6 +563,120 @@ func (e *Exchange) ListenToBatchTrades() (chan *contractsinterfaces.ExchangeLogB
 	return events, nil
 }
 
+// ListenToOperatorUpdates returns a channel that receives the operator update logs of the exchange smart contract
+func (e *Exchange) ListenToOperatorUpdates() (chan *contractsinterfaces.ExchangeLogOperatorUpdate, error) {
+	events := make(chan *contractsinterfaces.ExchangeLogOperatorUpdate)
+	opts := &bind.WatchOpts{nil, nil}
+
+	_, err := e.Interface.WatchLogOperatorUpdate(opts, events)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// FilterTrades returns the trade logs emitted by the exchange smart contract since the given block
+func (e *Exchange) FilterTrades(start uint64) ([]*contractsinterfaces.ExchangeLogTrade, error) {
+	opts := &bind.FilterOpts{Start: start}
+
+	it, err := e.Interface.FilterLogTrade(opts, nil, nil, nil)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer it.Close()
+
+	events := []*contractsinterfaces.ExchangeLogTrade{}
+	for it.Next() {
+		events = append(events, it.Event)
+	}
+
+	if it.Error() != nil {
+		logger.Error(it.Error())
+		return nil, it.Error()
+	}
+
+	return events, nil
+}
+
+// FilterBatchTrades returns the batch trade logs emitted by the exchange smart contract since the given block
+func (e *Exchange) FilterBatchTrades(start uint64) ([]*contractsinterfaces.ExchangeLogBatchTrades, error) {
+	opts := &bind.FilterOpts{Start: start}
+
+	it, err := e.Interface.FilterLogBatchTrades(opts, nil)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer it.Close()
+
+	events := []*contractsinterfaces.ExchangeLogBatchTrades{}
+	for it.Next() {
+		events = append(events, it.Event)
+	}
+
+	if it.Error() != nil {
+		logger.Error(it.Error())
+		return nil, it.Error()
+	}
+
+	return events, nil
+}
+
+// FilterErrors returns the error logs emitted by the exchange smart contract since the given block
+func (e *Exchange) FilterErrors(start uint64) ([]*contractsinterfaces.ExchangeLogError, error) {
+	opts := &bind.FilterOpts{Start: start}
+
+	it, err := e.Interface.FilterLogError(opts)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer it.Close()
+
+	events := []*contractsinterfaces.ExchangeLogError{}
+	for it.Next() {
+		events = append(events, it.Event)
+	}
+
+	if it.Error() != nil {
+		logger.Error(it.Error())
+		return nil, it.Error()
+	}
+
+	return events, nil
+}
+
+// FilterCancelOrders returns the order cancellation logs emitted by the exchange smart contract since the given block
+func (e *Exchange) FilterCancelOrders(start uint64) ([]*contractsinterfaces.ExchangeLogCancelOrder, error) {
+	opts := &bind.FilterOpts{Start: start}
+
+	it, err := e.Interface.FilterLogCancelOrder(opts)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer it.Close()
+
+	events := []*contractsinterfaces.ExchangeLogCancelOrder{}
+	for it.Next() {
+		events = append(events, it.Event)
+	}
+
+	if it.Error() != nil {
+		logger.Error(it.Error())
+		return nil, it.Error()
+	}
+
+	return events, nil
+}
+
 func (e *Exchange) GetErrorEvents(logs chan *contractsinterfaces.ExchangeLogError) error {
 	opts := &bind.WatchOpts{nil, nil}
 
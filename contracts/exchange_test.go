@@ -125,6 +125,36 @@ func TestSetOperator(t *testing.T) {
 	}
 }
 
+func TestCheckCompatibility(t *testing.T) {
+	deployer, _, feeAccount, wethToken, _, _ := SetupTest()
+
+	exchange, _, _, err := deployer.DeployExchange(wethToken, feeAccount)
+	if err != nil {
+		t.Errorf("Could not deploy exchange: %v", err)
+	}
+
+	simulator := deployer.Client.(*ethereum.SimulatedClient)
+	simulator.Commit()
+
+	status, err := exchange.CheckCompatibility(wethToken, common.Address{})
+	if err != nil {
+		t.Fatalf("Could not check the contract compatibility: %v", err)
+	}
+
+	if status.Version != "1.0.0" || !status.IsCompatible() {
+		t.Errorf("Expected a compatible contract, got version %v and mismatches %v", status.Version, status.Mismatches)
+	}
+
+	status, err = exchange.CheckCompatibility(testutils.GetTestAddress1(), common.Address{})
+	if err != nil {
+		t.Fatalf("Could not check the contract compatibility: %v", err)
+	}
+
+	if len(status.Mismatches) != 1 {
+		t.Errorf("Expected a WETH token mismatch, got %v", status.Mismatches)
+	}
+}
+
 func TestTrade(t *testing.T) {
 	deployer, admin, feeAccount, wethToken, maker, taker := SetupTest()
 	simulator := deployer.GetSimulator()
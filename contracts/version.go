@@ -0,0 +1,68 @@
+package contracts
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/types"
+)
+
+// SupportedVersions lists the exchange contract versions whose order hashing and
+// trade encoding match the bindings used by the server
+var SupportedVersions = []string{"1.0.0"}
+
+// CheckCompatibility reads the version, WETH token and reward account of the deployed exchange
+// contract and compares them with the supported versions and the given configuration. A zero
+// reward account is not compared.
+func (e *Exchange) CheckCompatibility(weth, rewardAccount common.Address) (*types.ContractStatus, error) {
+	version, err := e.Version()
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	contractWeth, err := e.WethToken()
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	contractRewardAccount, err := e.RewardAccount()
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	status := &types.ContractStatus{
+		Address:           e.Address,
+		Version:           version,
+		SupportedVersions: SupportedVersions,
+		WethToken:         contractWeth,
+		RewardAccount:     contractRewardAccount,
+		Mismatches:        []string{},
+	}
+
+	if !isSupportedVersion(version) {
+		status.Mismatches = append(status.Mismatches, fmt.Sprintf("Unsupported contract version %v", version))
+	}
+
+	if contractWeth != weth {
+		status.Mismatches = append(status.Mismatches, fmt.Sprintf("WETH token is %v on the contract and %v in the configuration", contractWeth.Hex(), weth.Hex()))
+	}
+
+	if rewardAccount != (common.Address{}) && contractRewardAccount != rewardAccount {
+		status.Mismatches = append(status.Mismatches, fmt.Sprintf("Reward account is %v on the contract and %v in the configuration", contractRewardAccount.Hex(), rewardAccount.Hex()))
+	}
+
+	return status, nil
+}
+
+func isSupportedVersion(version string) bool {
+	for _, v := range SupportedVersions {
+		if v == version {
+			return true
+		}
+	}
+
+	return false
+}
@@ -7,17 +7,28 @@ import (
 
 // CronService contains the services required to initialize crons
 type CronService struct {
-	ohlcvService *services.OHLCVService
+	ohlcvService          *services.OHLCVService
+	gasTopUpService       *services.GasTopUpService
+	reconciliationService *services.ReconciliationService
+	lockedBalanceService  *services.LockedBalanceService
 }
 
 // NewCronService returns a new instance of CronService
-func NewCronService(ohlcvService *services.OHLCVService) *CronService {
-	return &CronService{ohlcvService}
+func NewCronService(
+	ohlcvService *services.OHLCVService,
+	gasTopUpService *services.GasTopUpService,
+	reconciliationService *services.ReconciliationService,
+	lockedBalanceService *services.LockedBalanceService,
+) *CronService {
+	return &CronService{ohlcvService, gasTopUpService, reconciliationService, lockedBalanceService}
 }
 
 // InitCrons is responsible for initializing all the crons in the system
 func (s *CronService) InitCrons() {
 	c := cron.New()
 	s.tickStreamingCron(c)
+	s.gasTopUpCron(c)
+	s.reconciliationCron(c)
+	s.lockedBalanceCron(c)
 	c.Start()
 }
@@ -0,0 +1,30 @@
+package crons
+
+import (
+	"log"
+
+	"github.com/robfig/cron"
+	"github.com/tomochain/dex-server/app"
+)
+
+// gasTopUpCron takes instance of cron.Cron and adds the operator wallets
+// top-up cron according to the schedule mentioned in the config file
+func (s *CronService) gasTopUpCron(c *cron.Cron) {
+	schedule := app.Config.GasTopUp["schedule"]
+	if schedule == "" || s.gasTopUpService == nil {
+		return
+	}
+
+	err := c.AddFunc(schedule, s.topUpOperatorWallets)
+	if err != nil {
+		log.Printf("%s", err)
+	}
+}
+
+// topUpOperatorWallets funds the operator wallets whose balance is below the floor
+func (s *CronService) topUpOperatorWallets() {
+	_, err := s.gasTopUpService.TopUpOperatorWallets()
+	if err != nil {
+		log.Printf("%s", err)
+	}
+}
@@ -0,0 +1,30 @@
+package crons
+
+import (
+	"log"
+
+	"github.com/robfig/cron"
+	"github.com/tomochain/dex-server/app"
+)
+
+// lockedBalanceCron takes instance of cron.Cron and adds the locked balances recomputation
+// cron according to the schedule mentioned in the config file
+func (s *CronService) lockedBalanceCron(c *cron.Cron) {
+	schedule := app.Config.LockedBalances["schedule"]
+	if schedule == "" || s.lockedBalanceService == nil {
+		return
+	}
+
+	err := c.AddFunc(schedule, s.recomputeLockedBalances)
+	if err != nil {
+		log.Printf("%s", err)
+	}
+}
+
+// recomputeLockedBalances checks the incremental locked balances against the open orders
+func (s *CronService) recomputeLockedBalances() {
+	err := s.lockedBalanceService.Recompute()
+	if err != nil {
+		log.Printf("%s", err)
+	}
+}
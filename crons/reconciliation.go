@@ -0,0 +1,32 @@
+package crons
+
+import (
+	"log"
+
+	"github.com/robfig/cron"
+	"github.com/tomochain/dex-server/app"
+)
+
+// reconciliationCron takes instance of cron.Cron and adds the fill reconciliation
+// cron according to the schedule mentioned in the config file
+func (s *CronService) reconciliationCron(c *cron.Cron) {
+	schedule := app.Config.Reconciliation["schedule"]
+	if schedule == "" || s.reconciliationService == nil {
+		return
+	}
+
+	err := c.AddFunc(schedule, s.reconcileFills)
+	if err != nil {
+		log.Printf("%s", err)
+	}
+}
+
+// reconcileFills compares the open orders and pending trades with the exchange contract
+func (s *CronService) reconcileFills() {
+	autoCorrect := app.Config.Reconciliation["auto_correct"] == "true"
+
+	_, err := s.reconciliationService.Reconcile(autoCorrect)
+	if err != nil {
+		log.Printf("%s", err)
+	}
+}
@@ -169,6 +169,10 @@ func (dao *AccountDao) GetTokenBalance(owner common.Address, token common.Addres
 	//bytes, _ := bson.Marshal(res[0])
 	//bson.Unmarshal(bytes, &a)
 
+	if len(res) == 0 {
+		return nil, nil
+	}
+
 	return res[0].TokenBalances[token], nil
 }
 
@@ -215,6 +219,65 @@ func (dao *AccountDao) UpdateAllowance(owner common.Address, token common.Addres
 	return err
 }
 
+// UpdateLockedBalance sets the amount of a token locked by the open orders of an account
+func (dao *AccountDao) UpdateLockedBalance(owner common.Address, token common.Address, lockedBalance *big.Int) error {
+	q := bson.M{
+		"address": owner.Hex(),
+	}
+
+	updateQuery := bson.M{
+		"$set": bson.M{"tokenBalances." + token.Hex() + ".lockedBalance": lockedBalance.String()},
+	}
+
+	err := db.Update(dao.dbName, dao.collectionName, q, updateQuery)
+	return err
+}
+
+// UpdateRestriction sets the restriction level of an account. The isBlocked flag is kept in sync
+// for the clients that do not know the restriction levels
+func (dao *AccountDao) UpdateRestriction(owner common.Address, r *types.AccountRestriction) error {
+	q := bson.M{
+		"address": owner.Hex(),
+	}
+
+	updateQuery := bson.M{
+		"$set": bson.M{
+			"restriction": r,
+			"isBlocked":   r.Level == types.ACCOUNT_BLOCKED,
+			"updatedAt":   time.Now(),
+		},
+	}
+
+	err := db.Update(dao.dbName, dao.collectionName, q, updateQuery)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// ClearBalanceCache removes the cached balances and allowances of the given tokens from all the accounts
+func (dao *AccountDao) ClearBalanceCache(tokens ...common.Address) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	unset := bson.M{}
+	for _, t := range tokens {
+		unset["tokenBalances."+t.Hex()+".balance"] = ""
+		unset["tokenBalances."+t.Hex()+".allowance"] = ""
+	}
+
+	err := db.UpdateAll(dao.dbName, dao.collectionName, bson.M{}, bson.M{"$unset": unset})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
 // Drop drops all the order documents in the current database
 func (dao *AccountDao) Drop() {
 	db.DropCollection(dao.dbName, dao.collectionName)
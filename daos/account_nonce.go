@@ -0,0 +1,78 @@
+package daos
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/types"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// AccountNonceDao contains:
+// collectionName: MongoDB collection name
+// dbName: name of mongodb to interact with
+type AccountNonceDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewAccountNonceDao returns a new instance of AccountNonceDao
+func NewAccountNonceDao() *AccountNonceDao {
+	dbName := app.Config.DBName
+	collection := "account_nonces"
+
+	index := mgo.Index{
+		Key:    []string{"userAddress"},
+		Unique: true,
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(index)
+	if err != nil {
+		panic(err)
+	}
+
+	return &AccountNonceDao{collection, dbName}
+}
+
+// GetMinNonce returns the minimum valid nonce of an account, which is zero if it was never set
+func (dao *AccountNonceDao) GetMinNonce(addr common.Address) (*big.Int, error) {
+	res := []*types.AccountNonce{}
+	q := bson.M{"userAddress": addr.Hex()}
+
+	err := db.Get(dao.dbName, dao.collectionName, q, 0, 1, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if len(res) == 0 || res[0].MinNonce == nil {
+		return big.NewInt(0), nil
+	}
+
+	return res[0].MinNonce, nil
+}
+
+// SetMinNonce sets the minimum valid nonce of an account
+func (dao *AccountNonceDao) SetMinNonce(addr common.Address, nonce *big.Int) error {
+	n := &types.AccountNonce{
+		UserAddress: addr,
+		MinNonce:    nonce,
+		UpdatedAt:   time.Now(),
+	}
+
+	_, err := db.Upsert(dao.dbName, dao.collectionName, bson.M{"userAddress": addr.Hex()}, n)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Drop drops all the account nonce documents in the current database
+func (dao *AccountNonceDao) Drop() {
+	db.DropCollection(dao.dbName, dao.collectionName)
+}
@@ -0,0 +1,69 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/types"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// AccountRestrictionDao contains:
+// collectionName: MongoDB collection name
+// dbName: name of mongodb to interact with
+type AccountRestrictionDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewAccountRestrictionDao returns a new instance of AccountRestrictionDao
+func NewAccountRestrictionDao() *AccountRestrictionDao {
+	dbName := app.Config.DBName
+	collection := "account_restriction_changes"
+
+	index := mgo.Index{
+		Key: []string{"address", "-createdAt"},
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(index)
+	if err != nil {
+		panic(err)
+	}
+
+	return &AccountRestrictionDao{collection, dbName}
+}
+
+// Create inserts a new restriction change in the audit log
+func (dao *AccountRestrictionDao) Create(c *types.AccountRestrictionChange) error {
+	c.ID = bson.NewObjectId()
+	c.CreatedAt = time.Now()
+
+	err := db.Create(dao.dbName, dao.collectionName, c)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetByAddress returns the restriction changes of an account sorted from the most recent one
+func (dao *AccountRestrictionDao) GetByAddress(a common.Address, limit ...int) ([]*types.AccountRestrictionChange, error) {
+	res := []*types.AccountRestrictionChange{}
+
+	if limit == nil {
+		limit = []int{0}
+	}
+
+	q := bson.M{"address": a.Hex()}
+
+	err := db.GetAndSort(dao.dbName, dao.collectionName, q, []string{"-createdAt"}, 0, limit[0], &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
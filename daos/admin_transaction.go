@@ -0,0 +1,113 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/types"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// AdminTransactionDao contains:
+// collectionName: MongoDB collection name
+// dbName: name of mongodb to interact with
+type AdminTransactionDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewAdminTransactionDao returns a new instance of AdminTransactionDao
+func NewAdminTransactionDao() *AdminTransactionDao {
+	dbName := app.Config.DBName
+	collection := "admin_transactions"
+
+	i1 := mgo.Index{
+		Key:    []string{"txHash"},
+		Unique: true,
+	}
+
+	i2 := mgo.Index{
+		Key: []string{"createdAt"},
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(i1)
+	if err != nil {
+		panic(err)
+	}
+
+	err = db.Session.DB(dbName).C(collection).EnsureIndex(i2)
+	if err != nil {
+		panic(err)
+	}
+
+	return &AdminTransactionDao{collection, dbName}
+}
+
+// Create inserts a new admin transaction in the db
+func (dao *AdminTransactionDao) Create(t *types.AdminTransaction) error {
+	t.ID = bson.NewObjectId()
+	t.CreatedAt = time.Now()
+	t.UpdatedAt = time.Now()
+
+	err := db.Create(dao.dbName, dao.collectionName, t)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateStatus updates the status of a given transaction
+func (dao *AdminTransactionDao) UpdateStatus(h common.Hash, status string) error {
+	query := bson.M{"txHash": h.Hex()}
+	update := bson.M{"$set": bson.M{
+		"status":    status,
+		"updatedAt": time.Now(),
+	}}
+
+	err := db.Update(dao.dbName, dao.collectionName, query, update)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetAll returns the admin transactions sorted from the most recent one
+func (dao *AdminTransactionDao) GetAll(limit ...int) ([]*types.AdminTransaction, error) {
+	res := []*types.AdminTransaction{}
+
+	if limit == nil {
+		limit = []int{0}
+	}
+
+	err := db.GetAndSort(dao.dbName, dao.collectionName, bson.M{}, []string{"-createdAt"}, 0, limit[0], &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetByHash returns the admin transaction corresponding to a given transaction hash
+func (dao *AdminTransactionDao) GetByHash(h common.Hash) (*types.AdminTransaction, error) {
+	res := []*types.AdminTransaction{}
+	q := bson.M{"txHash": h.Hex()}
+
+	err := db.Get(dao.dbName, dao.collectionName, q, 0, 1, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, nil
+	}
+
+	return res[0], nil
+}
@@ -0,0 +1,108 @@
+package daos
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/types"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// APIKeyDao contains:
+// collectionName: MongoDB collection name
+// dbName: name of mongodb to interact with
+type APIKeyDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewAPIKeyDao returns a new instance of APIKeyDao
+func NewAPIKeyDao() *APIKeyDao {
+	dbName := app.Config.DBName
+	collection := "api_keys"
+
+	i1 := mgo.Index{
+		Key:    []string{"key"},
+		Unique: true,
+	}
+
+	i2 := mgo.Index{
+		Key: []string{"userAddress"},
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(i1)
+	if err != nil {
+		panic(err)
+	}
+
+	err = db.Session.DB(dbName).C(collection).EnsureIndex(i2)
+	if err != nil {
+		panic(err)
+	}
+
+	return &APIKeyDao{collection, dbName}
+}
+
+// Create inserts a new API key
+func (dao *APIKeyDao) Create(k *types.APIKey) error {
+	err := db.Create(dao.dbName, dao.collectionName, k)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetByKey returns the API key with a given public key, or nil if there is none
+func (dao *APIKeyDao) GetByKey(key string) (*types.APIKey, error) {
+	var res []*types.APIKey
+
+	err := db.Get(dao.dbName, dao.collectionName, bson.M{"key": key}, 0, 1, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, nil
+	}
+
+	return res[0], nil
+}
+
+// GetByUserAddress returns the API keys of an account, oldest first
+func (dao *APIKeyDao) GetByUserAddress(addr common.Address) ([]*types.APIKey, error) {
+	res := []*types.APIKey{}
+
+	err := db.GetAndSort(dao.dbName, dao.collectionName, bson.M{"userAddress": addr.Hex()}, []string{"createdAt"}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Delete removes and returns an API key of an account, or nil if the account has no such key
+func (dao *APIKeyDao) Delete(addr common.Address, key string) (*types.APIKey, error) {
+	q := bson.M{"userAddress": addr.Hex(), "key": key}
+	res := &types.APIKey{}
+
+	err := db.FindAndModify(dao.dbName, dao.collectionName, q, mgo.Change{Remove: true}, res)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Drop drops all the API keys in the current database
+func (dao *APIKeyDao) Drop() {
+	db.DropCollection(dao.dbName, dao.collectionName)
+}
@@ -0,0 +1,83 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/types"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// AuthChallengeDao contains:
+// collectionName: MongoDB collection name
+// dbName: name of mongodb to interact with
+type AuthChallengeDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewAuthChallengeDao returns a new instance of AuthChallengeDao
+func NewAuthChallengeDao() *AuthChallengeDao {
+	dbName := app.Config.DBName
+	collection := "auth_challenges"
+
+	i1 := mgo.Index{
+		Key:    []string{"address"},
+		Unique: true,
+	}
+
+	// expired challenges are removed by mongodb
+	i2 := mgo.Index{
+		Key:         []string{"expiresAt"},
+		ExpireAfter: time.Second,
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(i1)
+	if err != nil {
+		panic(err)
+	}
+
+	err = db.Session.DB(dbName).C(collection).EnsureIndex(i2)
+	if err != nil {
+		panic(err)
+	}
+
+	return &AuthChallengeDao{collection, dbName}
+}
+
+// Upsert creates or replaces the login challenge of an account
+func (dao *AuthChallengeDao) Upsert(c *types.AuthChallenge) error {
+	_, err := db.Upsert(dao.dbName, dao.collectionName, bson.M{"address": c.Address.Hex()}, c)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Consume removes and returns the login challenge of an account with a given nonce, or nil if
+// there is none. Removing the challenge as it is read prevents it from being used twice
+func (dao *AuthChallengeDao) Consume(addr common.Address, nonce common.Hash) (*types.AuthChallenge, error) {
+	q := bson.M{"address": addr.Hex(), "nonce": nonce.Hex()}
+	res := &types.AuthChallenge{}
+
+	err := db.FindAndModify(dao.dbName, dao.collectionName, q, mgo.Change{Remove: true}, res)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Drop drops all the login challenges in the current database
+func (dao *AuthChallengeDao) Drop() {
+	db.DropCollection(dao.dbName, dao.collectionName)
+}
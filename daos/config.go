@@ -16,6 +16,7 @@ const (
 	ethereumLastBlockKey    = "ethereum_last_block"
 	bitcoinAddressIndexKey  = "bitcoin_address_index"
 	bitcoinLastBlockKey     = "bitcoin_last_block"
+	tomochainLastBlockKey   = "tomochain_last_block"
 	defaultBlockIndex       = 0
 )
 
@@ -124,6 +125,8 @@ func (dao *ConfigDao) GetBlockToProcess(chain types.Chain) (uint64, error) {
 		return dao.getUint64ValueFromKey(ethereumLastBlockKey)
 	case types.ChainBitcoin:
 		return dao.getUint64ValueFromKey(bitcoinLastBlockKey)
+	case types.ChainTomochain:
+		return dao.getUint64ValueFromKey(tomochainLastBlockKey)
 	default:
 		return 0, errors.New("Invalid chain")
 	}
@@ -138,6 +141,8 @@ func (dao *ConfigDao) SaveLastProcessedBlock(chain types.Chain, block uint64) er
 		key = ethereumLastBlockKey
 	case types.ChainBitcoin:
 		key = bitcoinLastBlockKey
+	case types.ChainTomochain:
+		key = tomochainLastBlockKey
 	default:
 		return errors.New("Invalid chain")
 	}
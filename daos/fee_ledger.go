@@ -0,0 +1,159 @@
+package daos
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/types"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// FeeLedgerDao contains:
+// collectionName: MongoDB collection name
+// dbName: name of mongodb to interact with
+type FeeLedgerDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewFeeLedgerDao returns a new instance of FeeLedgerDao
+func NewFeeLedgerDao() *FeeLedgerDao {
+	dbName := app.Config.DBName
+	collection := "fee_ledger"
+
+	i1 := mgo.Index{
+		Key:    []string{"tradeHash"},
+		Unique: true,
+	}
+
+	i2 := mgo.Index{
+		Key: []string{"settledAt"},
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(i1)
+	if err != nil {
+		panic(err)
+	}
+
+	err = db.Session.DB(dbName).C(collection).EnsureIndex(i2)
+	if err != nil {
+		panic(err)
+	}
+
+	return &FeeLedgerDao{collection, dbName}
+}
+
+// Upsert records the fees of a trade. A trade is only recorded once, even if its
+// settlement is reported several times, and the first record is left unchanged
+func (dao *FeeLedgerDao) Upsert(e *types.FeeLedgerEntry) error {
+	query := bson.M{"tradeHash": e.TradeHash.Hex()}
+	update := bson.M{"$setOnInsert": e}
+
+	_, err := db.Upsert(dao.dbName, dao.collectionName, query, update)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetByTradeHash returns the fees recorded for a trade, or nil if the trade was not settled
+func (dao *FeeLedgerDao) GetByTradeHash(h common.Hash) (*types.FeeLedgerEntry, error) {
+	res := []*types.FeeLedgerEntry{}
+	q := bson.M{"tradeHash": h.Hex()}
+
+	err := db.Get(dao.dbName, dao.collectionName, q, 0, 1, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, nil
+	}
+
+	return res[0], nil
+}
+
+// GetDailyRevenues returns the fees of the trades settled between two dates, summed by
+// day (UTC), pair and fee token
+func (dao *FeeLedgerDao) GetDailyRevenues(from, to time.Time) ([]*types.FeeRevenue, error) {
+	res := []map[string]string{}
+
+	q := []bson.M{
+		bson.M{
+			"$match": bson.M{
+				"settledAt": bson.M{"$gte": from, "$lt": to},
+			},
+		},
+		bson.M{
+			"$group": bson.M{
+				"_id": bson.M{
+					"date":     bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": "$settledAt"}},
+					"pairName": "$pairName",
+					"feeToken": "$feeToken",
+				},
+				"makeFees":   bson.M{"$sum": bson.M{"$toDecimal": "$makeFee"}},
+				"takeFees":   bson.M{"$sum": bson.M{"$toDecimal": "$takeFee"}},
+				"tradeCount": bson.M{"$sum": 1},
+			},
+		},
+		bson.M{
+			"$sort": bson.D{{Name: "_id.date", Value: 1}, {Name: "_id.pairName", Value: 1}},
+		},
+		bson.M{
+			"$project": bson.M{
+				"_id":        0,
+				"date":       "$_id.date",
+				"pairName":   "$_id.pairName",
+				"feeToken":   "$_id.feeToken",
+				"makeFees":   bson.M{"$toString": "$makeFees"},
+				"takeFees":   bson.M{"$toString": "$takeFees"},
+				"tradeCount": bson.M{"$toString": "$tradeCount"},
+			},
+		},
+	}
+
+	err := db.Aggregate(dao.dbName, dao.collectionName, q, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	revenues := []*types.FeeRevenue{}
+	for _, r := range res {
+		makeFees, err := parseDecimal(r["makeFees"])
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		takeFees, err := parseDecimal(r["takeFees"])
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		count, _ := strconv.Atoi(r["tradeCount"])
+
+		revenues = append(revenues, &types.FeeRevenue{
+			Date:       r["date"],
+			PairName:   r["pairName"],
+			FeeToken:   common.HexToAddress(r["feeToken"]),
+			MakeFees:   makeFees,
+			TakeFees:   takeFees,
+			TradeCount: count,
+		})
+	}
+
+	return revenues, nil
+}
+
+// Drop drops all the fee ledger documents in the current database
+func (dao *FeeLedgerDao) Drop() {
+	db.DropCollection(dao.dbName, dao.collectionName)
+}
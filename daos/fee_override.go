@@ -0,0 +1,102 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/types"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// FeeOverrideDao contains:
+// collectionName: MongoDB collection name
+// dbName: name of mongodb to interact with
+type FeeOverrideDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewFeeOverrideDao returns a new instance of FeeOverrideDao
+func NewFeeOverrideDao() *FeeOverrideDao {
+	dbName := app.Config.DBName
+	collection := "fee_overrides"
+
+	index := mgo.Index{
+		Key:    []string{"userAddress", "quoteToken"},
+		Unique: true,
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(index)
+	if err != nil {
+		panic(err)
+	}
+
+	return &FeeOverrideDao{collection, dbName}
+}
+
+// GetByUserAddress returns the fee overrides of an account
+func (dao *FeeOverrideDao) GetByUserAddress(addr common.Address) ([]*types.FeeOverride, error) {
+	res := []*types.FeeOverride{}
+	q := bson.M{"userAddress": addr.Hex()}
+
+	err := db.Get(dao.dbName, dao.collectionName, q, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetByQuoteToken returns the fee override of an account for a quote token, or nil if it has none
+func (dao *FeeOverrideDao) GetByQuoteToken(addr, quoteToken common.Address) (*types.FeeOverride, error) {
+	res := []*types.FeeOverride{}
+	q := bson.M{"userAddress": addr.Hex(), "quoteToken": quoteToken.Hex()}
+
+	err := db.Get(dao.dbName, dao.collectionName, q, 0, 1, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, nil
+	}
+
+	return res[0], nil
+}
+
+// Upsert creates or replaces the fee override of an account for a quote token
+func (dao *FeeOverrideDao) Upsert(o *types.FeeOverride) error {
+	o.UpdatedAt = time.Now()
+
+	q := bson.M{"userAddress": o.UserAddress.Hex(), "quoteToken": o.QuoteToken.Hex()}
+
+	_, err := db.Upsert(dao.dbName, dao.collectionName, q, o)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Delete removes the fee override of an account for a quote token
+func (dao *FeeOverrideDao) Delete(addr, quoteToken common.Address) error {
+	q := bson.M{"userAddress": addr.Hex(), "quoteToken": quoteToken.Hex()}
+
+	err := db.RemoveAll(dao.dbName, dao.collectionName, q)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Drop drops all the fee override documents in the current database
+func (dao *FeeOverrideDao) Drop() {
+	db.DropCollection(dao.dbName, dao.collectionName)
+}
@@ -0,0 +1,97 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/types"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// FeeScheduleDao contains:
+// collectionName: MongoDB collection name
+// dbName: name of mongodb to interact with
+type FeeScheduleDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewFeeScheduleDao returns a new instance of FeeScheduleDao
+func NewFeeScheduleDao() *FeeScheduleDao {
+	dbName := app.Config.DBName
+	collection := "fee_schedules"
+
+	index := mgo.Index{
+		Key:    []string{"quoteToken"},
+		Unique: true,
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(index)
+	if err != nil {
+		panic(err)
+	}
+
+	return &FeeScheduleDao{collection, dbName}
+}
+
+// GetAll returns the fee schedules of all the quote tokens
+func (dao *FeeScheduleDao) GetAll() ([]*types.FeeSchedule, error) {
+	res := []*types.FeeSchedule{}
+
+	err := db.Get(dao.dbName, dao.collectionName, bson.M{}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetByQuoteToken returns the fee schedule of a quote token, or nil if it has none
+func (dao *FeeScheduleDao) GetByQuoteToken(quoteToken common.Address) (*types.FeeSchedule, error) {
+	res := []*types.FeeSchedule{}
+	q := bson.M{"quoteToken": quoteToken.Hex()}
+
+	err := db.Get(dao.dbName, dao.collectionName, q, 0, 1, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, nil
+	}
+
+	return res[0], nil
+}
+
+// Upsert creates or replaces the fee schedule of a quote token
+func (dao *FeeScheduleDao) Upsert(s *types.FeeSchedule) error {
+	s.UpdatedAt = time.Now()
+
+	_, err := db.Upsert(dao.dbName, dao.collectionName, bson.M{"quoteToken": s.QuoteToken.Hex()}, s)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteByQuoteToken removes the fee schedule of a quote token
+func (dao *FeeScheduleDao) DeleteByQuoteToken(quoteToken common.Address) error {
+	err := db.RemoveAll(dao.dbName, dao.collectionName, bson.M{"quoteToken": quoteToken.Hex()})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Drop drops all the fee schedule documents in the current database
+func (dao *FeeScheduleDao) Drop() {
+	db.DropCollection(dao.dbName, dao.collectionName)
+}
@@ -0,0 +1,168 @@
+package daos
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/math"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// GasTopUpDao contains:
+// collectionName: MongoDB collection name
+// dbName: name of mongodb to interact with
+type GasTopUpDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewGasTopUpDao returns a new instance of GasTopUpDao
+func NewGasTopUpDao() *GasTopUpDao {
+	dbName := app.Config.DBName
+	collection := "gas_topups"
+
+	i1 := mgo.Index{
+		Key: []string{"wallet", "createdAt"},
+	}
+
+	i2 := mgo.Index{
+		Key:    []string{"txHash"},
+		Sparse: true,
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(i1)
+	if err != nil {
+		panic(err)
+	}
+
+	err = db.Session.DB(dbName).C(collection).EnsureIndex(i2)
+	if err != nil {
+		panic(err)
+	}
+
+	return &GasTopUpDao{collection, dbName}
+}
+
+// Create inserts a new top-up entry in the ledger
+func (dao *GasTopUpDao) Create(t *types.GasTopUp) error {
+	t.ID = bson.NewObjectId()
+	t.CreatedAt = time.Now()
+	t.UpdatedAt = time.Now()
+
+	err := db.Create(dao.dbName, dao.collectionName, t)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateStatus updates the status of the top-up sent in the given transaction
+func (dao *GasTopUpDao) UpdateStatus(h common.Hash, status string) error {
+	query := bson.M{"txHash": h.Hex()}
+	update := bson.M{"$set": bson.M{
+		"status":    status,
+		"updatedAt": time.Now(),
+	}}
+
+	err := db.Update(dao.dbName, dao.collectionName, query, update)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateTransaction sets the transaction hash and the status of the top-up with the given id.
+// The hash is left unset when the transfer could not be sent
+func (dao *GasTopUpDao) UpdateTransaction(id bson.ObjectId, h common.Hash, status string) error {
+	query := bson.M{"_id": id}
+	fields := bson.M{
+		"status":    status,
+		"updatedAt": time.Now(),
+	}
+
+	if (h != common.Hash{}) {
+		fields["txHash"] = h.Hex()
+	}
+
+	update := bson.M{"$set": fields}
+
+	err := db.Update(dao.dbName, dao.collectionName, query, update)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetAll returns the ledger sorted from the most recent top-up
+func (dao *GasTopUpDao) GetAll(limit ...int) ([]*types.GasTopUp, error) {
+	res := []*types.GasTopUp{}
+
+	if limit == nil {
+		limit = []int{0}
+	}
+
+	err := db.GetAndSort(dao.dbName, dao.collectionName, bson.M{}, []string{"-createdAt"}, 0, limit[0], &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetByWalletAddress returns the top-ups sent to a given operator wallet
+func (dao *GasTopUpDao) GetByWalletAddress(a common.Address, limit ...int) ([]*types.GasTopUp, error) {
+	res := []*types.GasTopUp{}
+	q := bson.M{"wallet": a.Hex()}
+
+	if limit == nil {
+		limit = []int{0}
+	}
+
+	err := db.GetAndSort(dao.dbName, dao.collectionName, q, []string{"-createdAt"}, 0, limit[0], &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetTotalSince returns the amount sent since the given time, excluding failed
+// top-ups. If a wallet address is given, only the top-ups to this wallet are counted
+func (dao *GasTopUpDao) GetTotalSince(since time.Time, wallet ...common.Address) (*big.Int, error) {
+	res := []*types.GasTopUp{}
+	q := bson.M{
+		"createdAt": bson.M{"$gte": since},
+		"status":    bson.M{"$ne": types.FAILED},
+	}
+
+	if len(wallet) > 0 {
+		q["wallet"] = wallet[0].Hex()
+	}
+
+	err := db.Get(dao.dbName, dao.collectionName, q, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	total := big.NewInt(0)
+	for _, t := range res {
+		if t.Amount != nil {
+			total = math.Add(total, t.Amount)
+		}
+	}
+
+	return total, nil
+}
@@ -0,0 +1,73 @@
+package daos
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/types"
+)
+
+func TestGasTopUpDao(t *testing.T) {
+	dao := NewGasTopUpDao()
+	err := db.DropCollection(dao.dbName, dao.collectionName)
+	if err != nil {
+		t.Errorf("Could not drop previous gas top-up collection: %v", err)
+	}
+
+	wallet := common.HexToAddress("0xe8e84ee367bc63ddb38d3d01bccef106c194dc47")
+	funder := common.HexToAddress("0x6e6BB166F420DDd682cAEbf55dAfBaFda74f2c9c")
+	since := time.Now().Add(-time.Minute)
+
+	t1 := &types.GasTopUp{
+		Wallet:        wallet,
+		Funder:        funder,
+		Amount:        big.NewInt(1e18),
+		BalanceBefore: big.NewInt(1e17),
+		TxHash:        common.HexToHash("0x1"),
+		Status:        types.PENDING,
+	}
+
+	t2 := &types.GasTopUp{
+		Wallet:        wallet,
+		Funder:        funder,
+		Amount:        big.NewInt(2e18),
+		BalanceBefore: big.NewInt(1e17),
+		TxHash:        common.HexToHash("0x2"),
+		Status:        types.PENDING,
+	}
+
+	err = dao.Create(t1)
+	if err != nil {
+		t.Errorf("Could not create gas top-up: %v", err)
+	}
+
+	err = dao.Create(t2)
+	if err != nil {
+		t.Errorf("Could not create gas top-up: %v", err)
+	}
+
+	all, err := dao.GetByWalletAddress(wallet)
+	if err != nil {
+		t.Errorf("Could not get gas top-ups: %v", err)
+	}
+
+	if len(all) != 2 {
+		t.Errorf("Expected 2 gas top-ups, got %v", len(all))
+	}
+
+	err = dao.UpdateStatus(t2.TxHash, types.FAILED)
+	if err != nil {
+		t.Errorf("Could not update gas top-up status: %v", err)
+	}
+
+	total, err := dao.GetTotalSince(since, wallet)
+	if err != nil {
+		t.Errorf("Could not get gas top-up total: %v", err)
+	}
+
+	if total.Cmp(big.NewInt(1e18)) != 0 {
+		t.Errorf("Expected total of %v, got %v", big.NewInt(1e18), total)
+	}
+}
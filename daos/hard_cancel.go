@@ -0,0 +1,138 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/types"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// HardCancelDao contains:
+// collectionName: MongoDB collection name
+// dbName: name of mongodb to interact with
+type HardCancelDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewHardCancelDao returns a new instance of HardCancelDao
+func NewHardCancelDao() *HardCancelDao {
+	dbName := app.Config.DBName
+	collection := "hard_cancels"
+
+	i1 := mgo.Index{
+		Key:    []string{"orderHash"},
+		Unique: true,
+	}
+
+	i2 := mgo.Index{
+		Key: []string{"status"},
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(i1)
+	if err != nil {
+		panic(err)
+	}
+
+	err = db.Session.DB(dbName).C(collection).EnsureIndex(i2)
+	if err != nil {
+		panic(err)
+	}
+
+	return &HardCancelDao{collection, dbName}
+}
+
+// Queue records an order waiting to be cancelled on the exchange contract. A failed
+// hard cancel of the same order is replaced
+func (dao *HardCancelDao) Queue(o *types.Order) error {
+	query := bson.M{"orderHash": o.Hash.Hex()}
+	update := bson.M{
+		"$set": bson.M{
+			"order":     o,
+			"status":    types.HARD_CANCEL_QUEUED,
+			"txHash":    common.Hash{}.Hex(),
+			"updatedAt": time.Now(),
+		},
+		"$setOnInsert": bson.M{
+			"_id":       bson.NewObjectId(),
+			"createdAt": time.Now(),
+		},
+	}
+
+	_, err := db.Upsert(dao.dbName, dao.collectionName, query, update)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateStatus updates the status of the hard cancels of the given orders. The transaction
+// hash is left unchanged if it is empty
+func (dao *HardCancelDao) UpdateStatus(hashes []common.Hash, status string, txHash common.Hash) error {
+	hexes := []string{}
+	for _, h := range hashes {
+		hexes = append(hexes, h.Hex())
+	}
+
+	set := bson.M{
+		"status":    status,
+		"updatedAt": time.Now(),
+	}
+
+	if txHash != (common.Hash{}) {
+		set["txHash"] = txHash.Hex()
+	}
+
+	query := bson.M{"orderHash": bson.M{"$in": hexes}}
+	update := bson.M{"$set": set}
+
+	err := db.UpdateAll(dao.dbName, dao.collectionName, query, update)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetByOrderHash returns the hard cancel of an order, or nil if the order was never hard cancelled
+func (dao *HardCancelDao) GetByOrderHash(h common.Hash) (*types.HardCancel, error) {
+	res := []*types.HardCancel{}
+	q := bson.M{"orderHash": h.Hex()}
+
+	err := db.Get(dao.dbName, dao.collectionName, q, 0, 1, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, nil
+	}
+
+	return res[0], nil
+}
+
+// GetPending returns the hard cancels that were queued or sent but not confirmed
+func (dao *HardCancelDao) GetPending() ([]*types.HardCancel, error) {
+	res := []*types.HardCancel{}
+	q := bson.M{"status": bson.M{"$in": []string{types.HARD_CANCEL_QUEUED, types.HARD_CANCEL_SENT}}}
+
+	err := db.GetAndSort(dao.dbName, dao.collectionName, q, []string{"createdAt"}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Drop drops all the hard cancels in the current hard_cancels collection
+func (dao *HardCancelDao) Drop() {
+	db.DropCollection(dao.dbName, dao.collectionName)
+}
@@ -0,0 +1,105 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/types"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// HeldDepositDao contains:
+// collectionName: MongoDB collection name
+// dbName: name of mongodb to interact with
+type HeldDepositDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewHeldDepositDao returns a new instance of HeldDepositDao
+func NewHeldDepositDao() *HeldDepositDao {
+	dbName := app.Config.DBName
+	collection := "held_deposits"
+
+	i1 := mgo.Index{
+		Key:    []string{"chain", "transaction.transactionid"},
+		Unique: true,
+	}
+
+	i2 := mgo.Index{
+		Key: []string{"associatedAddress", "status"},
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(i1)
+	if err != nil {
+		panic(err)
+	}
+
+	err = db.Session.DB(dbName).C(collection).EnsureIndex(i2)
+	if err != nil {
+		panic(err)
+	}
+
+	return &HeldDepositDao{collection, dbName}
+}
+
+// Hold records a held deposit. A deposit transaction reported several times is only recorded once
+func (dao *HeldDepositDao) Hold(d *types.HeldDeposit) error {
+	d.ID = bson.NewObjectId()
+	d.CreatedAt = time.Now()
+	d.UpdatedAt = time.Now()
+
+	query := bson.M{"chain": d.Chain, "transaction.transactionid": d.Transaction.TransactionID}
+	update := bson.M{"$setOnInsert": d}
+
+	_, err := db.Upsert(dao.dbName, dao.collectionName, query, update)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetByAssociatedAddress returns the held deposits of an account from the oldest one. If a status
+// is given, only the deposits with this status are returned
+func (dao *HeldDepositDao) GetByAssociatedAddress(addr common.Address, status ...string) ([]*types.HeldDeposit, error) {
+	res := []*types.HeldDeposit{}
+	q := bson.M{"associatedAddress": addr.Hex()}
+
+	if len(status) > 0 {
+		q["status"] = status[0]
+	}
+
+	err := db.GetAndSort(dao.dbName, dao.collectionName, q, []string{"createdAt"}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// UpdateStatus updates the status of a held deposit
+func (dao *HeldDepositDao) UpdateStatus(id bson.ObjectId, status string) error {
+	query := bson.M{"_id": id}
+	update := bson.M{"$set": bson.M{
+		"status":    status,
+		"updatedAt": time.Now(),
+	}}
+
+	err := db.Update(dao.dbName, dao.collectionName, query, update)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Drop drops all the held deposits in the current database
+func (dao *HeldDepositDao) Drop() {
+	db.DropCollection(dao.dbName, dao.collectionName)
+}
@@ -1 +1,15 @@
 package daos
+
+import "math/big"
+
+// parseDecimal converts a mongodb decimal formatted as a string to a big integer. Large
+// decimals are formatted with an exponent and the fractional part is truncated
+func parseDecimal(s string) (*big.Int, error) {
+	f, _, err := big.ParseFloat(s, 10, 256, big.ToZero)
+	if err != nil {
+		return nil, err
+	}
+
+	i, _ := f.Int(nil)
+	return i, nil
+}
@@ -59,6 +59,10 @@ func NewOrderDao(opts ...OrderDaoOption) *OrderDao {
 		Key: []string{"quoteToken"},
 	}
 
+	i5 := mgo.Index{
+		Key: []string{"userAddress", "-createdAt", "-_id"},
+	}
+
 	err := db.Session.DB(dao.dbName).C(dao.collectionName).EnsureIndex(index)
 	if err != nil {
 		panic(err)
@@ -79,6 +83,11 @@ func NewOrderDao(opts ...OrderDaoOption) *OrderDao {
 		panic(err)
 	}
 
+	err = db.Session.DB(dao.dbName).C(dao.collectionName).EnsureIndex(i5)
+	if err != nil {
+		panic(err)
+	}
+
 	return dao
 }
 
@@ -448,6 +457,26 @@ func (dao *OrderDao) GetCurrentByUserAddress(addr common.Address, limit ...int)
 	return res, nil
 }
 
+// GetOpenOrders fetches the open and partially filled orders of all users
+func (dao *OrderDao) GetOpenOrders() ([]*types.Order, error) {
+	var res []*types.Order
+	q := bson.M{
+		"status": bson.M{"$in": []string{
+			"OPEN",
+			"PARTIAL_FILLED",
+		},
+		},
+	}
+
+	err := db.Get(dao.dbName, dao.collectionName, q, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
 // GetHistoryByUserAddress function fetches list of orders which are not in open/partial order status
 // from order collection based on user address.
 // Returns array of Order type struct
@@ -475,6 +504,48 @@ func (dao *OrderDao) GetHistoryByUserAddress(addr common.Address, limit ...int)
 	return res, nil
 }
 
+// GetPage returns a page of orders matching a history filter, sorted from the most recent
+// one, and the cursor of the next page, which is nil for the last page
+func (dao *OrderDao) GetPage(f *types.HistoryFilter) ([]*types.Order, *types.Cursor, error) {
+	q := bson.M{}
+	if (f.UserAddress != common.Address{}) {
+		q["userAddress"] = f.UserAddress.Hex()
+	}
+
+	return dao.getPage(q, f)
+}
+
+// GetHistoryPage returns a page of the orders matching a history filter that are not open
+// anymore, and the cursor of the next page
+func (dao *OrderDao) GetHistoryPage(f *types.HistoryFilter) ([]*types.Order, *types.Cursor, error) {
+	q := bson.M{"status": bson.M{"$nin": []string{"OPEN", "PARTIAL_FILLED"}}}
+	if (f.UserAddress != common.Address{}) {
+		q["userAddress"] = f.UserAddress.Hex()
+	}
+
+	return dao.getPage(q, f)
+}
+
+func (dao *OrderDao) getPage(q bson.M, f *types.HistoryFilter) ([]*types.Order, *types.Cursor, error) {
+	res := []*types.Order{}
+	limit := f.PageLimit()
+
+	// one more order is fetched to know if there is a next page
+	err := db.GetAndSort(dao.dbName, dao.collectionName, historyQuery(q, f), pageSort, 0, limit+1, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	if len(res) <= limit {
+		return res, nil, nil
+	}
+
+	res = res[:limit]
+	last := res[limit-1]
+	return res, types.NewCursor(last.CreatedAt, last.ID), nil
+}
+
 func (dao *OrderDao) GetUserLockedBalance(account common.Address, token common.Address, p *types.Pair) (*big.Int, error) {
 	var orders []*types.Order
 
@@ -0,0 +1,73 @@
+package daos
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/types"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// OrderEventDao contains:
+// collectionName: MongoDB collection name
+// dbName: name of mongodb to interact with
+type OrderEventDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewOrderEventDao returns a new instance of OrderEventDao
+func NewOrderEventDao() *OrderEventDao {
+	dbName := app.Config.DBName
+	collection := "order_events"
+
+	index := mgo.Index{
+		Key: []string{"orderHash", "createdAt"},
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(index)
+	if err != nil {
+		panic(err)
+	}
+
+	return &OrderEventDao{collection, dbName}
+}
+
+// Create inserts order events
+func (dao *OrderEventDao) Create(events ...*types.OrderEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	docs := []interface{}{}
+	for _, e := range events {
+		docs = append(docs, e)
+	}
+
+	err := db.Create(dao.dbName, dao.collectionName, docs...)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetByOrderHash returns the events of an order from the oldest one
+func (dao *OrderEventDao) GetByOrderHash(h common.Hash) ([]*types.OrderEvent, error) {
+	res := []*types.OrderEvent{}
+	q := bson.M{"orderHash": h.Hex()}
+
+	err := db.GetAndSort(dao.dbName, dao.collectionName, q, []string{"createdAt", "_id"}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Drop drops all the order events in the current database
+func (dao *OrderEventDao) Drop() {
+	db.DropCollection(dao.dbName, dao.collectionName)
+}
@@ -0,0 +1,106 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/types"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// OrderLockDao contains:
+// collectionName: MongoDB collection name
+// dbName: name of mongodb to interact with
+type OrderLockDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewOrderLockDao returns a new instance of OrderLockDao
+func NewOrderLockDao() *OrderLockDao {
+	dbName := app.Config.DBName
+	collection := "order_locks"
+
+	i1 := mgo.Index{
+		Key:    []string{"orderHash"},
+		Unique: true,
+	}
+
+	i2 := mgo.Index{
+		Key: []string{"userAddress", "token"},
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(i1)
+	if err != nil {
+		panic(err)
+	}
+
+	err = db.Session.DB(dbName).C(collection).EnsureIndex(i2)
+	if err != nil {
+		panic(err)
+	}
+
+	return &OrderLockDao{collection, dbName}
+}
+
+// GetAll returns all the order locks
+func (dao *OrderLockDao) GetAll() ([]*types.OrderLock, error) {
+	res := []*types.OrderLock{}
+
+	err := db.Get(dao.dbName, dao.collectionName, bson.M{}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetByHash returns the lock of the order corresponding to a given hash
+func (dao *OrderLockDao) GetByHash(h common.Hash) (*types.OrderLock, error) {
+	res := []*types.OrderLock{}
+	q := bson.M{"orderHash": h.Hex()}
+
+	err := db.Get(dao.dbName, dao.collectionName, q, 0, 1, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, nil
+	}
+
+	return res[0], nil
+}
+
+// Upsert creates or replaces the lock of an order
+func (dao *OrderLockDao) Upsert(l *types.OrderLock) error {
+	l.UpdatedAt = time.Now()
+
+	_, err := db.Upsert(dao.dbName, dao.collectionName, bson.M{"orderHash": l.OrderHash.Hex()}, l)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteByHash removes the lock of the order corresponding to a given hash
+func (dao *OrderLockDao) DeleteByHash(h common.Hash) error {
+	err := db.RemoveAll(dao.dbName, dao.collectionName, bson.M{"orderHash": h.Hex()})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Drop drops all the order lock documents in the current database
+func (dao *OrderLockDao) Drop() {
+	db.DropCollection(dao.dbName, dao.collectionName)
+}
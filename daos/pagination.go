@@ -0,0 +1,50 @@
+package daos
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/types"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// pageSort is the stable sort order of the pages of orders and trades
+var pageSort = []string{"-createdAt", "-_id"}
+
+// historyQuery combines a query on the orders or trades collection with the pair, side,
+// status, time range and cursor conditions of a history filter
+func historyQuery(q bson.M, f *types.HistoryFilter) bson.M {
+	conditions := []bson.M{q}
+
+	if (f.BaseToken != common.Address{}) {
+		conditions = append(conditions, bson.M{
+			"baseToken":  f.BaseToken.Hex(),
+			"quoteToken": f.QuoteToken.Hex(),
+		})
+	}
+
+	if f.Side != "" {
+		conditions = append(conditions, bson.M{"side": f.Side})
+	}
+
+	if f.Status != "" {
+		conditions = append(conditions, bson.M{"status": f.Status})
+	}
+
+	if !f.From.IsZero() {
+		conditions = append(conditions, bson.M{"createdAt": bson.M{"$gte": f.From}})
+	}
+
+	if !f.To.IsZero() {
+		conditions = append(conditions, bson.M{"createdAt": bson.M{"$lt": f.To}})
+	}
+
+	if f.Cursor != nil {
+		conditions = append(conditions, bson.M{
+			"$or": []bson.M{
+				{"createdAt": bson.M{"$lt": f.Cursor.CreatedAt}},
+				{"createdAt": f.Cursor.CreatedAt, "_id": bson.M{"$lt": f.Cursor.ID}},
+			},
+		})
+	}
+
+	return bson.M{"$and": conditions}
+}
@@ -200,3 +200,24 @@ func (dao *PairDao) GetByTokenAddress(baseToken, quoteToken common.Address) (*ty
 
 	return res[0], nil
 }
+
+// SetActive updates the active flag of the pair corresponding to the given tokens
+func (dao *PairDao) SetActive(bt, qt common.Address, active bool) error {
+	q := bson.M{
+		"baseTokenAddress":  bt.Hex(),
+		"quoteTokenAddress": qt.Hex(),
+	}
+
+	update := bson.M{"$set": bson.M{
+		"active":    active,
+		"updatedAt": time.Now(),
+	}}
+
+	err := db.Update(dao.dbName, dao.collectionName, q, update)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/types"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ReferralDao contains:
+// collectionName: MongoDB collection name
+// dbName: name of mongodb to interact with
+type ReferralDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewReferralDao returns a new instance of ReferralDao
+func NewReferralDao() *ReferralDao {
+	dbName := app.Config.DBName
+	collection := "referrals"
+
+	i1 := mgo.Index{
+		Key:    []string{"refereeAddress"},
+		Unique: true,
+	}
+
+	i2 := mgo.Index{
+		Key: []string{"referrerAddress"},
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(i1)
+	if err != nil {
+		panic(err)
+	}
+
+	err = db.Session.DB(dbName).C(collection).EnsureIndex(i2)
+	if err != nil {
+		panic(err)
+	}
+
+	return &ReferralDao{collection, dbName}
+}
+
+// Create inserts a new referral
+func (dao *ReferralDao) Create(r *types.Referral) error {
+	r.CreatedAt = time.Now()
+
+	err := db.Create(dao.dbName, dao.collectionName, r)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetByReferee returns the referral of a referee, or nil if it was not referred
+func (dao *ReferralDao) GetByReferee(addr common.Address) (*types.Referral, error) {
+	res := []*types.Referral{}
+	q := bson.M{"refereeAddress": addr.Hex()}
+
+	err := db.Get(dao.dbName, dao.collectionName, q, 0, 1, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, nil
+	}
+
+	return res[0], nil
+}
+
+// GetByReferrer returns the referrals of a referrer sorted from the most recent one
+func (dao *ReferralDao) GetByReferrer(addr common.Address) ([]*types.Referral, error) {
+	res := []*types.Referral{}
+	q := bson.M{"referrerAddress": addr.Hex()}
+
+	err := db.GetAndSort(dao.dbName, dao.collectionName, q, []string{"-createdAt"}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Drop drops all the referral documents in the current database
+func (dao *ReferralDao) Drop() {
+	db.DropCollection(dao.dbName, dao.collectionName)
+}
@@ -0,0 +1,192 @@
+package daos
+
+import (
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/types"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ReferralRebateDao contains:
+// collectionName: MongoDB collection name
+// dbName: name of mongodb to interact with
+type ReferralRebateDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewReferralRebateDao returns a new instance of ReferralRebateDao
+func NewReferralRebateDao() *ReferralRebateDao {
+	dbName := app.Config.DBName
+	collection := "referral_rebates"
+
+	i1 := mgo.Index{
+		Key:    []string{"tradeHash"},
+		Unique: true,
+	}
+
+	i2 := mgo.Index{
+		Key: []string{"referrerAddress", "-createdAt"},
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(i1)
+	if err != nil {
+		panic(err)
+	}
+
+	err = db.Session.DB(dbName).C(collection).EnsureIndex(i2)
+	if err != nil {
+		panic(err)
+	}
+
+	return &ReferralRebateDao{collection, dbName}
+}
+
+// Upsert records the rebate of a trade. A trade is only accrued once, even if its
+// settlement is reported several times
+func (dao *ReferralRebateDao) Upsert(r *types.ReferralRebate) error {
+	r.CreatedAt = time.Now()
+
+	_, err := db.Upsert(dao.dbName, dao.collectionName, bson.M{"tradeHash": r.TradeHash.Hex()}, r)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetByReferrer returns the rebates accrued to a referrer sorted from the most recent one
+func (dao *ReferralRebateDao) GetByReferrer(addr common.Address, limit ...int) ([]*types.ReferralRebate, error) {
+	res := []*types.ReferralRebate{}
+
+	if limit == nil {
+		limit = []int{0}
+	}
+
+	q := bson.M{"referrerAddress": addr.Hex()}
+
+	err := db.GetAndSort(dao.dbName, dao.collectionName, q, []string{"-createdAt"}, 0, limit[0], &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetBalances returns the rebates accrued to a referrer summed by quote token
+func (dao *ReferralRebateDao) GetBalances(addr common.Address) (map[common.Address]*big.Int, error) {
+	res := []map[string]string{}
+
+	q := []bson.M{
+		bson.M{
+			"$match": bson.M{"referrerAddress": addr.Hex()},
+		},
+		bson.M{
+			"$group": bson.M{
+				"_id":    "$quoteToken",
+				"amount": bson.M{"$sum": bson.M{"$toDecimal": "$amount"}},
+			},
+		},
+		bson.M{
+			"$project": bson.M{
+				"_id":        0,
+				"quoteToken": "$_id",
+				"amount":     bson.M{"$toString": "$amount"},
+			},
+		},
+	}
+
+	err := db.Aggregate(dao.dbName, dao.collectionName, q, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	balances := map[common.Address]*big.Int{}
+	for _, r := range res {
+		amount, err := parseDecimal(r["amount"])
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		balances[common.HexToAddress(r["quoteToken"])] = amount
+	}
+
+	return balances, nil
+}
+
+// GetRefereeSummaries returns the volume traded by each referee of a referrer and the rebates
+// its trades earned, by quote token
+func (dao *ReferralRebateDao) GetRefereeSummaries(addr common.Address) ([]*types.ReferralSummary, error) {
+	res := []map[string]string{}
+
+	q := []bson.M{
+		bson.M{
+			"$match": bson.M{"referrerAddress": addr.Hex()},
+		},
+		bson.M{
+			"$group": bson.M{
+				"_id":        bson.M{"refereeAddress": "$refereeAddress", "quoteToken": "$quoteToken"},
+				"volume":     bson.M{"$sum": bson.M{"$toDecimal": "$volume"}},
+				"rebates":    bson.M{"$sum": bson.M{"$toDecimal": "$amount"}},
+				"tradeCount": bson.M{"$sum": 1},
+			},
+		},
+		bson.M{
+			"$project": bson.M{
+				"_id":            0,
+				"refereeAddress": "$_id.refereeAddress",
+				"quoteToken":     "$_id.quoteToken",
+				"volume":         bson.M{"$toString": "$volume"},
+				"rebates":        bson.M{"$toString": "$rebates"},
+				"tradeCount":     bson.M{"$toString": "$tradeCount"},
+			},
+		},
+	}
+
+	err := db.Aggregate(dao.dbName, dao.collectionName, q, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	summaries := []*types.ReferralSummary{}
+	for _, r := range res {
+		volume, err := parseDecimal(r["volume"])
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		rebates, err := parseDecimal(r["rebates"])
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		count, _ := strconv.Atoi(r["tradeCount"])
+
+		summaries = append(summaries, &types.ReferralSummary{
+			RefereeAddress: common.HexToAddress(r["refereeAddress"]),
+			QuoteToken:     common.HexToAddress(r["quoteToken"]),
+			Volume:         volume,
+			Rebates:        rebates,
+			TradeCount:     count,
+		})
+	}
+
+	return summaries, nil
+}
+
+// Drop drops all the referral rebate documents in the current database
+func (dao *ReferralRebateDao) Drop() {
+	db.DropCollection(dao.dbName, dao.collectionName)
+}
@@ -1,6 +1,7 @@
 package daos
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -54,6 +55,18 @@ func NewTradeDao() *TradeDao {
 		Key: []string{"createdAt", "status", "baseToken", "quoteToken"},
 	}
 
+	i8 := mgo.Index{
+		Key: []string{"maker", "-createdAt", "-_id"},
+	}
+
+	i9 := mgo.Index{
+		Key: []string{"taker", "-createdAt", "-_id"},
+	}
+
+	i10 := mgo.Index{
+		Key: []string{"baseToken", "quoteToken", "-createdAt", "-_id"},
+	}
+
 	err := db.Session.DB(dbName).C(collection).EnsureIndex(i1)
 	if err != nil {
 		panic(err)
@@ -89,6 +102,21 @@ func NewTradeDao() *TradeDao {
 		panic(err)
 	}
 
+	err = db.Session.DB(dbName).C(collection).EnsureIndex(i8)
+	if err != nil {
+		panic(err)
+	}
+
+	err = db.Session.DB(dbName).C(collection).EnsureIndex(i9)
+	if err != nil {
+		panic(err)
+	}
+
+	err = db.Session.DB(dbName).C(collection).EnsureIndex(i10)
+	if err != nil {
+		panic(err)
+	}
+
 	return &TradeDao{collection, dbName}
 }
 
@@ -259,17 +287,21 @@ func (dao *TradeDao) GetByPairName(name string) ([]*types.Trade, error) {
 	return res, nil
 }
 
-// GetByHash fetches the first record that matches a certain hash
+// GetByHash fetches the first record that matches a certain hash, or nil if there is none
 func (dao *TradeDao) GetByHash(h common.Hash) (*types.Trade, error) {
 	q := bson.M{"hash": h.Hex()}
 
 	res := []*types.Trade{}
-	err := db.Get(dao.dbName, dao.collectionName, q, 0, 0, &res)
+	err := db.Get(dao.dbName, dao.collectionName, q, 0, 1, &res)
 	if err != nil {
 		logger.Error(err)
 		return nil, err
 	}
 
+	if len(res) == 0 {
+		return nil, nil
+	}
+
 	return res[0], nil
 }
 
@@ -300,6 +332,20 @@ func (dao *TradeDao) GetByTakerOrderHash(h common.Hash) ([]*types.Trade, error)
 	return res, nil
 }
 
+// GetByStatus returns the trades having a given status
+func (dao *TradeDao) GetByStatus(status string) ([]*types.Trade, error) {
+	q := bson.M{"status": status}
+
+	res := []*types.Trade{}
+	err := db.Get(dao.dbName, dao.collectionName, q, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
 func (dao *TradeDao) GetByOrderHashes(hashes []common.Hash) ([]*types.Trade, error) {
 	hexes := []string{}
 	for _, h := range hashes {
@@ -384,6 +430,33 @@ func (dao *TradeDao) GetSortedTradesByUserAddress(a common.Address, limit ...int
 	return res, nil
 }
 
+// GetPage returns a page of trades matching a history filter, sorted from the most recent
+// one, and the cursor of the next page, which is nil for the last page
+func (dao *TradeDao) GetPage(f *types.HistoryFilter) ([]*types.Trade, *types.Cursor, error) {
+	res := []*types.Trade{}
+	limit := f.PageLimit()
+
+	q := bson.M{}
+	if (f.UserAddress != common.Address{}) {
+		q["$or"] = []bson.M{{"maker": f.UserAddress.Hex()}, {"taker": f.UserAddress.Hex()}}
+	}
+
+	// one more trade is fetched to know if there is a next page
+	err := db.GetAndSort(dao.dbName, dao.collectionName, historyQuery(q, f), pageSort, 0, limit+1, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	if len(res) <= limit {
+		return res, nil, nil
+	}
+
+	res = res[:limit]
+	last := res[limit-1]
+	return res, types.NewCursor(last.CreatedAt, last.ID), nil
+}
+
 // GetByUserAddress fetches all the trades corresponding to a particular user address.
 func (dao *TradeDao) GetByUserAddress(a common.Address) ([]*types.Trade, error) {
 	var res []*types.Trade
@@ -398,6 +471,60 @@ func (dao *TradeDao) GetByUserAddress(a common.Address) ([]*types.Trade, error)
 	return res, nil
 }
 
+// GetUserVolumes returns the sum of amount * pricepoint of the pending and successful trades
+// of an account in a quote token since a given time, grouped by base token. The sums are
+// approximated to the precision of mongodb decimals
+func (dao *TradeDao) GetUserVolumes(a, quoteToken common.Address, since time.Time) (map[common.Address]*big.Int, error) {
+	res := []map[string]string{}
+
+	q := []bson.M{
+		bson.M{
+			"$match": bson.M{
+				"$or":        []bson.M{{"maker": a.Hex()}, {"taker": a.Hex()}},
+				"quoteToken": quoteToken.Hex(),
+				"status":     bson.M{"$in": []string{types.PENDING, types.SUCCESS}},
+				"createdAt":  bson.M{"$gte": since},
+			},
+		},
+		bson.M{
+			"$group": bson.M{
+				"_id": "$baseToken",
+				"volume": bson.M{
+					"$sum": bson.M{
+						"$multiply": []bson.M{bson.M{"$toDecimal": "$amount"}, bson.M{"$toDecimal": "$pricepoint"}},
+					},
+				},
+			},
+		},
+		bson.M{
+			"$project": bson.M{
+				"_id":       0,
+				"baseToken": "$_id",
+				"volume":    bson.M{"$toString": "$volume"},
+			},
+		},
+	}
+
+	err := db.Aggregate(dao.dbName, dao.collectionName, q, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	volumes := map[common.Address]*big.Int{}
+	for _, r := range res {
+		volume, err := parseDecimal(r["volume"])
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		volumes[common.HexToAddress(r["baseToken"])] = volume
+	}
+
+	return volumes, nil
+}
+
 func (dao *TradeDao) UpdateTradeStatus(h common.Hash, status string) error {
 	query := bson.M{"hash": h.Hex()}
 	update := bson.M{"$set": bson.M{"status": status}}
@@ -411,6 +538,25 @@ func (dao *TradeDao) UpdateTradeStatus(h common.Hash, status string) error {
 	return nil
 }
 
+// UpdateTradeSettlementError sets the trade status to ERROR and records the reason
+// why its on-chain settlement failed
+func (dao *TradeDao) UpdateTradeSettlementError(h common.Hash, e *types.SettlementError) error {
+	query := bson.M{"hash": h.Hex()}
+	update := bson.M{"$set": bson.M{
+		"status":          "ERROR",
+		"settlementError": e,
+		"updatedAt":       time.Now(),
+	}}
+
+	err := db.Update(dao.dbName, dao.collectionName, query, update)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
 func (dao *TradeDao) UpdateTradeStatuses(status string, hashes ...common.Hash) ([]*types.Trade, error) {
 	hexes := []string{}
 	for _, h := range hashes {
@@ -105,3 +105,17 @@ func (dao *WalletDao) GetOperatorWallets() ([]*types.Wallet, error) {
 
 	return res, nil
 }
+
+// SetOperator updates the operator flag of the wallet corresponding to a given address
+func (dao *WalletDao) SetOperator(a common.Address, isOperator bool) error {
+	q := bson.M{"address": a.Hex()}
+	update := bson.M{"$set": bson.M{"operator": isOperator}}
+
+	err := db.Update(dao.dbName, dao.collectionName, q, update)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
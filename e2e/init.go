@@ -74,21 +74,29 @@ func NewRouter() *mux.Router {
 	tradeDao := daos.NewTradeDao()
 	accountDao := daos.NewAccountDao()
 	walletDao := daos.NewWalletDao()
+	orderLockDao := daos.NewOrderLockDao()
+	accountNonceDao := daos.NewAccountNonceDao()
+	accountRestrictionDao := daos.NewAccountRestrictionDao()
+	feeScheduleDao := daos.NewFeeScheduleDao()
+	feeOverrideDao := daos.NewFeeOverrideDao()
+	feeLedgerDao := daos.NewFeeLedgerDao()
+	referralDao := daos.NewReferralDao()
+	referralRebateDao := daos.NewReferralRebateDao()
+	orderEventDao := daos.NewOrderEventDao()
+	authChallengeDao := daos.NewAuthChallengeDao()
+	apiKeyDao := daos.NewAPIKeyDao()
+	hardCancelDao := daos.NewHardCancelDao()
 
 	// instantiate engine
 	eng := engine.NewEngine(rabbitConn, orderDao, tradeDao, pairDao, provider)
 
 	// get services for injection
-	accountService := services.NewAccountService(accountDao, tokenDao)
+	accountService := services.NewAccountService(accountDao, tokenDao, accountRestrictionDao)
 	ohlcvService := services.NewOHLCVService(tradeDao)
 	tokenService := services.NewTokenService(tokenDao)
 	tradeService := services.NewTradeService(tradeDao)
 	pairService := services.NewPairService(pairDao, tokenDao, tradeDao, eng)
-	validatorService := services.NewValidatorService(provider, accountDao, orderDao, pairDao)
-	orderService := services.NewOrderService(orderDao, pairDao, accountDao, tradeDao, eng, validatorService, rabbitConn)
-	orderBookService := services.NewOrderBookService(pairDao, tokenDao, orderDao, eng)
 	walletService := services.NewWalletService(walletDao)
-	cronService := crons.NewCronService(ohlcvService)
 
 	// get exchange contract instance
 	exchangeAddress := common.HexToAddress(app.Config.Ethereum["exchange_address"])
@@ -102,6 +110,23 @@ func NewRouter() *mux.Router {
 		panic(err)
 	}
 
+	// token balances and allowances are cached from the ERC20 logs
+	balanceService := services.NewBalanceService(accountDao, tokenDao, provider, provider.Client)
+	go balanceService.Start()
+
+	// locked balances are maintained as the orders are updated and recomputed every night
+	lockedBalanceService := services.NewLockedBalanceService(orderDao, pairDao, accountDao, orderLockDao)
+	go lockedBalanceService.Recompute()
+
+	feeService := services.NewFeeService(feeScheduleDao, feeOverrideDao, tradeDao, pairDao, tokenDao, feeLedgerDao)
+	referralService := services.NewReferralService(referralDao, referralRebateDao, pairDao)
+	authService := services.NewAuthService(authChallengeDao)
+	apiKeyService := services.NewAPIKeyService(apiKeyDao)
+	validatorService := services.NewValidatorService(balanceService, lockedBalanceService, accountDao, pairDao, exchange)
+	orderService := services.NewOrderService(orderDao, pairDao, accountDao, tradeDao, accountNonceDao, orderEventDao, eng, validatorService, lockedBalanceService, feeService, referralService, rabbitConn)
+	orderBookService := services.NewOrderBookService(pairDao, tokenDao, orderDao, eng)
+	cronService := crons.NewCronService(ohlcvService, nil, nil, lockedBalanceService)
+
 	// deploy operator
 	op, err := operator.NewOperator(
 		walletService,
@@ -109,6 +134,7 @@ func NewRouter() *mux.Router {
 		orderService,
 		provider,
 		exchange,
+		hardCancelDao,
 		rabbitConn,
 	)
 
@@ -116,14 +142,19 @@ func NewRouter() *mux.Router {
 		panic(err)
 	}
 
+	// requests are authenticated with a JWT or with the signature of an API key
+	r.Use(endpoints.Authenticator(apiKeyService))
+
 	// deploy http and ws endpoints
-	endpoints.ServeAccountResource(r, accountService)
+	endpoints.ServeAccountResource(r, accountService, authService)
 	endpoints.ServeTokenResource(r, tokenService)
 	endpoints.ServePairResource(r, pairService)
 	endpoints.ServeOrderBookResource(r, orderBookService)
 	endpoints.ServeOHLCVResource(r, ohlcvService)
 	endpoints.ServeTradeResource(r, tradeService)
-	endpoints.ServeOrderResource(r, orderService, accountService)
+	endpoints.ServeOrderResource(r, orderService, accountService, authService, nil)
+	endpoints.ServeAuthResource(r, authService)
+	endpoints.ServeAPIKeyResource(r, apiKeyService)
 
 	//initialize rabbitmq subscriptions
 	rabbitConn.SubscribeOrders(eng.HandleOrders)
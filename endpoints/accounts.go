@@ -1,28 +1,37 @@
 package endpoints
 
 import (
+	"encoding/json"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
 	"github.com/tomochain/dex-server/interfaces"
 	"github.com/tomochain/dex-server/types"
 	"github.com/tomochain/dex-server/utils/httputils"
+	"github.com/tomochain/dex-server/ws"
 )
 
 type accountEndpoint struct {
 	accountService interfaces.AccountService
+	authService    interfaces.AuthService
 }
 
 func ServeAccountResource(
 	r *mux.Router,
 	accountService interfaces.AccountService,
+	authService interfaces.AuthService,
 ) {
 
-	e := &accountEndpoint{accountService}
-	r.HandleFunc("/account/create", e.handleCreateAccount).Methods("POST")
+	e := &accountEndpoint{accountService, authService}
+	r.HandleFunc("/account/create", authenticated(e.handleCreateAccount)).Methods("POST")
 	r.HandleFunc("/account/{address}", e.handleGetAccount).Methods("GET")
 	r.HandleFunc("/account/{address}/{token}", e.handleGetAccountTokenBalance).Methods("GET")
+	r.HandleFunc("/admin/accounts/{address}/restriction", adminOnly(e.handleGetAccountRestriction)).Methods("GET")
+	r.HandleFunc("/admin/accounts/{address}/restriction", adminOnly(e.handleSetAccountRestriction)).Methods("POST")
+	ws.RegisterChannel(ws.AccountChannel, e.ws)
 }
 
 func (e *accountEndpoint) handleCreateAccount(w http.ResponseWriter, r *http.Request) {
@@ -35,6 +44,11 @@ func (e *accountEndpoint) handleCreateAccount(w http.ResponseWriter, r *http.Req
 	}
 
 	a := common.HexToAddress(addr)
+	if !canActAs(r, a) {
+		httputils.WriteError(w, http.StatusForbidden, "Accounts can only be created by their owner")
+		return
+	}
+
 	existingAccount, err := e.accountService.GetByAddress(a)
 	if err != nil {
 		logger.Error(err)
@@ -102,3 +116,144 @@ func (e *accountEndpoint) handleGetAccountTokenBalance(w http.ResponseWriter, r
 
 	httputils.WriteJSON(w, http.StatusOK, b)
 }
+
+func (e *accountEndpoint) handleGetAccountRestriction(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	v := r.URL.Query()
+
+	addr := vars["address"]
+	if !common.IsHexAddress(addr) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+		return
+	}
+
+	limit := 0
+	if v.Get("limit") != "" {
+		limit, _ = strconv.Atoi(v.Get("limit"))
+	}
+
+	address := common.HexToAddress(addr)
+	a, err := e.accountService.GetByAddress(address)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	history, err := e.accountService.GetRestrictionHistory(address, limit)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := map[string]interface{}{
+		"address": address.Hex(),
+		"level":   a.RestrictionLevel(),
+		"history": history,
+	}
+
+	if a != nil && a.Restriction != nil {
+		res["restriction"] = a.Restriction
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+func (e *accountEndpoint) handleSetAccountRestriction(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	addr := vars["address"]
+	if !common.IsHexAddress(addr) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+		return
+	}
+
+	payload := &struct {
+		Level     string    `json:"level"`
+		Reason    string    `json:"reason"`
+		ExpiresAt time.Time `json:"expiresAt"`
+	}{}
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(payload)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+
+	defer r.Body.Close()
+
+	restriction := &types.AccountRestriction{
+		Level:     payload.Level,
+		Reason:    payload.Reason,
+		ExpiresAt: payload.ExpiresAt,
+	}
+
+	err = restriction.Validate()
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	a, err := e.accountService.SetRestriction(common.HexToAddress(addr), restriction, adminSource(r))
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, a)
+}
+
+// ws handles the incoming websocket messages on the account channel
+func (e *accountEndpoint) ws(input interface{}, c *ws.Client) {
+	msg := &types.WebsocketEvent{}
+
+	bytes, _ := json.Marshal(input)
+	if err := json.Unmarshal(bytes, &msg); err != nil {
+		logger.Error(err)
+		c.SendMessage(ws.AccountChannel, types.ERROR, err.Error())
+		return
+	}
+
+	switch msg.Type {
+	case types.SUBSCRIBE:
+		e.handleSubscribe(msg, c)
+	case types.UNSUBSCRIBE:
+		ws.UnregisterAccountConnections(c)
+	default:
+		c.SendMessage(ws.AccountChannel, types.ERROR, "Invalid message type")
+	}
+}
+
+// handleSubscribe subscribes a client to the token balance changes of an account, once it proved the
+// ownership of the account. The current token balances are sent back, and every change of a balance,
+// allowance or locked balance is then pushed as an UPDATE
+func (e *accountEndpoint) handleSubscribe(ev *types.WebsocketEvent, c *ws.Client) {
+	a, err := authenticateSubscription(e.authService, c, ev.Payload)
+	if err != nil {
+		c.SendMessage(ws.AccountChannel, types.ERROR, err.Error())
+		return
+	}
+
+	// registering before reading the balances guarantees that no change is missed
+	ws.RegisterAccountConnection(a.Address, c)
+
+	balances, err := e.accountService.GetTokenBalances(a.Address)
+	if err != nil {
+		logger.Error(err)
+		c.SendMessage(ws.AccountChannel, types.ERROR, "Could not get the token balances")
+		return
+	}
+
+	tokenBalances := []*types.TokenBalance{}
+	for _, b := range balances {
+		tokenBalances = append(tokenBalances, b)
+	}
+
+	c.SendMessage(ws.AccountChannel, types.INIT, map[string]interface{}{
+		"address":       a.Address.Hex(),
+		"tokenBalances": tokenBalances,
+	})
+}
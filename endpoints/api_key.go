@@ -0,0 +1,124 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tomochain/dex-server/interfaces"
+	"github.com/tomochain/dex-server/services"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/httputils"
+)
+
+type apiKeyEndpoint struct {
+	apiKeyService interfaces.APIKeyService
+}
+
+// ServeAPIKeyResource sets up the routing of the endpoints managing the API keys of the
+// authenticated account. API keys can only be created and revoked with a JWT
+func ServeAPIKeyResource(
+	r *mux.Router,
+	apiKeyService interfaces.APIKeyService,
+) {
+
+	e := &apiKeyEndpoint{apiKeyService}
+	r.HandleFunc("/api-keys", authenticated(e.handleGetAPIKeys)).Methods("GET")
+	r.HandleFunc("/api-keys", authenticated(e.handleCreateAPIKey)).Methods("POST")
+	r.HandleFunc("/api-keys/{key}", authenticated(e.handleRevokeAPIKey)).Methods("DELETE")
+}
+
+func (e *apiKeyEndpoint) handleGetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	a := httputils.GetAuthentication(r)
+
+	keys, err := e.apiKeyService.GetByUserAddress(a.Address)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, keys)
+}
+
+// handleCreateAPIKey creates an API key of the authenticated account. The secret of the key is
+// only returned in the response of this request
+func (e *apiKeyEndpoint) handleCreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	a := httputils.GetAuthentication(r)
+	if a.APIKey != "" {
+		httputils.WriteError(w, http.StatusForbidden, "API keys can not be created with an API key")
+		return
+	}
+
+	payload := &struct {
+		Label      string   `json:"label"`
+		Scope      string   `json:"scope"`
+		AllowedIPs []string `json:"allowedIps"`
+	}{}
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(payload)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+
+	defer r.Body.Close()
+
+	k := &types.APIKey{
+		UserAddress: a.Address,
+		Label:       payload.Label,
+		Scope:       payload.Scope,
+		AllowedIPs:  payload.AllowedIPs,
+	}
+
+	err = k.Validate()
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	secret, err := e.apiKeyService.Create(k)
+	if err != nil {
+		switch err {
+		case services.ErrTooManyAPIKeys:
+			httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		case types.ErrAPIKeysDisabled:
+			httputils.WriteError(w, http.StatusServiceUnavailable, err.Error())
+		default:
+			logger.Error(err)
+			httputils.WriteError(w, http.StatusInternalServerError, "")
+		}
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusCreated, map[string]interface{}{
+		"apiKey": k,
+		"secret": secret,
+	})
+}
+
+func (e *apiKeyEndpoint) handleRevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	a := httputils.GetAuthentication(r)
+	if a.APIKey != "" {
+		httputils.WriteError(w, http.StatusForbidden, "API keys can not be revoked with an API key")
+		return
+	}
+
+	vars := mux.Vars(r)
+	key := vars["key"]
+
+	err := e.apiKeyService.Revoke(a.Address, key)
+	if err != nil {
+		switch err {
+		case services.ErrAPIKeyNotFound:
+			httputils.WriteError(w, http.StatusNotFound, err.Error())
+		default:
+			logger.Error(err)
+			httputils.WriteError(w, http.StatusInternalServerError, "")
+		}
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, map[string]string{"key": key})
+}
@@ -0,0 +1,93 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	"github.com/tomochain/dex-server/interfaces"
+	"github.com/tomochain/dex-server/services"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/httputils"
+)
+
+type authEndpoint struct {
+	authService interfaces.AuthService
+}
+
+// ServeAuthResource sets up the routing of the login endpoints. An account logs in by signing
+// the nonce of a challenge and receives a JWT to send in the Authorization header of the
+// authenticated requests
+func ServeAuthResource(
+	r *mux.Router,
+	authService interfaces.AuthService,
+) {
+
+	e := &authEndpoint{authService}
+	r.HandleFunc("/auth/challenge", e.handleGetChallenge).Methods("GET")
+	r.HandleFunc("/auth/login", e.handleLogin).Methods("POST")
+}
+
+func (e *authEndpoint) handleGetChallenge(w http.ResponseWriter, r *http.Request) {
+	v := r.URL.Query()
+	addr := v.Get("address")
+
+	if addr == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "address Parameter missing")
+		return
+	}
+
+	if !common.IsHexAddress(addr) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+		return
+	}
+
+	c, err := e.authService.NewChallenge(common.HexToAddress(addr))
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, c)
+}
+
+func (e *authEndpoint) handleLogin(w http.ResponseWriter, r *http.Request) {
+	l := &types.AuthLogin{}
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(l)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+
+	defer r.Body.Close()
+
+	err = l.Validate()
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	token, claims, err := e.authService.Login(l)
+	if err != nil {
+		switch err {
+		case services.ErrInvalidSignature, services.ErrInvalidAuthChallenge:
+			httputils.WriteError(w, http.StatusUnauthorized, err.Error())
+		default:
+			logger.Error(err)
+			httputils.WriteError(w, http.StatusInternalServerError, "")
+		}
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"token":     token,
+		"address":   claims.Address().Hex(),
+		"role":      claims.Role,
+		"expiresAt": time.Unix(claims.ExpiresAt, 0).Format(time.RFC3339),
+	})
+}
@@ -29,6 +29,7 @@ type depositEndpoint struct {
 	depositService interfaces.DepositService
 	walletService  interfaces.WalletService
 	txService      interfaces.TxService
+	accountService interfaces.AccountService
 }
 
 func ServeDepositResource(
@@ -36,13 +37,16 @@ func ServeDepositResource(
 	depositService interfaces.DepositService,
 	walletService interfaces.WalletService,
 	txService interfaces.TxService,
+	accountService interfaces.AccountService,
 ) {
 
-	e := &depositEndpoint{depositService, walletService, txService}
+	e := &depositEndpoint{depositService, walletService, txService, accountService}
 	r.HandleFunc("/deposit/schema", e.handleGetSchema).Methods("GET")
-	r.HandleFunc("/deposit/generate-address", e.handleGenerateAddress).Methods("POST")
+	r.HandleFunc("/deposit/generate-address", scoped(types.API_KEY_SCOPE_TRADE, e.handleGenerateAddress)).Methods("POST")
 	r.HandleFunc("/deposit/history", e.handleGetHistory).Methods("GET")
 	r.HandleFunc("/deposit/recovery-transaction", e.handleRecoveryTransaction).Methods("GET")
+	r.HandleFunc("/admin/deposits/{address}/held", adminOnly(e.handleGetHeldDeposits)).Methods("GET")
+	r.HandleFunc("/admin/deposits/{address}/release", adminOnly(e.handleReleaseHeldDeposits)).Methods("POST")
 
 	// r.HandleFunc("/deposit/testws", e.handleTestWS).Methods("GET")
 
@@ -152,6 +156,16 @@ func (e *depositEndpoint) handleGenerateAddress(w http.ResponseWriter, r *http.R
 	}
 
 	associatedAddress := common.HexToAddress(addr)
+	if !canActAs(r, associatedAddress) {
+		httputils.WriteError(w, http.StatusForbidden, "Deposit addresses can only be generated by their owner")
+		return
+	}
+
+	err = e.checkAccountRestriction(associatedAddress)
+	if err != nil {
+		httputils.WriteError(w, http.StatusForbidden, err.Error())
+		return
+	}
 
 	var chain types.Chain
 	err = chain.Scan([]byte(chainStr))
@@ -236,6 +250,54 @@ func (e *depositEndpoint) handleRecoveryTransaction(w http.ResponseWriter, r *ht
 	httputils.WriteJSON(w, http.StatusOK, association)
 }
 
+func (e *depositEndpoint) handleGetHeldDeposits(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	addr := vars["address"]
+	if !common.IsHexAddress(addr) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+		return
+	}
+
+	held, err := e.depositService.GetHeldTransactions(common.HexToAddress(addr))
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, held)
+}
+
+// handleReleaseHeldDeposits credits the held deposits of an account once its restriction
+// level allows deposits again
+func (e *depositEndpoint) handleReleaseHeldDeposits(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	addr := vars["address"]
+	if !common.IsHexAddress(addr) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+		return
+	}
+
+	address := common.HexToAddress(addr)
+	err := e.checkAccountRestriction(address)
+	if err != nil {
+		httputils.WriteError(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	released, err := e.depositService.ReleaseHeldTransactions(address)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	logger.Infof("Released %v held deposits of %v (%v)", len(released), address.Hex(), adminSource(r))
+	httputils.WriteJSON(w, http.StatusOK, released)
+}
+
 // ws function handles incoming websocket messages on the order channel
 func (e *depositEndpoint) ws(input interface{}, c *ws.Client) {
 	msg := &types.WebsocketEvent{}
@@ -275,6 +337,12 @@ func (e *depositEndpoint) handleNewDeposit(ev *types.WebsocketEvent, c *ws.Clien
 		return
 	}
 
+	err = e.checkAccountRestriction(o.AssociatedAddress)
+	if err != nil {
+		c.SendMessage(ws.DepositChannel, types.ERROR, err.Error())
+		return
+	}
+
 	associationAddress, _ := e.depositService.GetAssociationByChainAssociatedAddress(o.Chain, o.AssociatedAddress)
 	var addressStr string
 
@@ -330,7 +398,14 @@ func (e *depositEndpoint) processNewTransaction(queueTx *types.DepositTransactio
 		return nil
 	}
 
-	err := e.processTransaction(addressAssociation)
+	// the deposits sent by restricted accounts are held until an admin releases them
+	err := e.checkAccountRestriction(common.HexToAddress(addressAssociation.AssociatedAddress))
+	if err != nil {
+		logger.Warningf("Holding deposit %v of %v: %v", queueTx.TransactionID, addressAssociation.AssociatedAddress, err)
+		return e.depositService.HoldTransaction(queueTx, addressAssociation)
+	}
+
+	err = e.processTransaction(addressAssociation)
 	if err != nil {
 		return err
 	}
@@ -348,6 +423,17 @@ func (e *depositEndpoint) processNewTransaction(queueTx *types.DepositTransactio
 	return nil
 }
 
+// checkAccountRestriction returns an error if the restriction level of an account does not allow deposits
+func (e *depositEndpoint) checkAccountRestriction(addr common.Address) error {
+	acc, err := e.accountService.GetByAddress(addr)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return acc.CheckRestriction(types.ACCOUNT_ACTION_DEPOSIT)
+}
+
 /***** events from engine ****/
 
 // onNewBitcoinTransaction checks if transaction is valid and adds it to
@@ -0,0 +1,260 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	"github.com/tomochain/dex-server/interfaces"
+	"github.com/tomochain/dex-server/services"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/httputils"
+	"github.com/tomochain/dex-server/utils/math"
+)
+
+type feeEndpoint struct {
+	feeService interfaces.FeeService
+}
+
+// ServeFeeResource sets up the routing of the admin endpoints managing the fee schedules
+// of the quote tokens and the fee overrides of the accounts, and reporting the fee revenue
+func ServeFeeResource(
+	r *mux.Router,
+	feeService interfaces.FeeService,
+) {
+
+	e := &feeEndpoint{feeService}
+	r.HandleFunc("/admin/fees/schedules", adminOnly(e.handleGetFeeSchedules)).Methods("GET")
+	r.HandleFunc("/admin/fees/schedules", adminOnly(e.handleSetFeeSchedule)).Methods("POST")
+	r.HandleFunc("/admin/fees/schedules/{quoteToken}", adminOnly(e.handleDeleteFeeSchedule)).Methods("DELETE")
+	r.HandleFunc("/admin/fees/overrides/{address}", adminOnly(e.handleGetFeeOverrides)).Methods("GET")
+	r.HandleFunc("/admin/fees/overrides/{address}", adminOnly(e.handleSetFeeOverride)).Methods("POST")
+	r.HandleFunc("/admin/fees/overrides/{address}/{quoteToken}", adminOnly(e.handleDeleteFeeOverride)).Methods("DELETE")
+	r.HandleFunc("/admin/fees/revenue", adminOnly(e.handleGetFeeRevenue)).Methods("GET")
+}
+
+func (e *feeEndpoint) handleGetFeeSchedules(w http.ResponseWriter, r *http.Request) {
+	schedules, err := e.feeService.GetSchedules()
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, schedules)
+}
+
+func (e *feeEndpoint) handleSetFeeSchedule(w http.ResponseWriter, r *http.Request) {
+	s := &types.FeeSchedule{}
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(s)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+
+	defer r.Body.Close()
+
+	err = s.Validate()
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = e.feeService.SetSchedule(s)
+	if err != nil {
+		writeFeeError(w, err)
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, s)
+}
+
+func (e *feeEndpoint) handleDeleteFeeSchedule(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	quoteToken := vars["quoteToken"]
+	if !common.IsHexAddress(quoteToken) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Quote Token Address")
+		return
+	}
+
+	err := e.feeService.DeleteSchedule(common.HexToAddress(quoteToken))
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, map[string]string{"quoteToken": quoteToken})
+}
+
+func (e *feeEndpoint) handleGetFeeOverrides(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	addr := vars["address"]
+	if !common.IsHexAddress(addr) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+		return
+	}
+
+	overrides, err := e.feeService.GetOverrides(common.HexToAddress(addr))
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, overrides)
+}
+
+func (e *feeEndpoint) handleSetFeeOverride(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	addr := vars["address"]
+	if !common.IsHexAddress(addr) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+		return
+	}
+
+	payload := &struct {
+		QuoteToken string    `json:"quoteToken"`
+		MakeFee    string    `json:"makeFee"`
+		TakeFee    string    `json:"takeFee"`
+		Reason     string    `json:"reason"`
+		ExpiresAt  time.Time `json:"expiresAt"`
+	}{}
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(payload)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+
+	defer r.Body.Close()
+
+	if !common.IsHexAddress(payload.QuoteToken) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Quote Token Address")
+		return
+	}
+
+	o := &types.FeeOverride{
+		UserAddress: common.HexToAddress(addr),
+		QuoteToken:  common.HexToAddress(payload.QuoteToken),
+		Reason:      payload.Reason,
+		ExpiresAt:   payload.ExpiresAt,
+	}
+
+	if payload.MakeFee != "" {
+		o.MakeFee = math.ToBigInt(payload.MakeFee)
+	}
+
+	if payload.TakeFee != "" {
+		o.TakeFee = math.ToBigInt(payload.TakeFee)
+	}
+
+	err = o.Validate()
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = e.feeService.SetOverride(o)
+	if err != nil {
+		writeFeeError(w, err)
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, o)
+}
+
+func (e *feeEndpoint) handleDeleteFeeOverride(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	addr := vars["address"]
+	if !common.IsHexAddress(addr) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+		return
+	}
+
+	quoteToken := vars["quoteToken"]
+	if !common.IsHexAddress(quoteToken) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Quote Token Address")
+		return
+	}
+
+	err := e.feeService.DeleteOverride(common.HexToAddress(addr), common.HexToAddress(quoteToken))
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, map[string]string{"address": addr, "quoteToken": quoteToken})
+}
+
+// handleGetFeeRevenue returns the fees of the trades settled between two timestamps (the last
+// 30 days by default) by day, pair and fee token, as JSON or as CSV if the format is csv
+func (e *feeEndpoint) handleGetFeeRevenue(w http.ResponseWriter, r *http.Request) {
+	v := r.URL.Query()
+	now := time.Now()
+
+	to := now
+	if v.Get("to") != "" {
+		t, err := strconv.ParseInt(v.Get("to"), 10, 64)
+		if err != nil {
+			httputils.WriteError(w, http.StatusBadRequest, "Invalid to Parameter")
+			return
+		}
+
+		to = time.Unix(t, 0)
+	}
+
+	from := now.AddDate(0, 0, -30)
+	if v.Get("from") != "" {
+		f, err := strconv.ParseInt(v.Get("from"), 10, 64)
+		if err != nil {
+			httputils.WriteError(w, http.StatusBadRequest, "Invalid from Parameter")
+			return
+		}
+
+		from = time.Unix(f, 0)
+	}
+
+	revenues, err := e.feeService.GetDailyRevenues(from, to)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	if v.Get("format") == "csv" {
+		records := [][]string{types.FeeRevenueCSVHeader()}
+		for _, rev := range revenues {
+			records = append(records, rev.CSVRecord())
+		}
+
+		filename := "fees-" + from.UTC().Format("20060102") + "-" + to.UTC().Format("20060102") + ".csv"
+		httputils.WriteCSV(w, http.StatusOK, filename, records)
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, revenues)
+}
+
+func writeFeeError(w http.ResponseWriter, err error) {
+	switch err {
+	case services.ErrQuoteTokenNotFound:
+		httputils.WriteError(w, http.StatusBadRequest, "Quote token not found")
+	case services.ErrQuoteTokenInvalid:
+		httputils.WriteError(w, http.StatusBadRequest, "Quote token invalid (token is not registered as quote)")
+	default:
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+	}
+}
@@ -0,0 +1,78 @@
+package endpoints
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	"github.com/tomochain/dex-server/interfaces"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/httputils"
+)
+
+type gasTopUpEndpoint struct {
+	gasTopUpService interfaces.GasTopUpService
+}
+
+// ServeGasTopUpResource sets up the routing of the operator wallets top-up endpoints
+func ServeGasTopUpResource(
+	r *mux.Router,
+	gasTopUpService interfaces.GasTopUpService,
+) {
+
+	e := &gasTopUpEndpoint{gasTopUpService}
+	r.HandleFunc("/admin/gas-topups", adminOnly(e.handleGetGasTopUps)).Methods("GET")
+	r.HandleFunc("/admin/gas-topups", adminOnly(e.handleTopUpOperatorWallets)).Methods("POST")
+}
+
+// handleGetGasTopUps returns the top-up ledger, optionally filtered by operator wallet address
+func (e *gasTopUpEndpoint) handleGetGasTopUps(w http.ResponseWriter, r *http.Request) {
+	v := r.URL.Query()
+	addr := v.Get("address")
+	limit := v.Get("limit")
+
+	lim := 0
+	if limit != "" {
+		lim, _ = strconv.Atoi(limit)
+	}
+
+	var err error
+	var res []*types.GasTopUp
+
+	if addr == "" {
+		res, err = e.gasTopUpService.GetAll(lim)
+	} else {
+		if !common.IsHexAddress(addr) {
+			httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+			return
+		}
+
+		res, err = e.gasTopUpService.GetByWalletAddress(common.HexToAddress(addr), lim)
+	}
+
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	if res == nil {
+		httputils.WriteJSON(w, http.StatusOK, []types.GasTopUp{})
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+// handleTopUpOperatorWallets triggers a top-up run without waiting for the cron
+func (e *gasTopUpEndpoint) handleTopUpOperatorWallets(w http.ResponseWriter, r *http.Request) {
+	res, err := e.gasTopUpService.TopUpOperatorWallets()
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusCreated, res)
+}
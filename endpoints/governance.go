@@ -0,0 +1,247 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	"github.com/tomochain/dex-server/interfaces"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/httputils"
+)
+
+type governanceEndpoint struct {
+	governanceService interfaces.GovernanceService
+}
+
+// ServeGovernanceResource sets up the routing of the exchange contract administration endpoints
+func ServeGovernanceResource(
+	r *mux.Router,
+	governanceService interfaces.GovernanceService,
+) {
+
+	e := &governanceEndpoint{governanceService}
+	r.HandleFunc("/admin/exchange", adminOnly(e.handleGetExchange)).Methods("GET")
+	r.HandleFunc("/admin/exchange/fee-account", adminOnly(e.handleSetFeeAccount)).Methods("POST")
+	r.HandleFunc("/admin/exchange/owner", adminOnly(e.handleSetOwner)).Methods("POST")
+	r.HandleFunc("/admin/exchange/weth-token", adminOnly(e.handleSetWethToken)).Methods("POST")
+	r.HandleFunc("/admin/exchange/operators", adminOnly(e.handleSetOperator)).Methods("POST")
+	r.HandleFunc("/admin/exchange/operators/{address}", adminOnly(e.handleGetOperator)).Methods("GET")
+	r.HandleFunc("/admin/exchange/pairs", adminOnly(e.handleRegisterPair)).Methods("POST")
+	r.HandleFunc("/admin/transactions", adminOnly(e.handleGetTransactions)).Methods("GET")
+	r.HandleFunc("/admin/transactions/{hash}", adminOnly(e.handleGetTransaction)).Methods("GET")
+}
+
+func (e *governanceEndpoint) handleGetExchange(w http.ResponseWriter, r *http.Request) {
+	res, err := e.governanceService.GetExchangeInfo()
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+func (e *governanceEndpoint) handleSetFeeAccount(w http.ResponseWriter, r *http.Request) {
+	a, ok := decodeAddress(w, r, "feeAccount")
+	if !ok {
+		return
+	}
+
+	tx, err := e.governanceService.SetFeeAccount(a)
+	writeAdminTransaction(w, tx, err)
+}
+
+func (e *governanceEndpoint) handleSetOwner(w http.ResponseWriter, r *http.Request) {
+	a, ok := decodeAddress(w, r, "owner")
+	if !ok {
+		return
+	}
+
+	tx, err := e.governanceService.SetOwner(a)
+	writeAdminTransaction(w, tx, err)
+}
+
+func (e *governanceEndpoint) handleSetWethToken(w http.ResponseWriter, r *http.Request) {
+	a, ok := decodeAddress(w, r, "wethToken")
+	if !ok {
+		return
+	}
+
+	tx, err := e.governanceService.SetWethToken(a)
+	writeAdminTransaction(w, tx, err)
+}
+
+func (e *governanceEndpoint) handleSetOperator(w http.ResponseWriter, r *http.Request) {
+	payload := &struct {
+		Operator   string `json:"operator"`
+		IsOperator *bool  `json:"isOperator"`
+	}{}
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(payload)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+
+	defer r.Body.Close()
+
+	if !common.IsHexAddress(payload.Operator) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid operator address")
+		return
+	}
+
+	if payload.IsOperator == nil {
+		httputils.WriteError(w, http.StatusBadRequest, "isOperator parameter missing")
+		return
+	}
+
+	tx, err := e.governanceService.SetOperator(common.HexToAddress(payload.Operator), *payload.IsOperator)
+	writeAdminTransaction(w, tx, err)
+}
+
+func (e *governanceEndpoint) handleGetOperator(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	addr := vars["address"]
+
+	if !common.IsHexAddress(addr) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+		return
+	}
+
+	a := common.HexToAddress(addr)
+	isOperator, err := e.governanceService.IsOperator(a)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	res := map[string]interface{}{
+		"address":    a.Hex(),
+		"isOperator": isOperator,
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+func (e *governanceEndpoint) handleRegisterPair(w http.ResponseWriter, r *http.Request) {
+	payload := &struct {
+		BaseToken            string `json:"baseToken"`
+		QuoteToken           string `json:"quoteToken"`
+		PricepointMultiplier string `json:"pricepointMultiplier"`
+	}{}
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(payload)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+
+	defer r.Body.Close()
+
+	if !common.IsHexAddress(payload.BaseToken) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid base token address")
+		return
+	}
+
+	if !common.IsHexAddress(payload.QuoteToken) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid quote token address")
+		return
+	}
+
+	multiplier, ok := new(big.Int).SetString(payload.PricepointMultiplier, 10)
+	if !ok || multiplier.Sign() <= 0 {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid pricepoint multiplier")
+		return
+	}
+
+	bt := common.HexToAddress(payload.BaseToken)
+	qt := common.HexToAddress(payload.QuoteToken)
+
+	tx, err := e.governanceService.RegisterPair(bt, qt, multiplier)
+	writeAdminTransaction(w, tx, err)
+}
+
+func (e *governanceEndpoint) handleGetTransactions(w http.ResponseWriter, r *http.Request) {
+	v := r.URL.Query()
+	limit := v.Get("limit")
+
+	lim := 0
+	if limit != "" {
+		lim, _ = strconv.Atoi(limit)
+	}
+
+	res, err := e.governanceService.GetTransactions(lim)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	if res == nil {
+		httputils.WriteJSON(w, http.StatusOK, []types.AdminTransaction{})
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+func (e *governanceEndpoint) handleGetTransaction(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	hash := vars["hash"]
+
+	res, err := e.governanceService.GetTransactionByHash(common.HexToHash(hash))
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	if res == nil {
+		httputils.WriteError(w, http.StatusNotFound, "Transaction not found")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+// decodeAddress reads the address stored under the given key of a JSON request body
+// and writes a bad request response if it is missing or invalid
+func decodeAddress(w http.ResponseWriter, r *http.Request, key string) (common.Address, bool) {
+	payload := map[string]string{}
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&payload)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return common.Address{}, false
+	}
+
+	defer r.Body.Close()
+
+	if !common.IsHexAddress(payload[key]) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid "+key+" address")
+		return common.Address{}, false
+	}
+
+	return common.HexToAddress(payload[key]), true
+}
+
+// writeAdminTransaction writes the admin transaction that was sent, which
+// contains the hash that can be used to track its confirmation
+func writeAdminTransaction(w http.ResponseWriter, tx *types.AdminTransaction, err error) {
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusAccepted, tx)
+}
@@ -7,21 +7,26 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/tomochain/dex-server/app"
 	"github.com/tomochain/dex-server/interfaces"
+	"github.com/tomochain/dex-server/types"
 	"github.com/tomochain/dex-server/utils/httputils"
 )
 
 type infoEndpoint struct {
-	walletService interfaces.WalletService
-	tokenService  interfaces.TokenService
+	walletService  interfaces.WalletService
+	tokenService   interfaces.TokenService
+	feeService     interfaces.FeeService
+	contractStatus *types.ContractStatus
 }
 
 func ServeInfoResource(
 	r *mux.Router,
 	walletService interfaces.WalletService,
 	tokenService interfaces.TokenService,
+	feeService interfaces.FeeService,
+	contractStatus *types.ContractStatus,
 ) {
 
-	e := &infoEndpoint{walletService, tokenService}
+	e := &infoEndpoint{walletService, tokenService, feeService, contractStatus}
 	r.HandleFunc("/info", e.handleGetInfo)
 	r.HandleFunc("/info/exchange", e.handleGetExchangeInfo)
 	r.HandleFunc("/info/operators", e.handleGetOperatorsInfo)
@@ -56,6 +61,8 @@ func (e *infoEndpoint) handleGetInfo(w http.ResponseWriter, r *http.Request) {
 		"exchangeAddress": ex.Hex(),
 		"fees":            fees,
 		"operators":       operators,
+		"contract":        e.contractStatus,
+		"readOnly":        e.contractStatus.IsReadOnly(),
 	}
 
 	httputils.WriteJSON(w, http.StatusOK, res)
@@ -81,7 +88,15 @@ func (e *infoEndpoint) handleGetOperatorsInfo(w http.ResponseWriter, r *http.Req
 	httputils.WriteJSON(w, http.StatusOK, res)
 }
 
+// handleGetFeeInfo returns the default fees of the quote tokens, or the effective fees of
+// an account if an address is given
 func (e *infoEndpoint) handleGetFeeInfo(w http.ResponseWriter, r *http.Request) {
+	addr := r.URL.Query().Get("address")
+	if addr != "" {
+		e.handleGetAccountFeeInfo(w, addr)
+		return
+	}
+
 	quotes, err := e.tokenService.GetQuoteTokens()
 	if err != nil {
 		logger.Error(err)
@@ -98,3 +113,19 @@ func (e *infoEndpoint) handleGetFeeInfo(w http.ResponseWriter, r *http.Request)
 
 	httputils.WriteJSON(w, http.StatusOK, fees)
 }
+
+func (e *infoEndpoint) handleGetAccountFeeInfo(w http.ResponseWriter, addr string) {
+	if !common.IsHexAddress(addr) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+		return
+	}
+
+	fees, err := e.feeService.GetEffectiveFees(common.HexToAddress(addr))
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, fees)
+}
@@ -1,5 +1,16 @@
 package endpoints
 
-import "github.com/tomochain/dex-server/utils"
+import (
+	"regexp"
+
+	"github.com/tomochain/dex-server/utils"
+)
 
 var logger = utils.APILogger
+
+var hashRegexp = regexp.MustCompile("^(0x)?[0-9a-fA-F]{64}$")
+
+// isValidHash checks that a string is a hex encoded 32 bytes hash
+func isValidHash(s string) bool {
+	return hashRegexp.MatchString(s)
+}
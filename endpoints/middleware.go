@@ -0,0 +1,318 @@
+package endpoints
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/errors"
+	"github.com/tomochain/dex-server/interfaces"
+	"github.com/tomochain/dex-server/services"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/httputils"
+	"github.com/tomochain/dex-server/ws"
+)
+
+var errForbiddenAccount = errors.New("Not allowed to act for this account")
+var errForbiddenScope = errors.New("API key scope does not allow this request")
+
+// Authenticator returns the router middleware authenticating the requests that carry a JWT in
+// their Authorization header, or that are signed with an API key in their X-API-Key, X-API-Timestamp
+// and X-API-Signature headers. The websocket handshakes, which can not set headers in browsers,
+// can also carry these credentials in the token, apiKey, timestamp and signature query parameters.
+// Requests without credentials are anonymous and requests with invalid credentials are refused
+func Authenticator(apiKeyService interfaces.APIKeyService) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			a, err := authenticate(r, apiKeyService)
+			if err != nil {
+				httputils.WriteError(w, http.StatusUnauthorized, err.Error())
+				return
+			}
+
+			if a != nil {
+				r = httputils.WithAuthentication(r, a)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// authenticated restricts a handler to the authenticated requests
+func authenticated(next http.HandlerFunc) http.HandlerFunc {
+	return scoped(types.API_KEY_SCOPE_READ, next)
+}
+
+// scoped restricts a handler to the authenticated requests allowed the actions of a scope. The
+// requests authenticated with a JWT are allowed all the scopes
+func scoped(scope string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		a, err := requestAuthentication(r)
+		if err != nil || a == nil {
+			httputils.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		if !a.Allows(scope) {
+			httputils.WriteError(w, http.StatusForbidden, errForbiddenScope.Error())
+			return
+		}
+
+		next(w, httputils.WithAuthentication(r, a))
+	}
+}
+
+// adminOnly restricts a handler to the requests carrying a JWT with the admin role in their
+// Authorization header, or the configured admin API key in their X-Admin-Key header. The API
+// key is refused if it is not configured
+func adminOnly(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		a, err := requestAuthentication(r)
+		if err != nil {
+			httputils.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		if a != nil {
+			if !a.IsAdmin() {
+				httputils.WriteError(w, http.StatusForbidden, "Admin role required")
+				return
+			}
+
+			next(w, httputils.WithAuthentication(r, a))
+			return
+		}
+
+		key := app.Config.AdminAPIKey
+		if key == "" {
+			httputils.WriteError(w, http.StatusForbidden, "Admin API disabled")
+			return
+		}
+
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Admin-Key")), []byte(key)) != 1 {
+			httputils.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// adminSource identifies the administrator of a request sent to an admin route in the audit
+// records: the authenticated address and API key, or the admin API key
+func adminSource(r *http.Request) string {
+	a := httputils.GetAuthentication(r)
+	if a == nil {
+		return "admin API key"
+	}
+
+	if a.APIKey != "" {
+		return a.Address.Hex() + " (API key " + a.APIKey + ")"
+	}
+
+	return a.Address.Hex()
+}
+
+// canActAs returns true if the account authenticated by a request is a given account or an admin
+func canActAs(r *http.Request, addr common.Address) bool {
+	a := httputils.GetAuthentication(r)
+	if a == nil {
+		return false
+	}
+
+	return a.IsAdmin() || a.Address == addr
+}
+
+// authorizeClient checks that an authenticated websocket client can act for an account within a
+// scope. The messages of anonymous clients are only authenticated by their signature
+func authorizeClient(c *ws.Client, addr common.Address, scope string) error {
+	a := c.Authentication()
+	if a == nil {
+		return nil
+	}
+
+	if !a.Allows(scope) {
+		return errForbiddenScope
+	}
+
+	if !a.IsAdmin() && a.Address != addr {
+		return errForbiddenAccount
+	}
+
+	return nil
+}
+
+// authenticateSubscription returns the authentication of a websocket subscription to the private
+// events of an account, proven by the payload of the subscription or by the websocket handshake
+func authenticateSubscription(authService interfaces.AuthService, c *ws.Client, payload interface{}) (*types.Authentication, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &types.PrivateSubscription{}
+	err = sub.UnmarshalJSON(b)
+	if err != nil {
+		return nil, err
+	}
+
+	if sub.IsEmpty() {
+		a := c.Authentication()
+		if a == nil {
+			return nil, errors.New("Subscription requires a token or a signed login challenge")
+		}
+
+		return a, nil
+	}
+
+	a, err := authService.AuthenticateSubscription(sub)
+	if err != nil {
+		switch err {
+		case services.ErrInvalidAuthToken, services.ErrInvalidSignature, services.ErrInvalidAuthChallenge:
+			return nil, err
+		default:
+			logger.Error(err)
+			return nil, errors.New("Subscription failed")
+		}
+	}
+
+	return a, nil
+}
+
+// requestAuthentication returns the authentication added to a request by the Authenticator
+// middleware, or the authentication of its bearer token if it was not authenticated yet
+func requestAuthentication(r *http.Request) (*types.Authentication, error) {
+	a := httputils.GetAuthentication(r)
+	if a != nil {
+		return a, nil
+	}
+
+	if r.Header.Get("Authorization") == "" {
+		return nil, nil
+	}
+
+	claims, err := parseAuthHeader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewTokenAuthentication(claims), nil
+}
+
+// authenticate returns the authentication of the credentials of a request, or nil if it has none
+func authenticate(r *http.Request, apiKeyService interfaces.APIKeyService) (*types.Authentication, error) {
+	isHandshake := strings.ToLower(r.Header.Get("Upgrade")) == "websocket"
+	credential := func(header, param string) string {
+		v := r.Header.Get(header)
+		if v == "" && isHandshake {
+			v = r.URL.Query().Get(param)
+		}
+
+		return v
+	}
+
+	key := credential("X-API-Key", "apiKey")
+	if key != "" {
+		body, err := readBody(r)
+		if err != nil {
+			return nil, err
+		}
+
+		req := &types.APIKeyRequest{
+			Key:       key,
+			Timestamp: credential("X-API-Timestamp", "timestamp"),
+			Signature: credential("X-API-Signature", "signature"),
+			Method:    r.Method,
+			URI:       signedURI(r, isHandshake),
+			Body:      body,
+			IP:        remoteIP(r),
+		}
+
+		return apiKeyService.Authenticate(req)
+	}
+
+	if r.Header.Get("Authorization") != "" {
+		claims, err := parseAuthHeader(r)
+		if err != nil {
+			return nil, errors.New("Invalid token")
+		}
+
+		return types.NewTokenAuthentication(claims), nil
+	}
+
+	if isHandshake && r.URL.Query().Get("token") != "" {
+		claims, err := types.ParseAuthToken(r.URL.Query().Get("token"))
+		if err != nil {
+			return nil, errors.New("Invalid token")
+		}
+
+		return types.NewTokenAuthentication(claims), nil
+	}
+
+	return nil, nil
+}
+
+// parseAuthHeader verifies the bearer token of the Authorization header of a request
+func parseAuthHeader(r *http.Request) (*types.AuthClaims, error) {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return nil, errors.New("Missing bearer token")
+	}
+
+	return types.ParseAuthToken(strings.TrimPrefix(header, "Bearer "))
+}
+
+// readBody reads the body of a request and replaces it so that it can be read again by the handler
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Body.Close()
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// signedURI returns the request URI covered by the signature of a request. The signature of a
+// websocket handshake covers its path and its query string without the signature parameter
+func signedURI(r *http.Request, isHandshake bool) string {
+	if !isHandshake {
+		return r.URL.RequestURI()
+	}
+
+	params := []string{}
+	for _, p := range strings.Split(r.URL.RawQuery, "&") {
+		if p != "" && !strings.HasPrefix(p, "signature=") {
+			params = append(params, p)
+		}
+	}
+
+	if len(params) == 0 {
+		return r.URL.Path
+	}
+
+	return r.URL.Path + "?" + strings.Join(params, "&")
+}
+
+// remoteIP returns the IP address of the sender of a request
+func remoteIP(r *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return net.ParseIP(host)
+}
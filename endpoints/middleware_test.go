@@ -0,0 +1,166 @@
+package endpoints
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/services"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/httputils"
+	"github.com/tomochain/dex-server/utils/testutils/mocks"
+	"github.com/tomochain/dex-server/ws"
+)
+
+// testAuthHeader returns the Authorization header of a request authenticated as an account
+func testAuthHeader(t *testing.T, addr common.Address, role string) string {
+	app.Config.JWTSigningKey = "test-jwt-key"
+	app.Config.JWTVerificationKey = "test-jwt-key"
+
+	token, err := types.NewAuthClaims(addr, role, time.Now().Add(time.Hour)).SignedString()
+	if err != nil {
+		t.Fatalf("Could not sign token: %v", err)
+	}
+
+	return "Bearer " + token
+}
+
+func TestAuthMiddlewares(t *testing.T) {
+	user := common.HexToAddress("0x1")
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if !canActAs(r, user) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+
+	tests := []struct {
+		name     string
+		mw       func(http.HandlerFunc) http.HandlerFunc
+		header   string
+		expected int
+	}{
+		{"no token", authenticated, "", http.StatusUnauthorized},
+		{"invalid token", authenticated, "Bearer abc", http.StatusUnauthorized},
+		{"user token", authenticated, testAuthHeader(t, user, types.AUTH_ROLE_USER), http.StatusOK},
+		{"other user token", authenticated, testAuthHeader(t, common.HexToAddress("0x2"), types.AUTH_ROLE_USER), http.StatusForbidden},
+		{"admin token", authenticated, testAuthHeader(t, common.HexToAddress("0x2"), types.AUTH_ROLE_ADMIN), http.StatusOK},
+		{"user token on admin route", adminOnly, testAuthHeader(t, user, types.AUTH_ROLE_USER), http.StatusForbidden},
+		{"admin token on admin route", adminOnly, testAuthHeader(t, common.HexToAddress("0x2"), types.AUTH_ROLE_ADMIN), http.StatusOK},
+	}
+
+	for _, test := range tests {
+		req, _ := http.NewRequest("POST", "/", nil)
+		if test.header != "" {
+			req.Header.Set("Authorization", test.header)
+		}
+
+		rr := httptest.NewRecorder()
+		test.mw(handler)(rr, req)
+
+		if rr.Code != test.expected {
+			t.Errorf("%v: expected status %v, got %v", test.name, test.expected, rr.Code)
+		}
+	}
+}
+
+func TestAuthenticator(t *testing.T) {
+	user := common.HexToAddress("0x1")
+	apiKeyService := new(mocks.APIKeyService)
+
+	tradeKey := &types.Authentication{Address: user, Role: types.AUTH_ROLE_USER, APIKey: "trade", Scope: types.API_KEY_SCOPE_TRADE}
+	readKey := &types.Authentication{Address: user, Role: types.AUTH_ROLE_USER, APIKey: "read", Scope: types.API_KEY_SCOPE_READ}
+
+	signed := func(key string) func(*types.APIKeyRequest) bool {
+		return func(req *types.APIKeyRequest) bool {
+			return req.Key == key && req.Method == "POST" && req.URI == "/orders?a=1" && string(req.Body) == "{}"
+		}
+	}
+
+	apiKeyService.On("Authenticate", mock.MatchedBy(signed("trade"))).Return(tradeKey, nil)
+	apiKeyService.On("Authenticate", mock.MatchedBy(signed("read"))).Return(readKey, nil)
+	apiKeyService.On("Authenticate", mock.MatchedBy(signed("revoked"))).Return(nil, services.ErrInvalidAPIKey)
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != "{}" || httputils.GetAuthentication(r).Address != user {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+
+	tests := []struct {
+		name     string
+		key      string
+		expected int
+	}{
+		{"trade key", "trade", http.StatusOK},
+		{"read key", "read", http.StatusForbidden},
+		{"revoked key", "revoked", http.StatusUnauthorized},
+		{"anonymous", "", http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		req, _ := http.NewRequest("POST", "/orders?a=1", bytes.NewBufferString("{}"))
+		if test.key != "" {
+			req.Header.Set("X-API-Key", test.key)
+			req.Header.Set("X-API-Timestamp", "1")
+			req.Header.Set("X-API-Signature", "abc")
+		}
+
+		rr := httptest.NewRecorder()
+		Authenticator(apiKeyService)(scoped(types.API_KEY_SCOPE_TRADE, handler)).ServeHTTP(rr, req)
+
+		if rr.Code != test.expected {
+			t.Errorf("%v: expected status %v, got %v", test.name, test.expected, rr.Code)
+		}
+	}
+}
+
+func TestSignedURI(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/socket?apiKey=abc&timestamp=1530000000&nonce=1&signature=0x12", nil)
+
+	if uri := signedURI(req, true); uri != "/socket?apiKey=abc&timestamp=1530000000&nonce=1" {
+		t.Errorf("Unexpected handshake signed URI %v", uri)
+	}
+
+	if uri := signedURI(req, false); uri != req.URL.RequestURI() {
+		t.Errorf("Unexpected signed URI %v", uri)
+	}
+}
+
+func TestAuthenticateSubscription(t *testing.T) {
+	user := common.HexToAddress("0x1")
+	authService := services.NewAuthService(nil)
+	token := strings.TrimPrefix(testAuthHeader(t, user, types.AUTH_ROLE_USER), "Bearer ")
+
+	a, err := authenticateSubscription(authService, &ws.Client{}, map[string]interface{}{"token": token})
+	if err != nil {
+		t.Fatalf("Could not authenticate subscription: %v", err)
+	}
+
+	if a.Address != user {
+		t.Errorf("Expected subscription of %v, got %v", user.Hex(), a.Address.Hex())
+	}
+
+	_, err = authenticateSubscription(authService, &ws.Client{}, map[string]interface{}{"token": "abc"})
+	if err != services.ErrInvalidAuthToken {
+		t.Errorf("Expected invalid token error, got %v", err)
+	}
+
+	_, err = authenticateSubscription(authService, &ws.Client{}, nil)
+	if err == nil {
+		t.Errorf("Expected anonymous subscription to be refused")
+	}
+}
@@ -5,11 +5,12 @@ import (
 	"log"
 	"net/http"
 	"strconv"
-
-	"github.com/tomochain/dex-server/errors"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
+	"github.com/tomochain/dex-server/errors"
 	"github.com/tomochain/dex-server/interfaces"
 	"github.com/tomochain/dex-server/utils/httputils"
 
@@ -17,9 +18,18 @@ import (
 	"github.com/tomochain/dex-server/ws"
 )
 
+// orderResponseTimeout is the time the order placement and cancellation endpoints wait for the
+// response of the engine before reporting the request as pending
+const orderResponseTimeout = 5 * time.Second
+
+// maxOrderBatchSize is the maximum number of orders placed or cancelled in a batch request
+const maxOrderBatchSize = 20
+
 type orderEndpoint struct {
 	orderService   interfaces.OrderService
 	accountService interfaces.AccountService
+	authService    interfaces.AuthService
+	contractStatus *types.ContractStatus
 }
 
 // ServeOrderResource sets up the routing of order endpoints and the corresponding handlers.
@@ -27,19 +37,30 @@ func ServeOrderResource(
 	r *mux.Router,
 	orderService interfaces.OrderService,
 	accountService interfaces.AccountService,
+	authService interfaces.AuthService,
+	contractStatus *types.ContractStatus,
 ) {
-	e := &orderEndpoint{orderService, accountService}
+	e := &orderEndpoint{orderService, accountService, authService, contractStatus}
 	r.HandleFunc("/orders/history", e.handleGetOrderHistory).Methods("GET")
 	r.HandleFunc("/orders/positions", e.handleGetPositions).Methods("GET")
 	r.HandleFunc("/orders/feeds/{address}", e.handleGetOrderFeeds).Methods("GET")
+	r.HandleFunc("/orders/nonce", e.handleGetMinNonce).Methods("GET")
+	r.HandleFunc("/orders/nonce", scoped(types.API_KEY_SCOPE_CANCEL, e.handlePostMinNonce)).Methods("POST")
 	r.HandleFunc("/orders", e.handleGetOrders).Methods("GET")
+	r.HandleFunc("/orders", scoped(types.API_KEY_SCOPE_TRADE, e.handlePostOrder)).Methods("POST")
+	r.HandleFunc("/orders/batch", scoped(types.API_KEY_SCOPE_TRADE, e.handlePostOrders)).Methods("POST")
+	r.HandleFunc("/orders/batch", scoped(types.API_KEY_SCOPE_CANCEL, e.handleDeleteOrders)).Methods("DELETE")
+	r.HandleFunc("/orders/{hash}", e.handleGetOrder).Methods("GET")
+	r.HandleFunc("/orders/{hash}/events", e.handleGetOrderEvents).Methods("GET")
+	r.HandleFunc("/orders/{hash}", scoped(types.API_KEY_SCOPE_CANCEL, e.handleDeleteOrder)).Methods("DELETE")
 	ws.RegisterChannel(ws.OrderChannel, e.ws)
 }
 
+// handleGetOrders returns a page of the orders of an account, most recent first. The orders can be
+// filtered by pair, side, status and creation date, and the next page is fetched with the returned cursor
 func (e *orderEndpoint) handleGetOrders(w http.ResponseWriter, r *http.Request) {
 	v := r.URL.Query()
 	addr := v.Get("address")
-	limit := v.Get("limit")
 
 	if addr == "" {
 		httputils.WriteError(w, http.StatusBadRequest, "address Parameter Missing")
@@ -51,17 +72,14 @@ func (e *orderEndpoint) handleGetOrders(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	var err error
-	var orders []*types.Order
-	address := common.HexToAddress(addr)
-
-	if limit == "" {
-		orders, err = e.orderService.GetByUserAddress(address)
-	} else {
-		lim, _ := strconv.Atoi(limit)
-		orders, err = e.orderService.GetByUserAddress(address, lim)
+	f, err := parseHistoryFilter(v, types.DefaultPageLimit)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
 	}
 
+	f.UserAddress = common.HexToAddress(addr)
+	orders, next, err := e.orderService.GetPage(f)
 	if err != nil {
 		logger.Error(err)
 		httputils.WriteError(w, http.StatusInternalServerError, "")
@@ -69,11 +87,59 @@ func (e *orderEndpoint) handleGetOrders(w http.ResponseWriter, r *http.Request)
 	}
 
 	if orders == nil {
-		httputils.WriteJSON(w, http.StatusOK, []types.Order{})
+		orders = []*types.Order{}
+	}
+
+	writePage(w, v, orders, next)
+}
+
+func (e *orderEndpoint) handleGetOrder(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	hash := vars["hash"]
+	if !isValidHash(hash) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Hash")
 		return
 	}
 
-	httputils.WriteJSON(w, http.StatusOK, orders)
+	o, err := e.orderService.GetByHash(common.HexToHash(hash))
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	if o == nil {
+		httputils.WriteError(w, http.StatusNotFound, "Order not found")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, o)
+}
+
+// handleGetOrderEvents returns the timeline of an order: its responses from the matching engine
+// and the settlement status of its trades, from the oldest event
+func (e *orderEndpoint) handleGetOrderEvents(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	hash := vars["hash"]
+	if !isValidHash(hash) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Hash")
+		return
+	}
+
+	events, err := e.orderService.GetEvents(common.HexToHash(hash))
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	if events == nil {
+		events = []*types.OrderEvent{}
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, events)
 }
 
 func (e *orderEndpoint) handleGetOrderFeeds(w http.ResponseWriter, r *http.Request) {
@@ -155,10 +221,11 @@ func (e *orderEndpoint) handleGetPositions(w http.ResponseWriter, r *http.Reques
 	httputils.WriteJSON(w, http.StatusOK, orders)
 }
 
+// handleGetOrderHistory returns a page of the filled, cancelled and rejected orders of an account,
+// with the same filters as handleGetOrders
 func (e *orderEndpoint) handleGetOrderHistory(w http.ResponseWriter, r *http.Request) {
 	v := r.URL.Query()
 	addr := v.Get("address")
-	limit := v.Get("limit")
 
 	if addr == "" {
 		httputils.WriteError(w, http.StatusBadRequest, "address Parameter missing")
@@ -170,17 +237,14 @@ func (e *orderEndpoint) handleGetOrderHistory(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	var err error
-	var orders []*types.Order
-	address := common.HexToAddress(addr)
-
-	if limit == "" {
-		orders, err = e.orderService.GetHistoryByUserAddress(address)
-	} else {
-		lim, _ := strconv.Atoi(limit)
-		orders, err = e.orderService.GetHistoryByUserAddress(address, lim)
+	f, err := parseHistoryFilter(v, types.DefaultPageLimit)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
 	}
 
+	f.UserAddress = common.HexToAddress(addr)
+	orders, next, err := e.orderService.GetHistoryPage(f)
 	if err != nil {
 		logger.Error(err)
 		httputils.WriteError(w, http.StatusInternalServerError, "Internal Server Error")
@@ -188,11 +252,399 @@ func (e *orderEndpoint) handleGetOrderHistory(w http.ResponseWriter, r *http.Req
 	}
 
 	if orders == nil {
-		httputils.WriteJSON(w, http.StatusOK, []types.Order{})
+		orders = []*types.Order{}
+	}
+
+	writePage(w, v, orders, next)
+}
+
+func (e *orderEndpoint) handleGetMinNonce(w http.ResponseWriter, r *http.Request) {
+	v := r.URL.Query()
+	addr := v.Get("address")
+
+	if addr == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "address Parameter missing")
 		return
 	}
 
-	httputils.WriteJSON(w, http.StatusOK, orders)
+	if !common.IsHexAddress(addr) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+		return
+	}
+
+	address := common.HexToAddress(addr)
+	minNonce, err := e.orderService.GetMinNonce(address)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	res := map[string]string{
+		"address":  address.Hex(),
+		"minNonce": minNonce.String(),
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+// handlePostMinNonce sets the minimum valid nonce of an account, with the same signed payload as the
+// SET_MIN_NONCE message of the orders websocket channel. It returns the open orders of the account
+// that were cancelled because of a lower nonce
+func (e *orderEndpoint) handlePostMinNonce(w http.ResponseWriter, r *http.Request) {
+	if e.contractStatus.IsReadOnly() {
+		httputils.WriteError(w, http.StatusServiceUnavailable, "Exchange is in read-only mode")
+		return
+	}
+
+	m := &types.MinNonce{}
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(m)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+
+	defer r.Body.Close()
+
+	if !canActAs(r, m.UserAddress) {
+		httputils.WriteError(w, http.StatusForbidden, errForbiddenAccount.Error())
+		return
+	}
+
+	orders, err := e.orderService.SetMinNonce(m)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	hashes := []common.Hash{}
+	for _, o := range orders {
+		hashes = append(hashes, o.Hash)
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"userAddress":     m.UserAddress,
+		"minNonce":        m.Nonce.String(),
+		"hash":            m.Hash,
+		"cancelledOrders": hashes,
+	})
+}
+
+// handlePostOrder places a signed order, with the same payload as the NEW_ORDER message of the
+// orders websocket channel. It returns the first response of the engine, or a PENDING status if
+// the engine did not respond in time
+func (e *orderEndpoint) handlePostOrder(w http.ResponseWriter, r *http.Request) {
+	if e.contractStatus.IsReadOnly() {
+		httputils.WriteError(w, http.StatusServiceUnavailable, "Exchange is in read-only mode")
+		return
+	}
+
+	o := &types.Order{}
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(o)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+
+	defer r.Body.Close()
+
+	res, err := e.placeOrder(r, o)
+	if err == errForbiddenAccount {
+		httputils.WriteError(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if res["status"] == types.ERROR_STATUS {
+		httputils.WriteError(w, http.StatusBadRequest, "Order rejected by the matching engine")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusCreated, res)
+}
+
+// handlePostOrders places a batch of signed orders. Each order is processed independently and
+// the results are returned in the order of the payload
+func (e *orderEndpoint) handlePostOrders(w http.ResponseWriter, r *http.Request) {
+	if e.contractStatus.IsReadOnly() {
+		httputils.WriteError(w, http.StatusServiceUnavailable, "Exchange is in read-only mode")
+		return
+	}
+
+	payloads := []json.RawMessage{}
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&payloads)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+
+	defer r.Body.Close()
+
+	if len(payloads) == 0 || len(payloads) > maxOrderBatchSize {
+		httputils.WriteError(w, http.StatusBadRequest, "A batch should contain between 1 and "+strconv.Itoa(maxOrderBatchSize)+" orders")
+		return
+	}
+
+	orders := make([]*types.Order, len(payloads))
+	accounts := make([]common.Address, len(payloads))
+	errs := make([]error, len(payloads))
+
+	for i, b := range payloads {
+		o := &types.Order{}
+		errs[i] = decodeBatchPayload(b, o, func() error { return o.Validate() })
+		orders[i] = o
+		accounts[i] = o.UserAddress
+	}
+
+	results := batch(accounts, errs, func(i int) (map[string]interface{}, error) {
+		return e.placeOrder(r, orders[i])
+	})
+
+	httputils.WriteJSON(w, http.StatusOK, results)
+}
+
+// handleDeleteOrder cancels an order, with the same signed payload as the CANCEL_ORDER message
+// of the orders websocket channel
+func (e *orderEndpoint) handleDeleteOrder(w http.ResponseWriter, r *http.Request) {
+	if e.contractStatus.IsReadOnly() {
+		httputils.WriteError(w, http.StatusServiceUnavailable, "Exchange is in read-only mode")
+		return
+	}
+
+	vars := mux.Vars(r)
+
+	hash := vars["hash"]
+	if !isValidHash(hash) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Hash")
+		return
+	}
+
+	oc := &types.OrderCancel{}
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(oc)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	defer r.Body.Close()
+
+	if oc.OrderHash != common.HexToHash(hash) {
+		httputils.WriteError(w, http.StatusBadRequest, "orderHash does not match the order of the request path")
+		return
+	}
+
+	res, err := e.cancelOrder(r, oc)
+	if err == errForbiddenAccount {
+		httputils.WriteError(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if res["status"] == types.ERROR_STATUS {
+		httputils.WriteError(w, http.StatusBadRequest, "Cancellation rejected by the matching engine")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+// handleDeleteOrders cancels a batch of orders. Each cancellation is processed independently
+// and the results are returned in the order of the payload
+func (e *orderEndpoint) handleDeleteOrders(w http.ResponseWriter, r *http.Request) {
+	if e.contractStatus.IsReadOnly() {
+		httputils.WriteError(w, http.StatusServiceUnavailable, "Exchange is in read-only mode")
+		return
+	}
+
+	payloads := []json.RawMessage{}
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&payloads)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+
+	defer r.Body.Close()
+
+	if len(payloads) == 0 || len(payloads) > maxOrderBatchSize {
+		httputils.WriteError(w, http.StatusBadRequest, "A batch should contain between 1 and "+strconv.Itoa(maxOrderBatchSize)+" cancellations")
+		return
+	}
+
+	cancels := make([]*types.OrderCancel, len(payloads))
+	accounts := make([]common.Address, len(payloads))
+	errs := make([]error, len(payloads))
+
+	for i, b := range payloads {
+		oc := &types.OrderCancel{}
+		errs[i] = decodeBatchPayload(b, oc, func() error {
+			if oc.Signature == nil {
+				return errors.New("OrderCancel 'signature' parameter is required")
+			}
+
+			var err error
+			accounts[i], err = oc.GetSenderAddress()
+			return err
+		})
+		cancels[i] = oc
+	}
+
+	results := batch(accounts, errs, func(i int) (map[string]interface{}, error) {
+		return e.cancelOrder(r, cancels[i])
+	})
+
+	httputils.WriteJSON(w, http.StatusOK, results)
+}
+
+// placeOrder submits an order of the authenticated account to the order service and waits for
+// the response of the engine
+func (e *orderEndpoint) placeOrder(r *http.Request, o *types.Order) (map[string]interface{}, error) {
+	if !canActAs(r, o.UserAddress) {
+		return nil, errForbiddenAccount
+	}
+
+	o.Hash = o.ComputeHash()
+
+	// the restriction level of the account is checked by the order service
+	_, err := e.accountService.FindOrCreate(o.UserAddress)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	res, err := e.orderService.NewOrderAndWait(o, orderResponseTimeout)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return engineResult(o.Hash, res), nil
+}
+
+// cancelOrder submits the cancellation of an order of the authenticated account to the order service
+// and waits for the response of the engine. The order service checks that the cancellation is signed
+// by the maker of the order
+func (e *orderEndpoint) cancelOrder(r *http.Request, oc *types.OrderCancel) (map[string]interface{}, error) {
+	o, err := e.orderService.GetByHash(oc.OrderHash)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if o == nil {
+		return nil, errors.New("No order with corresponding hash")
+	}
+
+	if !canActAs(r, o.UserAddress) {
+		return nil, errForbiddenAccount
+	}
+
+	res, err := e.orderService.CancelOrderAndWait(oc, orderResponseTimeout)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return engineResult(oc.OrderHash, res), nil
+}
+
+// engineResult describes the response of the engine to an order request. The status is
+// PENDING if the engine did not respond in time
+func engineResult(h common.Hash, res *types.EngineResponse) map[string]interface{} {
+	result := map[string]interface{}{
+		"hash":   h.Hex(),
+		"status": "PENDING",
+	}
+
+	if res == nil {
+		return result
+	}
+
+	result["status"] = res.Status
+
+	if res.Order != nil {
+		result["order"] = res.Order
+	}
+
+	if res.Matches != nil {
+		result["matches"] = res.Matches
+	}
+
+	return result
+}
+
+// decodeBatchPayload unmarshals and checks a payload of a batch on the request goroutine. The
+// decoders assume the types of the fields, so a field of an unexpected type is reported as an
+// invalid payload instead of crashing the worker processing the batch
+func decodeBatchPayload(b json.RawMessage, v json.Unmarshaler, check func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New("Invalid payload")
+		}
+	}()
+
+	err = v.UnmarshalJSON(b)
+	if err != nil {
+		return err
+	}
+
+	return check()
+}
+
+// batch runs the requests of a batch and returns their results in order. The requests of different
+// accounts run concurrently, while the requests of an account run one after another so that each one
+// is checked against the balances locked by the previous ones. The requests that failed to decode are
+// not run, and the result of a failed request only contains its error
+func batch(accounts []common.Address, errs []error, request func(i int) (map[string]interface{}, error)) []map[string]interface{} {
+	results := make([]map[string]interface{}, len(accounts))
+
+	groups := map[common.Address][]int{}
+	for i, a := range accounts {
+		if errs[i] != nil {
+			results[i] = map[string]interface{}{"error": errs[i].Error()}
+			continue
+		}
+
+		groups[a] = append(groups[a], i)
+	}
+
+	wg := sync.WaitGroup{}
+	for _, indexes := range groups {
+		wg.Add(1)
+
+		go func(indexes []int) {
+			defer wg.Done()
+
+			for _, i := range indexes {
+				res, err := request(i)
+				if err != nil {
+					res = map[string]interface{}{"error": err.Error()}
+				}
+
+				results[i] = res
+			}
+		}(indexes)
+	}
+
+	wg.Wait()
+	return results
 }
 
 // ws function handles incoming websocket messages on the order channel
@@ -205,16 +657,42 @@ func (e *orderEndpoint) ws(input interface{}, c *ws.Client) {
 		c.SendMessage(ws.OrderChannel, types.ERROR, err.Error())
 	}
 
+	// orders can not be settled with an incompatible exchange contract
+	if (msg.Type == "NEW_ORDER" || msg.Type == "CANCEL_ORDER" || msg.Type == "SET_MIN_NONCE") && e.contractStatus.IsReadOnly() {
+		c.SendMessage(ws.OrderChannel, types.ERROR, "Exchange is in read-only mode")
+		return
+	}
+
 	switch msg.Type {
+	case types.SUBSCRIBE:
+		e.handleSubscribe(msg, c)
+	case types.UNSUBSCRIBE:
+		ws.UnregisterOrderConnections(c)
 	case "NEW_ORDER":
 		e.handleNewOrder(msg, c)
 	case "CANCEL_ORDER":
 		e.handleCancelOrder(msg, c)
+	case "SET_MIN_NONCE":
+		e.handleSetMinNonce(msg, c)
 	default:
 		log.Print("Response with error")
 	}
 }
 
+// handleSubscribe subscribes a client to the private order and trade events of an account, once it
+// proved the ownership of the account with a JWT, a signed login challenge or the credentials of
+// the websocket handshake. The events are then sent even if the orders are placed by another client
+func (e *orderEndpoint) handleSubscribe(ev *types.WebsocketEvent, c *ws.Client) {
+	a, err := authenticateSubscription(e.authService, c, ev.Payload)
+	if err != nil {
+		c.SendMessage(ws.OrderChannel, types.ERROR, err.Error())
+		return
+	}
+
+	ws.RegisterOrderConnection(a.Address, c)
+	c.SendMessage(ws.OrderChannel, types.INIT, map[string]string{"address": a.Address.Hex()})
+}
+
 // handleNewOrder handles NewOrder message. New order messages are transmitted to the order service after being unmarshalled
 func (e *orderEndpoint) handleNewOrder(ev *types.WebsocketEvent, c *ws.Client) {
 	o := &types.Order{}
@@ -236,16 +714,19 @@ func (e *orderEndpoint) handleNewOrder(ev *types.WebsocketEvent, c *ws.Client) {
 	}
 
 	o.Hash = o.ComputeHash()
-	ws.RegisterOrderConnection(o.UserAddress, c)
 
-	acc, err := e.accountService.FindOrCreate(o.UserAddress)
+	err = authorizeClient(c, o.UserAddress, types.API_KEY_SCOPE_TRADE)
 	if err != nil {
-		logger.Error(err)
 		c.SendOrderErrorMessage(err, o.Hash)
+		return
 	}
 
-	if acc.IsBlocked {
-		c.SendMessage(ws.OrderChannel, types.ERROR, errors.New("Account is blocked"))
+	// the restriction level of the account is checked by the order service
+	_, err = e.accountService.FindOrCreate(o.UserAddress)
+	if err != nil {
+		logger.Error(err)
+		c.SendOrderErrorMessage(err, o.Hash)
+		return
 	}
 
 	err = e.orderService.NewOrder(o)
@@ -265,20 +746,72 @@ func (e *orderEndpoint) handleCancelOrder(ev *types.WebsocketEvent, c *ws.Client
 	if err != nil {
 		logger.Error(err)
 		c.SendOrderErrorMessage(err, oc.Hash)
+		return
 	}
 
 	addr, err := oc.GetSenderAddress()
 	if err != nil {
 		logger.Error(err)
 		c.SendOrderErrorMessage(err, oc.Hash)
+		return
 	}
 
-	ws.RegisterOrderConnection(addr, c)
+	err = authorizeClient(c, addr, types.API_KEY_SCOPE_CANCEL)
+	if err != nil {
+		c.SendOrderErrorMessage(err, oc.Hash)
+		return
+	}
 
-	orderErr := e.orderService.CancelOrder(oc)
-	if orderErr != nil {
+	// cancellations are refused for blocked accounts
+	err = e.orderService.CancelOrder(oc)
+	if err != nil {
 		logger.Error(err)
-		c.SendOrderErrorMessage(orderErr, oc.Hash)
+		c.SendOrderErrorMessage(err, oc.Hash)
 		return
 	}
 }
+
+// handleSetMinNonce handles SetMinNonce message. The open orders of the account with a lower nonce
+// are cancelled and their cancellation is reported with ORDER_CANCELLED messages
+func (e *orderEndpoint) handleSetMinNonce(ev *types.WebsocketEvent, c *ws.Client) {
+	m := &types.MinNonce{}
+
+	bytes, err := json.Marshal(ev.Payload)
+	if err != nil {
+		logger.Error(err)
+		c.SendMessage(ws.OrderChannel, types.ERROR, err.Error())
+		return
+	}
+
+	err = m.UnmarshalJSON(bytes)
+	if err != nil {
+		logger.Error(err)
+		c.SendOrderErrorMessage(err, m.Hash)
+		return
+	}
+
+	err = authorizeClient(c, m.UserAddress, types.API_KEY_SCOPE_CANCEL)
+	if err != nil {
+		c.SendOrderErrorMessage(err, m.Hash)
+		return
+	}
+
+	orders, err := e.orderService.SetMinNonce(m)
+	if err != nil {
+		logger.Error(err)
+		c.SendOrderErrorMessage(err, m.Hash)
+		return
+	}
+
+	hashes := []common.Hash{}
+	for _, o := range orders {
+		hashes = append(hashes, o.Hash)
+	}
+
+	c.SendMessage(ws.OrderChannel, "MIN_NONCE_SET", map[string]interface{}{
+		"userAddress":     m.UserAddress,
+		"minNonce":        m.Nonce.String(),
+		"hash":            m.Hash,
+		"cancelledOrders": hashes,
+	})
+}
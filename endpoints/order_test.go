@@ -0,0 +1,367 @@
+package endpoints
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/mock"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/httputils"
+	"github.com/tomochain/dex-server/utils/testutils"
+	"github.com/tomochain/dex-server/utils/testutils/mocks"
+	"github.com/tomochain/dex-server/ws"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func SetupOrderEndpointTest() (*mux.Router, *mocks.OrderService) {
+	r := mux.NewRouter()
+	orderService := new(mocks.OrderService)
+	accountService := new(mocks.AccountService)
+
+	ServeOrderResource(r, orderService, accountService, nil, &types.ContractStatus{})
+
+	return r, orderService
+}
+
+func newTestOrderCancel(t *testing.T, orderHash common.Hash) *types.OrderCancel {
+	oc := &types.OrderCancel{OrderHash: orderHash}
+
+	err := oc.Sign(testutils.GetTestWallet1())
+	if err != nil {
+		t.Fatalf("Could not sign order cancel: %v", err)
+	}
+
+	return oc
+}
+
+func TestHandleDeleteOrder(t *testing.T) {
+	router, orderService := SetupOrderEndpointTest()
+
+	o := &types.Order{Hash: common.HexToHash("0x1234"), UserAddress: testutils.GetTestWallet1().Address, Status: types.CANCELLED}
+	oc := newTestOrderCancel(t, o.Hash)
+
+	res := &types.EngineResponse{Status: types.ORDER_CANCELLED, Order: o}
+	orderService.On("GetByHash", o.Hash).Return(o, nil)
+	orderService.On("CancelOrderAndWait", mock.Anything, orderResponseTimeout).Return(res, nil)
+
+	b, _ := json.Marshal(oc)
+	req, _ := http.NewRequest("DELETE", "/orders/"+o.Hash.Hex(), bytes.NewBuffer(b))
+	req.Header.Set("Authorization", testAuthHeader(t, testutils.GetTestWallet1().Address, types.AUTH_ROLE_USER))
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Handler return wrong status. Got %v want %v", rr.Code, http.StatusOK)
+	}
+
+	payload := struct {
+		Data map[string]interface{} `json:"data"`
+	}{}
+
+	json.NewDecoder(rr.Body).Decode(&payload)
+	if payload.Data["status"] != types.ORDER_CANCELLED || payload.Data["hash"] != o.Hash.Hex() {
+		t.Errorf("Unexpected response %v", payload.Data)
+	}
+}
+
+func TestHandleDeleteOrderHashMismatch(t *testing.T) {
+	router, orderService := SetupOrderEndpointTest()
+
+	oc := newTestOrderCancel(t, common.HexToHash("0x1234"))
+
+	b, _ := json.Marshal(oc)
+	req, _ := http.NewRequest("DELETE", "/orders/"+common.HexToHash("0x5678").Hex(), bytes.NewBuffer(b))
+	req.Header.Set("Authorization", testAuthHeader(t, testutils.GetTestWallet1().Address, types.AUTH_ROLE_USER))
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Handler return wrong status. Got %v want %v", rr.Code, http.StatusBadRequest)
+	}
+
+	orderService.AssertNotCalled(t, "CancelOrderAndWait", mock.Anything, mock.Anything)
+}
+
+func TestHandleDeleteOrders(t *testing.T) {
+	router, orderService := SetupOrderEndpointTest()
+
+	oc1 := newTestOrderCancel(t, common.HexToHash("0x1"))
+	oc2 := newTestOrderCancel(t, common.HexToHash("0x2"))
+	o1 := &types.Order{Hash: oc1.OrderHash, UserAddress: testutils.GetTestWallet1().Address}
+
+	orderService.On("GetByHash", oc1.OrderHash).Return(o1, nil)
+	orderService.On("GetByHash", oc2.OrderHash).Return(nil, nil)
+	orderService.On("CancelOrderAndWait", oc1, orderResponseTimeout).Return(nil, nil)
+
+	b, _ := json.Marshal([]*types.OrderCancel{oc1, oc2})
+	req, _ := http.NewRequest("DELETE", "/orders/batch", bytes.NewBuffer(b))
+	req.Header.Set("Authorization", testAuthHeader(t, testutils.GetTestWallet1().Address, types.AUTH_ROLE_USER))
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Handler return wrong status. Got %v want %v", rr.Code, http.StatusOK)
+	}
+
+	payload := struct {
+		Data []map[string]interface{} `json:"data"`
+	}{}
+
+	json.NewDecoder(rr.Body).Decode(&payload)
+	if len(payload.Data) != 2 {
+		t.Fatalf("Expected 2 results, got %v", len(payload.Data))
+	}
+
+	if payload.Data[0]["status"] != "PENDING" {
+		t.Errorf("Expected a pending cancellation, got %v", payload.Data[0])
+	}
+
+	if payload.Data[1]["error"] != "No order with corresponding hash" {
+		t.Errorf("Expected an error, got %v", payload.Data[1])
+	}
+
+	orderService.AssertNotCalled(t, "CancelOrderAndWait", oc2, orderResponseTimeout)
+}
+
+func TestHandlePostOrdersInvalidPayload(t *testing.T) {
+	router, orderService := SetupOrderEndpointTest()
+
+	req, _ := http.NewRequest("POST", "/orders/batch", bytes.NewBufferString(`[{"amount":1}]`))
+	req.Header.Set("Authorization", testAuthHeader(t, testutils.GetTestWallet1().Address, types.AUTH_ROLE_USER))
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Handler return wrong status. Got %v want %v", rr.Code, http.StatusOK)
+	}
+
+	payload := struct {
+		Data []map[string]interface{} `json:"data"`
+	}{}
+
+	json.NewDecoder(rr.Body).Decode(&payload)
+	if len(payload.Data) != 1 || payload.Data[0]["error"] != "Invalid payload" {
+		t.Errorf("Expected an invalid payload error, got %v", payload.Data)
+	}
+
+	orderService.AssertNotCalled(t, "NewOrderAndWait", mock.Anything, mock.Anything)
+}
+
+func TestHandleDeleteOrderAuthorization(t *testing.T) {
+	router, orderService := SetupOrderEndpointTest()
+
+	o := &types.Order{Hash: common.HexToHash("0x1234"), UserAddress: testutils.GetTestWallet1().Address}
+	orderService.On("GetByHash", o.Hash).Return(o, nil)
+
+	// a cancellation signed by another account does not allow this account to cancel the order
+	oc := newTestOrderCancel(t, o.Hash)
+	other := &types.OrderCancel{OrderHash: o.Hash}
+	other.Sign(testutils.GetTestWallet2())
+
+	tests := []struct {
+		name     string
+		header   string
+		cancel   *types.OrderCancel
+		expected int
+	}{
+		{"anonymous", "", oc, http.StatusUnauthorized},
+		{"other account", testAuthHeader(t, testutils.GetTestWallet2().Address, types.AUTH_ROLE_USER), oc, http.StatusForbidden},
+		{"signed by another account", testAuthHeader(t, testutils.GetTestWallet2().Address, types.AUTH_ROLE_USER), other, http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		b, _ := json.Marshal(test.cancel)
+		req, _ := http.NewRequest("DELETE", "/orders/"+o.Hash.Hex(), bytes.NewBuffer(b))
+		if test.header != "" {
+			req.Header.Set("Authorization", test.header)
+		}
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != test.expected {
+			t.Errorf("%v: expected status %v, got %v", test.name, test.expected, rr.Code)
+		}
+	}
+
+	orderService.AssertNotCalled(t, "CancelOrderAndWait", mock.Anything, mock.Anything)
+}
+
+func TestHandlePostMinNonce(t *testing.T) {
+	router, orderService := SetupOrderEndpointTest()
+
+	o := &types.Order{Hash: common.HexToHash("0x1234")}
+	orderService.On("SetMinNonce", mock.Anything).Return([]*types.Order{o}, nil)
+
+	m := &types.MinNonce{
+		UserAddress: testutils.GetTestWallet1().Address,
+		Nonce:       big.NewInt(10),
+		Signature:   &types.Signature{V: 27},
+	}
+
+	b, _ := json.Marshal(m)
+
+	tests := []struct {
+		name     string
+		header   string
+		expected int
+	}{
+		{"other account", testAuthHeader(t, testutils.GetTestWallet2().Address, types.AUTH_ROLE_USER), http.StatusForbidden},
+		{"account", testAuthHeader(t, testutils.GetTestWallet1().Address, types.AUTH_ROLE_USER), http.StatusOK},
+	}
+
+	for _, test := range tests {
+		req, _ := http.NewRequest("POST", "/orders/nonce", bytes.NewBuffer(b))
+		req.Header.Set("Authorization", test.header)
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != test.expected {
+			t.Errorf("%v: expected status %v, got %v", test.name, test.expected, rr.Code)
+		}
+	}
+
+	orderService.AssertNumberOfCalls(t, "SetMinNonce", 1)
+}
+
+func TestOrderChannelSubscribe(t *testing.T) {
+	SetupOrderEndpointTest()
+
+	addr := testutils.GetTestWallet1().Address
+	auth := &types.Authentication{Address: addr, Role: types.AUTH_ROLE_USER}
+
+	// the connection is authenticated by its handshake, as done by the authenticator middleware
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws.ConnectionEndpoint(w, httputils.WithAuthentication(r, auth))
+	}))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Could not connect: %v", err)
+	}
+	defer conn.Close()
+
+	read := func() types.WebsocketMessage {
+		m := types.WebsocketMessage{}
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+
+		err := conn.ReadJSON(&m)
+		if err != nil {
+			t.Fatalf("Could not read message: %v", err)
+		}
+
+		return m
+	}
+
+	err = conn.WriteJSON(types.WebsocketMessage{
+		Channel: ws.OrderChannel,
+		Event:   types.WebsocketEvent{Type: types.SUBSCRIBE, Payload: map[string]interface{}{}},
+	})
+	if err != nil {
+		t.Fatalf("Could not subscribe: %v", err)
+	}
+
+	m := read()
+	if m.Event.Type != types.INIT {
+		t.Fatalf("Expected an INIT message, got %v", m)
+	}
+
+	ws.SendOrderMessage(types.UPDATE, common.HexToAddress("0x3"), "other account")
+	ws.SendOrderMessage(types.UPDATE, addr, "order")
+
+	m = read()
+	if m.Channel != ws.OrderChannel || m.Event.Type != types.UPDATE || m.Event.Payload != "order" {
+		t.Errorf("Unexpected message %v", m)
+	}
+}
+
+func TestHandleGetOrderHistoryPage(t *testing.T) {
+	router, orderService := SetupOrderEndpointTest()
+
+	addr := testutils.GetTestWallet1().Address
+	o := &types.Order{Hash: common.HexToHash("0x1234"), UserAddress: addr}
+	next := types.NewCursor(time.Unix(1500000000, 0), bson.NewObjectId())
+	orderService.On("GetHistoryPage", mock.Anything).Return([]*types.Order{o}, next, nil)
+
+	cases := []struct {
+		query string
+		next  bool
+	}{
+		{"", false},
+		{"&limit=1", false},
+		{"&status=FILLED", true},
+		{"&cursor=" + next.Encode(), true},
+	}
+
+	for _, c := range cases {
+		req, _ := http.NewRequest("GET", "/orders/history?address="+addr.Hex()+c.query, nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("Handler return wrong status for %v. Got %v want %v", c.query, rr.Code, http.StatusOK)
+		}
+
+		payload := map[string]interface{}{}
+		json.NewDecoder(rr.Body).Decode(&payload)
+
+		data, ok := payload["data"].([]interface{})
+		if !ok || len(data) != 1 {
+			t.Errorf("Unexpected orders for %v: %v", c.query, payload["data"])
+		}
+
+		_, ok = payload["next"]
+		if ok != c.next {
+			t.Errorf("Unexpected next cursor for %v: %v", c.query, payload["next"])
+		}
+	}
+}
+
+func TestOrderEndpointsReadOnly(t *testing.T) {
+	r := mux.NewRouter()
+	orderService := new(mocks.OrderService)
+	accountService := new(mocks.AccountService)
+	ServeOrderResource(r, orderService, accountService, nil, &types.ContractStatus{ReadOnly: true})
+
+	h := common.HexToHash("0x1").Hex()
+	tests := []struct {
+		method string
+		url    string
+	}{
+		{"POST", "/orders"},
+		{"POST", "/orders/batch"},
+		{"POST", "/orders/nonce"},
+		{"DELETE", "/orders/" + h},
+	}
+
+	for _, test := range tests {
+		req, _ := http.NewRequest(test.method, test.url, bytes.NewBufferString(`{}`))
+		req.Header.Set("Authorization", testAuthHeader(t, testutils.GetTestWallet1().Address, types.AUTH_ROLE_USER))
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusServiceUnavailable {
+			t.Errorf("%v %v: handler return wrong status. Got %v want %v", test.method, test.url, rr.Code, http.StatusServiceUnavailable)
+		}
+	}
+
+	if len(orderService.Calls) != 0 {
+		t.Errorf("Expected no call to the order service in read-only mode, got %v", orderService.Calls)
+	}
+}
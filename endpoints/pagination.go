@@ -0,0 +1,105 @@
+package endpoints
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/errors"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/httputils"
+)
+
+// parseHistoryFilter reads the filter and pagination parameters of an order or trade history
+// request: baseToken and quoteToken, side, status, from and to timestamps, cursor and limit
+func parseHistoryFilter(v url.Values, defaultLimit int) (*types.HistoryFilter, error) {
+	f := &types.HistoryFilter{
+		Side:   v.Get("side"),
+		Status: v.Get("status"),
+		Limit:  defaultLimit,
+	}
+
+	for param, addr := range map[string]*common.Address{"baseToken": &f.BaseToken, "quoteToken": &f.QuoteToken} {
+		if v.Get(param) == "" {
+			continue
+		}
+
+		if !common.IsHexAddress(v.Get(param)) {
+			return nil, errors.New("Invalid " + param + " address")
+		}
+
+		*addr = common.HexToAddress(v.Get(param))
+	}
+
+	for param, t := range map[string]*time.Time{"from": &f.From, "to": &f.To} {
+		if v.Get(param) == "" {
+			continue
+		}
+
+		ts, err := strconv.ParseInt(v.Get(param), 10, 64)
+		if err != nil {
+			return nil, errors.New("Invalid " + param + " parameter")
+		}
+
+		*t = time.Unix(ts, 0)
+	}
+
+	if v.Get("limit") != "" {
+		limit, err := strconv.Atoi(v.Get("limit"))
+		if err != nil {
+			return nil, errors.New("Invalid limit parameter")
+		}
+
+		f.Limit = limit
+	}
+
+	if v.Get("cursor") != "" {
+		c, err := types.DecodeCursor(v.Get("cursor"))
+		if err != nil {
+			return nil, err
+		}
+
+		f.Cursor = c
+	}
+
+	err := f.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// pageParams are the parameters that select the paginated response of the history endpoints
+var pageParams = []string{"cursor", "side", "status", "from", "to"}
+
+// isPageRequest returns true if a history request has pagination or filter parameters. The
+// other requests keep the response of the unpaginated endpoints, without the next cursor
+func isPageRequest(v url.Values) bool {
+	for _, param := range pageParams {
+		if v.Get(param) != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writePage writes a page of orders or trades with the cursor of the next page, which is
+// omitted for the last page. Requests without pagination or filter parameters only get the
+// list of records, as before the pagination of the history endpoints
+func writePage(w http.ResponseWriter, v url.Values, items interface{}, next *types.Cursor) {
+	if !isPageRequest(v) {
+		httputils.WriteJSON(w, http.StatusOK, items)
+		return
+	}
+
+	res := map[string]interface{}{"data": items}
+	if next != nil {
+		res["next"] = next.Encode()
+	}
+
+	httputils.Write(w, http.StatusOK, res)
+}
@@ -25,7 +25,7 @@ func ServePairResource(
 	e := &pairEndpoint{p}
 	r.HandleFunc("/pairs", e.HandleGetPairs).Methods("GET")
 	r.HandleFunc("/pair", e.HandleGetPair).Methods("GET")
-	r.HandleFunc("/pair", e.HandleCreatePair).Methods("POST")
+	r.HandleFunc("/pair", adminOnly(e.HandleCreatePair)).Methods("POST")
 	r.HandleFunc("/pairs/data", e.HandleGetPairData).Methods("GET")
 }
 
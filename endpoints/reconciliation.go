@@ -0,0 +1,50 @@
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tomochain/dex-server/interfaces"
+	"github.com/tomochain/dex-server/utils/httputils"
+)
+
+type reconciliationEndpoint struct {
+	reconciliationService interfaces.ReconciliationService
+}
+
+// ServeReconciliationResource sets up the routing of the fill reconciliation endpoints
+func ServeReconciliationResource(
+	r *mux.Router,
+	reconciliationService interfaces.ReconciliationService,
+) {
+
+	e := &reconciliationEndpoint{reconciliationService}
+	r.HandleFunc("/admin/reconciliation", adminOnly(e.handleGetReport)).Methods("GET")
+	r.HandleFunc("/admin/reconciliation", adminOnly(e.handleReconcile)).Methods("POST")
+}
+
+// handleGetReport returns the report of the last reconciliation run
+func (e *reconciliationEndpoint) handleGetReport(w http.ResponseWriter, r *http.Request) {
+	res := e.reconciliationService.GetLastReport()
+	if res == nil {
+		httputils.WriteError(w, http.StatusNotFound, "No reconciliation report available")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+// handleReconcile triggers a reconciliation run without waiting for the cron. Discrepancies
+// are only corrected if the autoCorrect query parameter is set to true
+func (e *reconciliationEndpoint) handleReconcile(w http.ResponseWriter, r *http.Request) {
+	autoCorrect := r.URL.Query().Get("autoCorrect") == "true"
+
+	res, err := e.reconciliationService.Reconcile(autoCorrect)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
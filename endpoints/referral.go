@@ -0,0 +1,197 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	"github.com/tomochain/dex-server/interfaces"
+	"github.com/tomochain/dex-server/services"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/httputils"
+)
+
+type referralEndpoint struct {
+	referralService interfaces.ReferralService
+}
+
+// ServeReferralResource sets up the routing of referral endpoints and the corresponding handlers.
+func ServeReferralResource(
+	r *mux.Router,
+	referralService interfaces.ReferralService,
+) {
+
+	e := &referralEndpoint{referralService}
+	r.HandleFunc("/referrals", e.handleRegisterReferral).Methods("POST")
+	r.HandleFunc("/referrals/{address}", e.handleGetReferralInfo).Methods("GET")
+	r.HandleFunc("/referrals/{address}/referees", e.handleGetReferees).Methods("GET")
+	r.HandleFunc("/referrals/{address}/rebates", e.handleGetRebates).Methods("GET")
+}
+
+func (e *referralEndpoint) handleRegisterReferral(w http.ResponseWriter, r *http.Request) {
+	reg := &types.ReferralRegistration{}
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(reg)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+
+	defer r.Body.Close()
+
+	err = reg.Validate()
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	referral, err := e.referralService.Register(reg)
+	if err != nil {
+		switch err {
+		case services.ErrInvalidSignature, services.ErrReferralExists, services.ErrReferralCycle:
+			httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		default:
+			logger.Error(err)
+			httputils.WriteError(w, http.StatusInternalServerError, "")
+		}
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusCreated, referral)
+}
+
+// handleGetReferralInfo returns the referrer of an account, its number of referees and the rebates
+// accrued from their trades
+func (e *referralEndpoint) handleGetReferralInfo(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	addr := vars["address"]
+	if !common.IsHexAddress(addr) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+		return
+	}
+
+	address := common.HexToAddress(addr)
+	referral, err := e.referralService.GetReferral(address)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	referees, err := e.referralService.GetReferees(address)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	balances, err := e.referralService.GetBalances(address)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	rebates := []map[string]string{}
+	for token, amount := range balances {
+		rebates = append(rebates, map[string]string{
+			"quoteToken": token.Hex(),
+			"amount":     amount.String(),
+		})
+	}
+
+	res := map[string]interface{}{
+		"address":      address.Hex(),
+		"refereeCount": len(referees),
+		"rebates":      rebates,
+	}
+
+	if referral != nil {
+		res["referrerAddress"] = referral.ReferrerAddress.Hex()
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+// handleGetReferees returns the referees of an account with the volume they traded and the
+// rebates they earned by quote token
+func (e *referralEndpoint) handleGetReferees(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	addr := vars["address"]
+	if !common.IsHexAddress(addr) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+		return
+	}
+
+	address := common.HexToAddress(addr)
+	referees, err := e.referralService.GetReferees(address)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	summaries, err := e.referralService.GetRefereeSummaries(address)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	volumes := map[common.Address][]*types.ReferralSummary{}
+	for _, s := range summaries {
+		volumes[s.RefereeAddress] = append(volumes[s.RefereeAddress], s)
+	}
+
+	res := []map[string]interface{}{}
+	for _, referee := range referees {
+		v := volumes[referee.RefereeAddress]
+		if v == nil {
+			v = []*types.ReferralSummary{}
+		}
+
+		res = append(res, map[string]interface{}{
+			"refereeAddress": referee.RefereeAddress.Hex(),
+			"createdAt":      referee.CreatedAt.Format(time.RFC3339Nano),
+			"volumes":        v,
+		})
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+func (e *referralEndpoint) handleGetRebates(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	addr := vars["address"]
+	if !common.IsHexAddress(addr) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+		return
+	}
+
+	var err error
+	var rebates []*types.ReferralRebate
+	address := common.HexToAddress(addr)
+
+	limit := r.URL.Query().Get("limit")
+	if limit == "" {
+		rebates, err = e.referralService.GetRebates(address)
+	} else {
+		lim, _ := strconv.Atoi(limit)
+		rebates, err = e.referralService.GetRebates(address, lim)
+	}
+
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, rebates)
+}
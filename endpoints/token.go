@@ -28,7 +28,7 @@ func ServeTokenResource(
 	r.HandleFunc("/tokens/quote", e.HandleGetQuoteTokens).Methods("GET")
 	r.HandleFunc("/tokens/{address}", e.HandleGetToken).Methods("GET")
 	r.HandleFunc("/tokens", e.HandleGetTokens).Methods("GET")
-	r.HandleFunc("/tokens", e.HandleCreateTokens).Methods("POST")
+	r.HandleFunc("/tokens", adminOnly(e.HandleCreateTokens)).Methods("POST")
 
 	ws.RegisterChannel(ws.TokenChannel, e.ws)
 }
@@ -42,6 +42,8 @@ func TestHandleCreateTokens(t *testing.T) {
 		t.Error(err)
 	}
 
+	req.Header.Set("Authorization", testAuthHeader(t, common.HexToAddress("0x2"), types.AUTH_ROLE_ADMIN))
+
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
 
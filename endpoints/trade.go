@@ -3,7 +3,6 @@ package endpoints
 import (
 	"encoding/json"
 	"net/http"
-	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
@@ -26,44 +25,41 @@ func ServeTradeResource(
 	e := &tradeEndpoint{tradeService}
 	r.HandleFunc("/trades/pair", e.HandleGetTradeHistory)
 	r.HandleFunc("/trades", e.HandleGetTrades)
+	r.HandleFunc("/trades/{hash}", e.HandleGetTrade).Methods("GET")
 	ws.RegisterChannel(ws.TradeChannel, e.tradeWebsocket)
 }
 
-// history is reponsible for handling pair's trade history requests
+// errTradeSide is returned for trade history requests filtered by side, as trades are both a buy
+// and a sell and do not record a side
+const errTradeSide = "side parameter is not supported for trades"
+
+// HandleGetTradeHistory returns a page of the trades of a pair, most recent first. The trades can be
+// filtered by status and creation date, and the next page is fetched with the returned cursor
 func (e *tradeEndpoint) HandleGetTradeHistory(w http.ResponseWriter, r *http.Request) {
 	v := r.URL.Query()
-	bt := v.Get("baseToken")
-	qt := v.Get("quoteToken")
-	l := v.Get("limit")
 
-	if bt == "" {
+	if v.Get("baseToken") == "" {
 		httputils.WriteError(w, http.StatusBadRequest, "baseToken Parameter missing")
 		return
 	}
 
-	if qt == "" {
+	if v.Get("quoteToken") == "" {
 		httputils.WriteError(w, http.StatusBadRequest, "quoteToken Parameter missing")
 		return
 	}
 
-	if !common.IsHexAddress(bt) {
-		httputils.WriteError(w, http.StatusBadRequest, "Invalid base token address")
+	if v.Get("side") != "" {
+		httputils.WriteError(w, http.StatusBadRequest, errTradeSide)
 		return
 	}
 
-	if !common.IsHexAddress(qt) {
-		httputils.WriteError(w, http.StatusBadRequest, "Invalid quote token address")
+	f, err := parseHistoryFilter(v, 20)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	limit := 20
-	if l != "" {
-		limit, _ = strconv.Atoi(l)
-	}
-
-	baseToken := common.HexToAddress(bt)
-	quoteToken := common.HexToAddress(qt)
-	res, err := e.tradeService.GetSortedTrades(baseToken, quoteToken, limit)
+	res, next, err := e.tradeService.GetPage(f)
 	if err != nil {
 		logger.Error(err)
 		httputils.WriteError(w, http.StatusInternalServerError, "")
@@ -71,18 +67,17 @@ func (e *tradeEndpoint) HandleGetTradeHistory(w http.ResponseWriter, r *http.Req
 	}
 
 	if res == nil {
-		httputils.WriteJSON(w, http.StatusOK, []types.Trade{})
-		return
+		res = []*types.Trade{}
 	}
 
-	httputils.WriteJSON(w, http.StatusOK, res)
+	writePage(w, v, res, next)
 }
 
-// get is reponsible for handling user's trade history requests
+// HandleGetTrades returns a page of the trades of an account as maker or taker, with the same
+// filters as HandleGetTradeHistory and an optional pair
 func (e *tradeEndpoint) HandleGetTrades(w http.ResponseWriter, r *http.Request) {
 	v := r.URL.Query()
 	addr := v.Get("address")
-	limit := v.Get("limit")
 
 	if addr == "" {
 		httputils.WriteError(w, http.StatusBadRequest, "address Parameter missing")
@@ -94,13 +89,19 @@ func (e *tradeEndpoint) HandleGetTrades(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	lim := 100
-	if limit != "" {
-		lim, _ = strconv.Atoi(limit)
+	if v.Get("side") != "" {
+		httputils.WriteError(w, http.StatusBadRequest, errTradeSide)
+		return
+	}
+
+	f, err := parseHistoryFilter(v, types.DefaultPageLimit)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
 	}
 
-	address := common.HexToAddress(addr)
-	res, err := e.tradeService.GetSortedTradesByUserAddress(address, lim)
+	f.UserAddress = common.HexToAddress(addr)
+	res, next, err := e.tradeService.GetPage(f)
 	if err != nil {
 		logger.Error(err)
 		httputils.WriteError(w, http.StatusInternalServerError, "")
@@ -108,11 +109,35 @@ func (e *tradeEndpoint) HandleGetTrades(w http.ResponseWriter, r *http.Request)
 	}
 
 	if res == nil {
-		httputils.WriteJSON(w, http.StatusOK, []types.Trade{})
+		res = []*types.Trade{}
+	}
+
+	writePage(w, v, res, next)
+}
+
+// HandleGetTrade returns a trade from its hash
+func (e *tradeEndpoint) HandleGetTrade(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	hash := vars["hash"]
+	if !isValidHash(hash) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Hash")
+		return
+	}
+
+	t, err := e.tradeService.GetByHash(common.HexToHash(hash))
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	if t == nil {
+		httputils.WriteError(w, http.StatusNotFound, "Trade not found")
 		return
 	}
 
-	httputils.WriteJSON(w, http.StatusOK, res)
+	httputils.WriteJSON(w, http.StatusOK, t)
 }
 
 func (e *tradeEndpoint) tradeWebsocket(input interface{}, c *ws.Client) {
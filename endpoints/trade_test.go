@@ -0,0 +1,43 @@
+package endpoints
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	"github.com/tomochain/dex-server/utils/testutils/mocks"
+)
+
+func SetupTradeEndpointTest() (*mux.Router, *mocks.TradeService) {
+	r := mux.NewRouter()
+	tradeService := new(mocks.TradeService)
+
+	ServeTradeResource(r, tradeService)
+
+	return r, tradeService
+}
+
+func TestHandleGetTradesSide(t *testing.T) {
+	router, tradeService := SetupTradeEndpointTest()
+
+	base := common.HexToAddress("0x1").Hex()
+	quote := common.HexToAddress("0x2").Hex()
+	urls := []string{
+		"/trades?address=" + base + "&side=BUY",
+		"/trades/pair?baseToken=" + base + "&quoteToken=" + quote + "&side=SELL",
+	}
+
+	for _, url := range urls {
+		req, _ := http.NewRequest("GET", url, nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Handler return wrong status for %v. Got %v want %v", url, rr.Code, http.StatusBadRequest)
+		}
+	}
+
+	tradeService.AssertNotCalled(t, "GetPage")
+}
@@ -0,0 +1,19 @@
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tomochain/dex-server/utils/httputils"
+	"github.com/tomochain/dex-server/ws"
+)
+
+// ServeWebsocketResource sets up the routing of the admin endpoint reporting the connections
+// of the websocket hub and the clients disconnected for not reading their messages
+func ServeWebsocketResource(r *mux.Router) {
+	r.HandleFunc("/admin/websocket", adminOnly(handleGetWebsocketMetrics)).Methods("GET")
+}
+
+func handleGetWebsocketMetrics(w http.ResponseWriter, r *http.Request) {
+	httputils.WriteJSON(w, http.StatusOK, ws.GetHubMetrics())
+}
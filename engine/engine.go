@@ -127,6 +127,12 @@ func (e *Engine) HandleOrders(msg *rabbitmq.Message) error {
 			logger.Error(err)
 			return err
 		}
+	case "CORRECT_ORDER":
+		err := e.handleCorrectOrder(msg.Data)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
 	case "INVALIDATE_MAKER_ORDERS":
 		err := e.handleInvalidateMakerOrders(msg.Data)
 		if err != nil {
@@ -230,6 +236,34 @@ func (e *Engine) handleCancelOrder(bytes []byte) error {
 	return nil
 }
 
+func (e *Engine) handleCorrectOrder(bytes []byte) error {
+	o := &types.Order{}
+	err := json.Unmarshal(bytes, o)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	code, err := o.PairCode()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	ob := e.orderbooks[code]
+	if ob == nil {
+		return errors.New("Orderbook error")
+	}
+
+	err = ob.correctOrder(o)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
 func (e *Engine) handleInvalidateMakerOrders(bytes []byte) error {
 	m := types.Matches{}
 	err := json.Unmarshal(bytes, &m)
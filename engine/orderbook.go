@@ -296,6 +296,38 @@ func (ob *OrderBook) cancelOrder(o *types.Order) error {
 	return nil
 }
 
+// correctOrder applies the filled amount and the status of an order corrected from the exchange
+// contract state. The orders that can still be filled are run through the orderbook again, since
+// they might match the orders added while their filled amount was wrong
+func (ob *OrderBook) correctOrder(o *types.Order) error {
+	if o.Status == "OPEN" || o.Status == "PARTIAL_FILLED" {
+		return ob.newOrder(o)
+	}
+
+	ob.mutex.Lock()
+	defer ob.mutex.Unlock()
+
+	_, err := ob.orderDao.FindAndModify(o.Hash, o)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	res := &types.EngineResponse{
+		Status:  types.ORDER_CORRECTED,
+		Order:   o,
+		Matches: nil,
+	}
+
+	err = ob.rabbitMQConn.PublishEngineResponse(res)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
 // cancelTrades revertTrades and reintroduces the taker orders in the orderbook
 func (ob *OrderBook) invalidateMakerOrders(matches types.Matches) error {
 	ob.mutex.Lock()
@@ -117,6 +117,35 @@ func TestBuyOrder(t *testing.T) {
 	testutils.CompareEngineResponse(t, expected, res)
 }
 
+func TestCorrectOrder(t *testing.T) {
+	_, ob, _, _, _, _, _, _, factory1, factory2 := setupTest()
+
+	o1, _ := factory1.NewSellOrder(1e3, 1e8, 0)
+	o2, _ := factory2.NewBuyOrder(1e3, 1e8, 0)
+
+	_, err := ob.sellOrder(&o1)
+	if err != nil {
+		t.Error("Error in sell order: ", err)
+	}
+
+	o1.FilledAmount = o1.Amount
+	o1.Status = "FILLED"
+
+	err = ob.correctOrder(&o1)
+	if err != nil {
+		t.Error("Error in correct order: ", err)
+	}
+
+	matchingOrders, err := ob.orderDao.GetMatchingSellOrders(&o2)
+	if err != nil {
+		t.Error("Error getting matching orders: ", err)
+	}
+
+	if len(matchingOrders) != 0 {
+		t.Errorf("Expected the corrected order to be removed from the orderbook, got %v matching orders", len(matchingOrders))
+	}
+}
+
 func TestFillOrder1(t *testing.T) {
 	_, ob, _, _, _, _, _, _, factory1, factory2 := setupTest()
 
@@ -2,21 +2,30 @@ package ethereum
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	eth "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ethereum/go-ethereum/swarm/api/client"
 	"github.com/tomochain/dex-server/app"
 	"github.com/tomochain/dex-server/contracts/contractsinterfaces"
+	"github.com/tomochain/dex-server/errors"
 	"github.com/tomochain/dex-server/interfaces"
 	"github.com/tomochain/dex-server/utils"
 )
 
+// networkIDReader is implemented by the RPC clients, which can read the network ID of the
+// node when the chain ID is not configured
+type networkIDReader interface {
+	NetworkID(ctx context.Context) (*big.Int, error)
+}
+
 type EthereumProvider struct {
 	Client    interfaces.EthereumClient
 	BzzClient *client.Client
@@ -91,7 +100,19 @@ func NewSimulatedEthereumProvider(accs []common.Address) *EthereumProvider {
 }
 
 func (e *EthereumProvider) WaitMined(hash common.Hash) (*eth.Receipt, error) {
-	ctx := context.Background()
+	return e.waitMined(context.Background(), hash)
+}
+
+// WaitMinedWithTimeout waits for the transaction receipt and returns an error
+// if the transaction has not been mined before the timeout expires
+func (e *EthereumProvider) WaitMinedWithTimeout(hash common.Hash, timeout time.Duration) (*eth.Receipt, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return e.waitMined(ctx, hash)
+}
+
+func (e *EthereumProvider) waitMined(ctx context.Context, hash common.Hash) (*eth.Receipt, error) {
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
 
@@ -101,11 +122,6 @@ func (e *EthereumProvider) WaitMined(hash common.Hash) (*eth.Receipt, error) {
 			return receipt, nil
 		}
 
-		// if err != nil {
-		// 	logger.Error(err)
-		// 	// return nil, err
-		// }
-
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
@@ -136,6 +152,100 @@ func (e *EthereumProvider) GetPendingNonceAt(a common.Address) (uint64, error) {
 	return nonce, nil
 }
 
+// ChainID returns the chain ID used to sign transactions. It is read from the chain_id
+// configuration when it is set, and from the network ID of the node otherwise
+func (e *EthereumProvider) ChainID() (*big.Int, error) {
+	if app.Config.Ethereum["chain_id"] != "" {
+		chainID, ok := new(big.Int).SetString(app.Config.Ethereum["chain_id"], 10)
+		if !ok {
+			err := errors.New("Invalid chain ID configuration")
+			logger.Error(err)
+			return nil, err
+		}
+
+		return chainID, nil
+	}
+
+	c, ok := e.Client.(networkIDReader)
+	if !ok {
+		err := errors.New("Chain ID configuration not found")
+		logger.Error(err)
+		return nil, err
+	}
+
+	chainID, err := c.NetworkID(context.Background())
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return chainID, nil
+}
+
+// NewTransactor returns the transaction options of the account corresponding to the given
+// key. Unlike bind.NewKeyedTransactor, the transactions are signed with an EIP155 signer for
+// the chain ID, so that they can not be replayed on another chain
+func (e *EthereumProvider) NewTransactor(key *ecdsa.PrivateKey) (*bind.TransactOpts, error) {
+	chainID, err := e.ChainID()
+	if err != nil {
+		return nil, err
+	}
+
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	signer := eth.NewEIP155Signer(chainID)
+
+	return &bind.TransactOpts{
+		From: from,
+		Signer: func(_ eth.Signer, a common.Address, tx *eth.Transaction) (*eth.Transaction, error) {
+			if a != from {
+				return nil, errors.New("Not authorized to sign this account")
+			}
+
+			return eth.SignTx(tx, signer, key)
+		},
+	}, nil
+}
+
+// SendEther signs and sends a plain native token transfer from the account
+// corresponding to the given key and returns the transaction hash. The transaction
+// is signed for the chain ID so that it can not be replayed on another chain
+func (e *EthereumProvider) SendEther(key *ecdsa.PrivateKey, to common.Address, amount *big.Int) (common.Hash, error) {
+	ctx := context.Background()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	opts, err := e.NewTransactor(key)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	nonce, err := e.Client.PendingNonceAt(ctx, from)
+	if err != nil {
+		logger.Error(err)
+		return common.Hash{}, err
+	}
+
+	gasPrice, err := e.Client.SuggestGasPrice(ctx)
+	if err != nil {
+		logger.Error(err)
+		return common.Hash{}, err
+	}
+
+	tx := eth.NewTransaction(nonce, to, amount, 21000, gasPrice, nil)
+	signedTx, err := opts.Signer(nil, from, tx)
+	if err != nil {
+		logger.Error(err)
+		return common.Hash{}, err
+	}
+
+	err = e.Client.SendTransaction(ctx, signedTx)
+	if err != nil {
+		logger.Error(err)
+		return common.Hash{}, err
+	}
+
+	return signedTx.Hash(), nil
+}
+
 func (e *EthereumProvider) Decimals(token common.Address) (uint8, error) {
 	var tokenInterface *contractsinterfaces.ERC20
 	var err error
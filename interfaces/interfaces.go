@@ -2,7 +2,9 @@ package interfaces
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"math/big"
+	"time"
 
 	ethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -32,6 +34,9 @@ type OrderDao interface {
 	GetByUserAddress(addr common.Address, limit ...int) ([]*types.Order, error)
 	GetCurrentByUserAddress(a common.Address, limit ...int) ([]*types.Order, error)
 	GetHistoryByUserAddress(a common.Address, limit ...int) ([]*types.Order, error)
+	GetPage(f *types.HistoryFilter) ([]*types.Order, *types.Cursor, error)
+	GetHistoryPage(f *types.HistoryFilter) ([]*types.Order, *types.Cursor, error)
+	GetOpenOrders() ([]*types.Order, error)
 	GetMatchingBuyOrders(o *types.Order) ([]*types.Order, error)
 	GetMatchingSellOrders(o *types.Order) ([]*types.Order, error)
 	UpdateOrderFilledAmount(h common.Hash, value *big.Int) error
@@ -59,6 +64,9 @@ type AccountDao interface {
 	UpdateBalance(owner common.Address, token common.Address, balance *big.Int) (err error)
 	FindOrCreate(addr common.Address) (*types.Account, error)
 	UpdateAllowance(owner common.Address, token common.Address, allowance *big.Int) (err error)
+	UpdateLockedBalance(owner common.Address, token common.Address, lockedBalance *big.Int) (err error)
+	UpdateRestriction(owner common.Address, r *types.AccountRestriction) error
+	ClearBalanceCache(tokens ...common.Address) error
 	Drop()
 }
 
@@ -82,6 +90,13 @@ type AssociationDao interface {
 	SaveAssociationStatus(chain types.Chain, sourceAccount common.Address, status string) error
 }
 
+type HeldDepositDao interface {
+	Hold(d *types.HeldDeposit) error
+	GetByAssociatedAddress(addr common.Address, status ...string) ([]*types.HeldDeposit, error)
+	UpdateStatus(id bson.ObjectId, status string) error
+	Drop()
+}
+
 type WalletDao interface {
 	Create(wallet *types.Wallet) error
 	GetAll() ([]types.Wallet, error)
@@ -89,6 +104,7 @@ type WalletDao interface {
 	GetByAddress(addr common.Address) (*types.Wallet, error)
 	GetDefaultAdminWallet() (*types.Wallet, error)
 	GetOperatorWallets() ([]*types.Wallet, error)
+	SetOperator(a common.Address, isOperator bool) error
 }
 
 type PairDao interface {
@@ -101,6 +117,7 @@ type PairDao interface {
 	GetByTokenAddress(baseToken, quoteToken common.Address) (*types.Pair, error)
 	GetListedPairs() ([]types.Pair, error)
 	GetUnlistedPairs() ([]types.Pair, error)
+	SetActive(bt, qt common.Address, active bool) error
 }
 
 type TradeDao interface {
@@ -114,16 +131,20 @@ type TradeDao interface {
 	GetByMakerOrderHash(h common.Hash) ([]*types.Trade, error)
 	GetByTakerOrderHash(h common.Hash) ([]*types.Trade, error)
 	GetByOrderHashes(hashes []common.Hash) ([]*types.Trade, error)
+	GetByStatus(status string) ([]*types.Trade, error)
 	GetSortedTrades(bt, qt common.Address, n int) ([]*types.Trade, error)
 	GetSortedTradesByUserAddress(a common.Address, limit ...int) ([]*types.Trade, error)
+	GetPage(f *types.HistoryFilter) ([]*types.Trade, *types.Cursor, error)
 	GetNTradesByPairAddress(bt, qt common.Address, n int) ([]*types.Trade, error)
 	GetTradesByPairAddress(bt, qt common.Address, n int) ([]*types.Trade, error)
 	GetAllTradesByPairAddress(bt, qt common.Address) ([]*types.Trade, error)
 	FindAndModify(h common.Hash, t *types.Trade) (*types.Trade, error)
 	GetByUserAddress(a common.Address) ([]*types.Trade, error)
 	UpdateTradeStatus(h common.Hash, status string) error
+	UpdateTradeSettlementError(h common.Hash, e *types.SettlementError) error
 	UpdateTradeStatuses(status string, hashes ...common.Hash) ([]*types.Trade, error)
 	UpdateTradeStatusesByOrderHashes(status string, hashes ...common.Hash) ([]*types.Trade, error)
+	GetUserVolumes(a, quoteToken common.Address, since time.Time) (map[common.Address]*big.Int, error)
 	Drop()
 }
 
@@ -137,6 +158,107 @@ type TokenDao interface {
 	Drop() error
 }
 
+type GasTopUpDao interface {
+	Create(t *types.GasTopUp) error
+	UpdateStatus(h common.Hash, status string) error
+	UpdateTransaction(id bson.ObjectId, h common.Hash, status string) error
+	GetAll(limit ...int) ([]*types.GasTopUp, error)
+	GetByWalletAddress(a common.Address, limit ...int) ([]*types.GasTopUp, error)
+	GetTotalSince(since time.Time, wallet ...common.Address) (*big.Int, error)
+}
+
+type AdminTransactionDao interface {
+	Create(t *types.AdminTransaction) error
+	UpdateStatus(h common.Hash, status string) error
+	GetAll(limit ...int) ([]*types.AdminTransaction, error)
+	GetByHash(h common.Hash) (*types.AdminTransaction, error)
+}
+
+type AccountRestrictionDao interface {
+	Create(c *types.AccountRestrictionChange) error
+	GetByAddress(a common.Address, limit ...int) ([]*types.AccountRestrictionChange, error)
+}
+
+type AccountNonceDao interface {
+	GetMinNonce(addr common.Address) (*big.Int, error)
+	SetMinNonce(addr common.Address, nonce *big.Int) error
+	Drop()
+}
+
+type OrderLockDao interface {
+	GetAll() ([]*types.OrderLock, error)
+	GetByHash(h common.Hash) (*types.OrderLock, error)
+	Upsert(l *types.OrderLock) error
+	DeleteByHash(h common.Hash) error
+	Drop()
+}
+
+type FeeScheduleDao interface {
+	GetAll() ([]*types.FeeSchedule, error)
+	GetByQuoteToken(quoteToken common.Address) (*types.FeeSchedule, error)
+	Upsert(s *types.FeeSchedule) error
+	DeleteByQuoteToken(quoteToken common.Address) error
+	Drop()
+}
+
+type FeeLedgerDao interface {
+	Upsert(e *types.FeeLedgerEntry) error
+	GetByTradeHash(h common.Hash) (*types.FeeLedgerEntry, error)
+	GetDailyRevenues(from, to time.Time) ([]*types.FeeRevenue, error)
+	Drop()
+}
+
+type ReferralDao interface {
+	Create(r *types.Referral) error
+	GetByReferee(addr common.Address) (*types.Referral, error)
+	GetByReferrer(addr common.Address) ([]*types.Referral, error)
+	Drop()
+}
+
+type ReferralRebateDao interface {
+	Upsert(r *types.ReferralRebate) error
+	GetByReferrer(addr common.Address, limit ...int) ([]*types.ReferralRebate, error)
+	GetBalances(addr common.Address) (map[common.Address]*big.Int, error)
+	GetRefereeSummaries(addr common.Address) ([]*types.ReferralSummary, error)
+	Drop()
+}
+
+type OrderEventDao interface {
+	Create(events ...*types.OrderEvent) error
+	GetByOrderHash(h common.Hash) ([]*types.OrderEvent, error)
+	Drop()
+}
+
+type AuthChallengeDao interface {
+	Upsert(c *types.AuthChallenge) error
+	Consume(addr common.Address, nonce common.Hash) (*types.AuthChallenge, error)
+	Drop()
+}
+
+type APIKeyDao interface {
+	Create(k *types.APIKey) error
+	GetByKey(key string) (*types.APIKey, error)
+	GetByUserAddress(addr common.Address) ([]*types.APIKey, error)
+	Delete(addr common.Address, key string) (*types.APIKey, error)
+	Drop()
+}
+
+type HardCancelDao interface {
+	Queue(o *types.Order) error
+	UpdateStatus(hashes []common.Hash, status string, txHash common.Hash) error
+	GetByOrderHash(h common.Hash) (*types.HardCancel, error)
+	GetPending() ([]*types.HardCancel, error)
+	Drop()
+}
+
+type FeeOverrideDao interface {
+	GetByUserAddress(addr common.Address) ([]*types.FeeOverride, error)
+	GetByQuoteToken(addr, quoteToken common.Address) (*types.FeeOverride, error)
+	Upsert(o *types.FeeOverride) error
+	Delete(addr, quoteToken common.Address) error
+	Drop()
+}
+
 type Exchange interface {
 	GetAddress() common.Address
 	GetTxCallOptions() *bind.CallOpts
@@ -144,13 +266,35 @@ type Exchange interface {
 	SetOperator(a common.Address, isOperator bool, txOpts *bind.TransactOpts) (*eth.Transaction, error)
 	CallTrade(m *types.Matches, call *ethereum.CallMsg) (uint64, error)
 	CallBatchTrades(m *types.Matches, txOpts *ethereum.CallMsg) (uint64, error)
+	SetOwner(a common.Address, txOpts *bind.TransactOpts) (*eth.Transaction, error)
+	SetWethToken(a common.Address, txOpts *bind.TransactOpts) (*eth.Transaction, error)
+	RegisterPair(bt, qt common.Address, multiplier *big.Int, txOpts *bind.TransactOpts) (*eth.Transaction, error)
 	FeeAccount() (common.Address, error)
 	Operator(a common.Address) (bool, error)
+	Owner() (common.Address, error)
+	WethToken() (common.Address, error)
+	Version() (string, error)
+	RewardAccount() (common.Address, error)
+	CheckCompatibility(weth, rewardAccount common.Address) (*types.ContractStatus, error)
+	PairIsRegistered(bt, qt common.Address) (bool, error)
+	GetPairPricepointMultiplier(bt, qt common.Address) (*big.Int, error)
+	Filled(h common.Hash) (*big.Int, error)
+	Traded(h common.Hash) (bool, error)
+	IsValidSignature(signer common.Address, h common.Hash, sig *types.Signature) (bool, error)
+	IsRoundingError(numerator, denominator, target *big.Int) (bool, error)
 	Trade(m *types.Matches, txOpts *bind.TransactOpts) (*eth.Transaction, error)
 	ExecuteBatchTrades(m *types.Matches, txOpts *bind.TransactOpts) (*eth.Transaction, error)
+	CancelOrder(o *types.Order, txOpts *bind.TransactOpts) (*eth.Transaction, error)
+	BatchCancelOrders(orders []*types.Order, txOpts *bind.TransactOpts) (*eth.Transaction, error)
 	ListenToErrors() (chan *contractsinterfaces.ExchangeLogError, error)
 	ListenToTrades() (chan *contractsinterfaces.ExchangeLogTrade, error)
 	ListenToBatchTrades() (chan *contractsinterfaces.ExchangeLogBatchTrades, error)
+	ListenToOperatorUpdates() (chan *contractsinterfaces.ExchangeLogOperatorUpdate, error)
+	ListenToCancelOrders() (chan *contractsinterfaces.ExchangeLogCancelOrder, error)
+	FilterTrades(start uint64) ([]*contractsinterfaces.ExchangeLogTrade, error)
+	FilterBatchTrades(start uint64) ([]*contractsinterfaces.ExchangeLogBatchTrades, error)
+	FilterErrors(start uint64) ([]*contractsinterfaces.ExchangeLogError, error)
+	FilterCancelOrders(start uint64) ([]*contractsinterfaces.ExchangeLogCancelOrder, error)
 	GetErrorEvents(logs chan *contractsinterfaces.ExchangeLogError) error
 	GetTrades(logs chan *contractsinterfaces.ExchangeLogTrade) error
 	PrintTrades() error
@@ -177,6 +321,79 @@ type WalletService interface {
 	GetByAddress(addr common.Address) (*types.Wallet, error)
 }
 
+type GasTopUpService interface {
+	TopUpOperatorWallets() ([]*types.GasTopUp, error)
+	GetAll(limit ...int) ([]*types.GasTopUp, error)
+	GetByWalletAddress(a common.Address, limit ...int) ([]*types.GasTopUp, error)
+}
+
+type GovernanceService interface {
+	GetExchangeInfo() (map[string]interface{}, error)
+	IsOperator(a common.Address) (bool, error)
+	SetFeeAccount(a common.Address) (*types.AdminTransaction, error)
+	SetOperator(a common.Address, isOperator bool) (*types.AdminTransaction, error)
+	SetOwner(a common.Address) (*types.AdminTransaction, error)
+	SetWethToken(a common.Address) (*types.AdminTransaction, error)
+	RegisterPair(bt, qt common.Address, multiplier *big.Int) (*types.AdminTransaction, error)
+	GetTransactions(limit ...int) ([]*types.AdminTransaction, error)
+	GetTransactionByHash(h common.Hash) (*types.AdminTransaction, error)
+	SyncOperatorWallets() error
+}
+
+type BalanceService interface {
+	Start() error
+	GetBalance(owner, token common.Address) (*big.Int, error)
+	GetAllowance(owner, token common.Address) (*big.Int, error)
+}
+
+type LockedBalanceService interface {
+	UpdateOrders(hashes ...common.Hash)
+	GetLockedBalance(owner, token common.Address) (*big.Int, error)
+	Recompute() error
+}
+
+type FeeService interface {
+	ResolveFees(addr common.Address, p *types.Pair) (*types.ResolvedFee, error)
+	GetEffectiveFees(addr common.Address) ([]*types.ResolvedFee, error)
+	GetUserVolume(addr, quoteToken common.Address) (*big.Int, error)
+	GetSchedules() ([]*types.FeeSchedule, error)
+	SetSchedule(s *types.FeeSchedule) error
+	DeleteSchedule(quoteToken common.Address) error
+	GetOverrides(addr common.Address) ([]*types.FeeOverride, error)
+	SetOverride(o *types.FeeOverride) error
+	DeleteOverride(addr, quoteToken common.Address) error
+	RecordSettledTrades(m *types.Matches)
+	GetDailyRevenues(from, to time.Time) ([]*types.FeeRevenue, error)
+}
+
+type ReferralService interface {
+	Register(r *types.ReferralRegistration) (*types.Referral, error)
+	GetReferral(addr common.Address) (*types.Referral, error)
+	GetReferees(addr common.Address) ([]*types.Referral, error)
+	GetRefereeSummaries(addr common.Address) ([]*types.ReferralSummary, error)
+	GetRebates(addr common.Address, limit ...int) ([]*types.ReferralRebate, error)
+	GetBalances(addr common.Address) (map[common.Address]*big.Int, error)
+	HandleTradesSettled(m *types.Matches)
+}
+
+type AuthService interface {
+	NewChallenge(addr common.Address) (*types.AuthChallenge, error)
+	Login(l *types.AuthLogin) (string, *types.AuthClaims, error)
+	AuthenticateSubscription(s *types.PrivateSubscription) (*types.Authentication, error)
+}
+
+type APIKeyService interface {
+	Create(k *types.APIKey) (string, error)
+	GetByUserAddress(addr common.Address) ([]*types.APIKey, error)
+	Revoke(addr common.Address, key string) error
+	Authenticate(req *types.APIKeyRequest) (*types.Authentication, error)
+}
+
+type ReconciliationService interface {
+	Reconcile(autoCorrect bool) (*types.ReconciliationReport, error)
+	GetLastReport() *types.ReconciliationReport
+}
+
 type OHLCVService interface {
 	Unsubscribe(c *ws.Client)
 	UnsubscribeChannel(c *ws.Client, p *types.SubscriptionPayload)
@@ -199,8 +416,16 @@ type OrderService interface {
 	GetByUserAddress(a common.Address, limit ...int) ([]*types.Order, error)
 	GetCurrentByUserAddress(a common.Address, limit ...int) ([]*types.Order, error)
 	GetHistoryByUserAddress(a common.Address, limit ...int) ([]*types.Order, error)
+	GetPage(f *types.HistoryFilter) ([]*types.Order, *types.Cursor, error)
+	GetHistoryPage(f *types.HistoryFilter) ([]*types.Order, *types.Cursor, error)
 	NewOrder(o *types.Order) error
+	NewOrderAndWait(o *types.Order, timeout time.Duration) (*types.EngineResponse, error)
 	CancelOrder(oc *types.OrderCancel) error
+	CancelOrderAndWait(oc *types.OrderCancel, timeout time.Duration) (*types.EngineResponse, error)
+	GetMinNonce(addr common.Address) (*big.Int, error)
+	SetMinNonce(m *types.MinNonce) ([]*types.Order, error)
+	UpdateFilledAmount(o *types.Order, filledAmount *big.Int) error
+	GetEvents(h common.Hash) ([]*types.OrderEvent, error)
 	HandleEngineResponse(res *types.EngineResponse) error
 }
 
@@ -241,6 +466,7 @@ type TradeService interface {
 	GetAllTradesByPairAddress(bt, qt common.Address) ([]*types.Trade, error)
 	GetSortedTrades(bt, qt common.Address, n int) ([]*types.Trade, error)
 	GetSortedTradesByUserAddress(a common.Address, limit ...int) ([]*types.Trade, error)
+	GetPage(f *types.HistoryFilter) ([]*types.Trade, *types.Cursor, error)
 	GetByUserAddress(a common.Address) ([]*types.Trade, error)
 	GetByHash(h common.Hash) (*types.Trade, error)
 	GetByOrderHashes(h []common.Hash) ([]*types.Trade, error)
@@ -270,6 +496,8 @@ type AccountService interface {
 	FindOrCreate(a common.Address) (*types.Account, error)
 	GetTokenBalance(owner common.Address, token common.Address) (*types.TokenBalance, error)
 	GetTokenBalances(owner common.Address) (map[common.Address]*types.TokenBalance, error)
+	SetRestriction(a common.Address, r *types.AccountRestriction, source string) (*types.Account, error)
+	GetRestrictionHistory(a common.Address, limit ...int) ([]*types.AccountRestrictionChange, error)
 }
 
 type DepositService interface {
@@ -299,6 +527,11 @@ type DepositService interface {
 	QueueAdd(queueTx *types.DepositTransaction) error
 	QueuePool() (<-chan *types.DepositTransaction, error)
 
+	// deposits of restricted accounts
+	HoldTransaction(queueTx *types.DepositTransaction, addressAssociation *types.AddressAssociationRecord) error
+	GetHeldTransactions(addr common.Address) ([]*types.HeldDeposit, error)
+	ReleaseHeldTransactions(addr common.Address) ([]*types.HeldDeposit, error)
+
 	// help creating token
 	EthereumClient() EthereumClient
 	WethAddress() common.Address
@@ -318,6 +551,8 @@ type SwapEngineHandler interface {
 type ValidatorService interface {
 	ValidateBalance(o *types.Order) error
 	ValidateAvailableBalance(o *types.Order) error
+	ValidateTakerSettlement(to *types.Order, amount *big.Int) error
+	ValidateMakerSettlement(mo *types.Order, t *types.Trade) error
 }
 
 type EthereumConfig interface {
@@ -343,8 +578,12 @@ type EthereumClient interface {
 
 type EthereumProvider interface {
 	WaitMined(h common.Hash) (*eth.Receipt, error)
+	WaitMinedWithTimeout(h common.Hash, timeout time.Duration) (*eth.Receipt, error)
 	GetBalanceAt(a common.Address) (*big.Int, error)
 	GetPendingNonceAt(a common.Address) (uint64, error)
+	ChainID() (*big.Int, error)
+	NewTransactor(key *ecdsa.PrivateKey) (*bind.TransactOpts, error)
+	SendEther(key *ecdsa.PrivateKey, to common.Address, amount *big.Int) (common.Hash, error)
 	BalanceOf(owner common.Address, token common.Address) (*big.Int, error)
 	Allowance(owner, spender, token common.Address) (*big.Int, error)
 	ExchangeAllowance(owner, token common.Address) (*big.Int, error)
@@ -0,0 +1,242 @@
+package operator
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
+	"github.com/tomochain/dex-server/errors"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/math"
+)
+
+// hardCancelInterval is the maximum time a hard cancel request waits for other
+// requests before being submitted to the exchange contract
+const hardCancelInterval = 10 * time.Second
+
+// maxHardCancelBatchSize is the number of orders above which a batch is submitted
+// without waiting for the next interval
+const maxHardCancelBatchSize = 20
+
+// HandleHardCancel queues an order to be cancelled on the exchange contract. Queued orders
+// are cancelled in batches sent from an operator wallet. The queue is persisted, and an order
+// that was already queued or cancelled on the exchange contract is not queued again, so that a
+// replayed cancel request does not spend gas.
+func (op *Operator) HandleHardCancel(o *types.Order) error {
+	op.cancelMutex.Lock()
+	defer op.cancelMutex.Unlock()
+
+	for _, queued := range op.hardCancels {
+		if queued.Hash == o.Hash {
+			return nil
+		}
+	}
+
+	hc, err := op.HardCancelDao.GetByOrderHash(o.Hash)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if hc != nil && hc.IsActive() {
+		logger.Infof("Order %v is already hard cancelled (status: %v)", o.Hash.Hex(), hc.Status)
+		return nil
+	}
+
+	cancelled, err := op.isCancelledOnChain(o)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if cancelled {
+		logger.Infof("Order %v is already filled or cancelled on the exchange contract", o.Hash.Hex())
+		return nil
+	}
+
+	err = op.HardCancelDao.Queue(o)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	op.hardCancels = append(op.hardCancels, o)
+	if len(op.hardCancels) >= maxHardCancelBatchSize {
+		go op.submitHardCancels(op.takeHardCancels())
+	}
+
+	return nil
+}
+
+// restoreHardCancels queues the hard cancels that were queued or sent but not confirmed.
+// The orders that were cancelled in the meantime are removed before the next submission
+func (op *Operator) restoreHardCancels() error {
+	pending, err := op.HardCancelDao.GetPending()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	op.cancelMutex.Lock()
+	defer op.cancelMutex.Unlock()
+
+	for _, hc := range pending {
+		if hc.Order != nil {
+			op.hardCancels = append(op.hardCancels, hc.Order)
+		}
+	}
+
+	if len(pending) > 0 {
+		logger.Infof("Restored %v hard cancels", len(pending))
+	}
+
+	return nil
+}
+
+// handleHardCancels periodically submits the queued hard cancel requests. The transactions are
+// sent on the ticker goroutine but awaited in the background, so that a slow transaction does
+// not delay the next batches
+func (op *Operator) handleHardCancels() {
+	ticker := time.NewTicker(hardCancelInterval)
+
+	for range ticker.C {
+		op.cancelMutex.Lock()
+		orders := op.takeHardCancels()
+		op.cancelMutex.Unlock()
+
+		if len(orders) > 0 {
+			op.submitHardCancels(orders)
+		}
+	}
+}
+
+// takeHardCancels empties the hard cancel queue and returns its content.
+// The caller must hold cancelMutex
+func (op *Operator) takeHardCancels() []*types.Order {
+	orders := op.hardCancels
+	op.hardCancels = []*types.Order{}
+	return orders
+}
+
+// submitHardCancels sends a cancel transaction for the given orders and tracks its confirmation
+// in the background
+func (op *Operator) submitHardCancels(orders []*types.Order) {
+	orders = op.removeCancelledOnChain(orders)
+	if len(orders) == 0 {
+		return
+	}
+
+	tx, err := op.sendHardCancels(orders)
+	if err != nil {
+		logger.Error(err)
+		op.updateHardCancelStatus(orders, types.FAILED, common.Hash{})
+		op.publishHardCancelTxError(orders, common.Hash{})
+		return
+	}
+
+	op.updateHardCancelStatus(orders, types.HARD_CANCEL_SENT, tx.Hash())
+
+	err = op.Broker.PublishHardCancelTxPendingMessage(orders, tx.Hash())
+	if err != nil {
+		logger.Error(err)
+	}
+
+	go op.confirmHardCancels(orders, tx)
+}
+
+// confirmHardCancels waits for a cancel transaction to be mined and records its outcome
+func (op *Operator) confirmHardCancels(orders []*types.Order, tx *eth.Transaction) {
+	receipt, err := op.EthereumProvider.WaitMinedWithTimeout(tx.Hash(), settlementTimeout)
+	if err != nil || receipt.Status == 0 {
+		logger.Errorf("Hard cancel transaction failed: %v", tx.Hash().Hex())
+		op.updateHardCancelStatus(orders, types.FAILED, tx.Hash())
+		op.publishHardCancelTxError(orders, tx.Hash())
+		return
+	}
+
+	logger.Infof("Cancelled %v orders on the exchange contract (tx: %v)", len(orders), tx.Hash().Hex())
+	op.updateHardCancelStatus(orders, types.SUCCESS, tx.Hash())
+
+	err = op.Broker.PublishHardCancelTxSuccessMessage(orders, tx.Hash())
+	if err != nil {
+		logger.Error(err)
+	}
+}
+
+// removeCancelledOnChain returns the orders that are not yet filled or cancelled on the
+// exchange contract. The hard cancels of the other orders are marked as successful
+func (op *Operator) removeCancelledOnChain(orders []*types.Order) []*types.Order {
+	pending := []*types.Order{}
+	cancelled := []*types.Order{}
+
+	for _, o := range orders {
+		ok, err := op.isCancelledOnChain(o)
+		if err != nil {
+			logger.Error(err)
+		}
+
+		if ok {
+			cancelled = append(cancelled, o)
+			continue
+		}
+
+		pending = append(pending, o)
+	}
+
+	if len(cancelled) > 0 {
+		op.updateHardCancelStatus(cancelled, types.SUCCESS, common.Hash{})
+	}
+
+	return pending
+}
+
+// isCancelledOnChain returns true if the filled amount of an order on the exchange contract
+// reached its amount. Cancelled orders are marked as entirely filled by the contract
+func (op *Operator) isCancelledOnChain(o *types.Order) (bool, error) {
+	filled, err := op.Exchange.Filled(o.Hash)
+	if err != nil {
+		return false, err
+	}
+
+	return math.IsEqualOrGreaterThan(filled, o.Amount), nil
+}
+
+func (op *Operator) updateHardCancelStatus(orders []*types.Order, status string, h common.Hash) {
+	hashes := []common.Hash{}
+	for _, o := range orders {
+		hashes = append(hashes, o.Hash)
+	}
+
+	err := op.HardCancelDao.UpdateStatus(hashes, status, h)
+	if err != nil {
+		logger.Error(err)
+	}
+}
+
+func (op *Operator) sendHardCancels(orders []*types.Order) (*eth.Transaction, error) {
+	txq, _, err := op.GetShortestQueue()
+	if err != nil {
+		return nil, err
+	}
+
+	if txq.Wallet == nil {
+		return nil, errors.New("No operator wallet available")
+	}
+
+	// the transaction is sent by the queue so that it does not take the nonce of a trade
+	return txq.SendTx(func(txOpts *bind.TransactOpts) (*eth.Transaction, error) {
+		if len(orders) == 1 {
+			return op.Exchange.CancelOrder(orders[0], txOpts)
+		}
+
+		return op.Exchange.BatchCancelOrders(orders, txOpts)
+	})
+}
+
+func (op *Operator) publishHardCancelTxError(orders []*types.Order, h common.Hash) {
+	err := op.Broker.PublishHardCancelTxErrorMessage(orders, h)
+	if err != nil {
+		logger.Error(err)
+	}
+}
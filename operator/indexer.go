@@ -0,0 +1,383 @@
+package operator
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/tomochain/dex-server/errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/contracts/contractsinterfaces"
+	"github.com/tomochain/dex-server/interfaces"
+	"github.com/tomochain/dex-server/rabbitmq"
+	"github.com/tomochain/dex-server/types"
+)
+
+// reconciliationDelay leaves time to the transaction queues to process the receipts
+// of their own transactions before the corresponding live events are reconciled
+const reconciliationDelay = 30 * time.Second
+
+// Indexer follows the trade and error logs of the exchange smart contract and
+// corrects the trades whose status in the database disagrees with the chain.
+// It also cancels the orders that were cancelled directly on the exchange contract.
+// The last processed block is stored so that the indexer resumes where it stopped.
+type Indexer struct {
+	ConfigDao    interfaces.ConfigDao
+	TradeService interfaces.TradeService
+	OrderService interfaces.OrderService
+	Exchange     interfaces.Exchange
+	Broker       *rabbitmq.Connection
+	events       chan *chainEvent
+}
+
+// chainEvent wraps one of the exchange logs followed by the indexer
+type chainEvent struct {
+	Raw        eth.Log
+	Trade      *contractsinterfaces.ExchangeLogTrade
+	BatchTrade *contractsinterfaces.ExchangeLogBatchTrades
+	Error      *contractsinterfaces.ExchangeLogError
+	Cancel     *contractsinterfaces.ExchangeLogCancelOrder
+	ReceivedAt time.Time
+}
+
+// NewIndexer returns a new instance of Indexer
+func NewIndexer(
+	configDao interfaces.ConfigDao,
+	tradeService interfaces.TradeService,
+	orderService interfaces.OrderService,
+	exchange interfaces.Exchange,
+	conn *rabbitmq.Connection,
+) *Indexer {
+	return &Indexer{
+		ConfigDao:    configDao,
+		TradeService: tradeService,
+		OrderService: orderService,
+		Exchange:     exchange,
+		Broker:       conn,
+		events:       make(chan *chainEvent, 1000),
+	}
+}
+
+// Start subscribes to the exchange logs, reconciles the logs emitted since the last
+// processed block and then keeps reconciling new logs as they are received. Without a
+// processed block, the logs are reconciled from the configured deploy block of the
+// exchange contract, or from the current head if no deploy block is configured
+func (idx *Indexer) Start() error {
+	start, err := idx.ConfigDao.GetBlockToProcess(types.ChainTomochain)
+	catchUp := err == nil
+	if !catchUp {
+		start, catchUp = deployBlock()
+	}
+
+	// subscriptions are made before catching up so that no log is missed in between.
+	// Logs received twice are reconciled twice, which has no effect the second time.
+	tradeEvents, err := idx.Exchange.ListenToTrades()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	batchTradeEvents, err := idx.Exchange.ListenToBatchTrades()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	errorEvents, err := idx.Exchange.ListenToErrors()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	cancelEvents, err := idx.Exchange.ListenToCancelOrders()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	go idx.listen(tradeEvents, batchTradeEvents, errorEvents, cancelEvents)
+
+	if catchUp {
+		err = idx.catchUp(start)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+	} else {
+		logger.Warning("No processed block or deploy block found, indexing exchange logs from the current head")
+	}
+
+	go idx.handleEvents()
+	return nil
+}
+
+// deployBlock returns the configured block in which the exchange contract was deployed
+func deployBlock() (uint64, bool) {
+	block, err := strconv.ParseUint(app.Config.Ethereum["deploy_block"], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return block, true
+}
+
+// catchUp reconciles in chain order the logs emitted since the given block
+func (idx *Indexer) catchUp(start uint64) error {
+	trades, err := idx.Exchange.FilterTrades(start)
+	if err != nil {
+		return err
+	}
+
+	batchTrades, err := idx.Exchange.FilterBatchTrades(start)
+	if err != nil {
+		return err
+	}
+
+	errorLogs, err := idx.Exchange.FilterErrors(start)
+	if err != nil {
+		return err
+	}
+
+	cancelLogs, err := idx.Exchange.FilterCancelOrders(start)
+	if err != nil {
+		return err
+	}
+
+	events := []*chainEvent{}
+	for _, ev := range trades {
+		events = append(events, &chainEvent{Raw: ev.Raw, Trade: ev})
+	}
+
+	for _, ev := range batchTrades {
+		events = append(events, &chainEvent{Raw: ev.Raw, BatchTrade: ev})
+	}
+
+	for _, ev := range errorLogs {
+		events = append(events, &chainEvent{Raw: ev.Raw, Error: ev})
+	}
+
+	for _, ev := range cancelLogs {
+		events = append(events, &chainEvent{Raw: ev.Raw, Cancel: ev})
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Raw.BlockNumber != events[j].Raw.BlockNumber {
+			return events[i].Raw.BlockNumber < events[j].Raw.BlockNumber
+		}
+
+		return events[i].Raw.Index < events[j].Raw.Index
+	})
+
+	logger.Infof("Reconciling %v exchange logs from block %v", len(events), start)
+	for _, ev := range events {
+		idx.handleEvent(ev)
+	}
+
+	return nil
+}
+
+func (idx *Indexer) listen(
+	tradeEvents chan *contractsinterfaces.ExchangeLogTrade,
+	batchTradeEvents chan *contractsinterfaces.ExchangeLogBatchTrades,
+	errorEvents chan *contractsinterfaces.ExchangeLogError,
+	cancelEvents chan *contractsinterfaces.ExchangeLogCancelOrder,
+) {
+	for {
+		select {
+		case ev := <-tradeEvents:
+			idx.events <- &chainEvent{Raw: ev.Raw, Trade: ev, ReceivedAt: time.Now()}
+		case ev := <-batchTradeEvents:
+			idx.events <- &chainEvent{Raw: ev.Raw, BatchTrade: ev, ReceivedAt: time.Now()}
+		case ev := <-errorEvents:
+			idx.events <- &chainEvent{Raw: ev.Raw, Error: ev, ReceivedAt: time.Now()}
+		case ev := <-cancelEvents:
+			// cancelled orders are removed from the orderbook without waiting, since any
+			// further match would revert. Cancelling an order twice has no effect.
+			if !ev.Raw.Removed {
+				go idx.reconcileOrderCancel(common.BytesToHash(ev.OrderHash[:]))
+			}
+		}
+	}
+}
+
+// handleEvents reconciles the live events in the order they were received
+func (idx *Indexer) handleEvents() {
+	for ev := range idx.events {
+		wait := time.Until(ev.ReceivedAt.Add(reconciliationDelay))
+		if wait > 0 {
+			time.Sleep(wait)
+		}
+
+		idx.handleEvent(ev)
+	}
+}
+
+func (idx *Indexer) handleEvent(ev *chainEvent) {
+	// logs removed by a chain reorganisation are ignored
+	if ev.Raw.Removed {
+		return
+	}
+
+	switch {
+	case ev.Trade != nil:
+		makerOrderHash := common.BytesToHash(ev.Trade.OrderHash[:])
+		idx.reconcileTradeSuccess(makerOrderHash, ev.Trade.Taker, ev.Raw.TxHash)
+	case ev.BatchTrade != nil:
+		for i, h := range ev.BatchTrade.MakerOrderHashes {
+			if i >= len(ev.BatchTrade.TakerOrderHashes) {
+				break
+			}
+
+			makerOrderHash := common.BytesToHash(h[:])
+			takerOrderHash := common.BytesToHash(ev.BatchTrade.TakerOrderHashes[i][:])
+			idx.reconcileBatchTradeSuccess(makerOrderHash, takerOrderHash, ev.Raw.TxHash)
+		}
+	case ev.Error != nil:
+		makerOrderHash := common.BytesToHash(ev.Error.MakerOrderHash[:])
+		takerOrderHash := common.BytesToHash(ev.Error.TakerOrderHash[:])
+		idx.reconcileTradeError(makerOrderHash, takerOrderHash, int(ev.Error.ErrorId))
+	case ev.Cancel != nil:
+		idx.reconcileOrderCancel(common.BytesToHash(ev.Cancel.OrderHash[:]))
+	}
+
+	err := idx.ConfigDao.SaveLastProcessedBlock(types.ChainTomochain, ev.Raw.BlockNumber)
+	if err != nil {
+		logger.Error(err)
+	}
+}
+
+// reconcileTradeSuccess handles a LogTrade event, which identifies the trade by its
+// maker order hash and its taker address
+func (idx *Indexer) reconcileTradeSuccess(makerOrderHash common.Hash, taker common.Address, txh common.Hash) {
+	trades, err := idx.TradeService.GetByMakerOrderHash(makerOrderHash)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	for _, t := range trades {
+		if t.Taker == taker {
+			idx.markTradeSuccessful(t, txh)
+		}
+	}
+}
+
+// reconcileBatchTradeSuccess handles a LogBatchTrades event entry, which identifies the trade
+// by its maker and taker order hashes
+func (idx *Indexer) reconcileBatchTradeSuccess(makerOrderHash, takerOrderHash common.Hash, txh common.Hash) {
+	trades, err := idx.TradeService.GetByMakerOrderHash(makerOrderHash)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	for _, t := range trades {
+		if t.TakerOrderHash == takerOrderHash {
+			idx.markTradeSuccessful(t, txh)
+		}
+	}
+}
+
+// reconcileTradeError handles a LogError event
+func (idx *Indexer) reconcileTradeError(makerOrderHash, takerOrderHash common.Hash, errID int) {
+	trades, err := idx.TradeService.GetByMakerOrderHash(makerOrderHash)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	for _, t := range trades {
+		if t.TakerOrderHash != takerOrderHash {
+			continue
+		}
+
+		if t.Status == types.ERROR_STATUS && t.SettlementError != nil {
+			continue
+		}
+
+		logger.Warningf("Trade %v is %v in DB but failed on chain (error %v)", t.Hash.Hex(), t.Status, errID)
+
+		m, err := idx.getMatches(t)
+		if err != nil {
+			logger.Error(err)
+			continue
+		}
+
+		err = idx.Broker.PublishTxErrorMessage(m, types.NewContractSettlementError(errID))
+		if err != nil {
+			logger.Error(err)
+		}
+	}
+}
+
+// reconcileOrderCancel handles a LogCancelOrder event. The order is cancelled through the
+// engine, which removes it from the orderbook and notifies the order service
+func (idx *Indexer) reconcileOrderCancel(h common.Hash) {
+	o, err := idx.OrderService.GetByHash(h)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	if o == nil {
+		return
+	}
+
+	if o.Status == types.FILLED || o.Status == types.CANCELLED || o.Status == types.ERROR_STATUS {
+		return
+	}
+
+	logger.Warningf("Order %v is %v in DB but was cancelled on chain", h.Hex(), o.Status)
+
+	err = idx.Broker.PublishCancelOrderMessage(o)
+	if err != nil {
+		logger.Error(err)
+	}
+}
+
+func (idx *Indexer) markTradeSuccessful(t *types.Trade, txh common.Hash) {
+	if t.Status == types.SUCCESS {
+		return
+	}
+
+	logger.Warningf("Trade %v is %v in DB but succeeded on chain (tx: %v)", t.Hash.Hex(), t.Status, txh.Hex())
+
+	err := idx.TradeService.UpdateTradeTxHash(t, txh)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	m, err := idx.getMatches(t)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	err = idx.Broker.PublishTradeSuccessMessage(m)
+	if err != nil {
+		logger.Error(err)
+	}
+}
+
+// getMatches returns the matches corresponding to a single trade
+func (idx *Indexer) getMatches(t *types.Trade) (*types.Matches, error) {
+	mo, err := idx.OrderService.GetByHash(t.MakerOrderHash)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := idx.OrderService.GetByHash(t.TakerOrderHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if mo == nil || to == nil {
+		return nil, errors.New("Could not find the orders of trade " + t.Hash.Hex())
+	}
+
+	return types.NewMatches([]*types.Order{mo}, to, []*types.Trade{t}), nil
+}
@@ -0,0 +1,299 @@
+package operator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/contracts/contractsinterfaces"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/testutils/mocks"
+	mgo "gopkg.in/mgo.v2"
+)
+
+func SetupIndexerTest() (*mocks.ConfigDao, *mocks.TradeService, *mocks.OrderService, *mocks.Exchange, *Indexer) {
+	configDao := new(mocks.ConfigDao)
+	tradeService := new(mocks.TradeService)
+	orderService := new(mocks.OrderService)
+	exchange := new(mocks.Exchange)
+
+	// the orders of the trades are not found so that no message is published to the broker
+	orderService.On("GetByHash", mock.Anything).Return((*types.Order)(nil), nil)
+
+	idx := NewIndexer(configDao, tradeService, orderService, exchange, nil)
+	return configDao, tradeService, orderService, exchange, idx
+}
+
+func TestIndexerHandleTradeEvent(t *testing.T) {
+	makerOrderHash := common.HexToHash("0x1")
+	taker := common.HexToAddress("0x2")
+	txh := common.HexToHash("0x3")
+
+	tests := []struct {
+		name    string
+		status  string
+		taker   common.Address
+		updated bool
+	}{
+		{"pending trade", types.PENDING, taker, true},
+		{"successful trade", types.SUCCESS, taker, false},
+		{"trade of another taker", types.PENDING, common.HexToAddress("0x4"), false},
+	}
+
+	for _, test := range tests {
+		configDao, tradeService, _, _, idx := SetupIndexerTest()
+		tr := &types.Trade{Hash: common.HexToHash("0x5"), MakerOrderHash: makerOrderHash, Taker: test.taker, Status: test.status}
+
+		tradeService.On("GetByMakerOrderHash", makerOrderHash).Return([]*types.Trade{tr}, nil)
+		tradeService.On("UpdateTradeTxHash", tr, txh).Return(nil)
+		configDao.On("SaveLastProcessedBlock", types.ChainTomochain, uint64(10)).Return(nil)
+
+		idx.handleEvent(&chainEvent{
+			Raw:   eth.Log{BlockNumber: 10, TxHash: txh},
+			Trade: &contractsinterfaces.ExchangeLogTrade{OrderHash: makerOrderHash, Taker: taker},
+		})
+
+		if test.updated {
+			tradeService.AssertCalled(t, "UpdateTradeTxHash", tr, txh)
+		} else {
+			tradeService.AssertNotCalled(t, "UpdateTradeTxHash", mock.Anything, mock.Anything)
+		}
+
+		configDao.AssertExpectations(t)
+	}
+}
+
+func TestIndexerHandleBatchTradeEvent(t *testing.T) {
+	configDao, tradeService, _, _, idx := SetupIndexerTest()
+	txh := common.HexToHash("0x10")
+
+	mo1, mo2 := common.HexToHash("0x1"), common.HexToHash("0x2")
+	to1, to2 := common.HexToHash("0x3"), common.HexToHash("0x4")
+
+	t1 := &types.Trade{Hash: common.HexToHash("0x5"), MakerOrderHash: mo1, TakerOrderHash: to1, Status: types.PENDING}
+	t2 := &types.Trade{Hash: common.HexToHash("0x6"), MakerOrderHash: mo2, TakerOrderHash: common.HexToHash("0x7"), Status: types.PENDING}
+
+	tradeService.On("GetByMakerOrderHash", mo1).Return([]*types.Trade{t1}, nil)
+	tradeService.On("GetByMakerOrderHash", mo2).Return([]*types.Trade{t2}, nil)
+	tradeService.On("UpdateTradeTxHash", t1, txh).Return(nil)
+	configDao.On("SaveLastProcessedBlock", types.ChainTomochain, uint64(11)).Return(nil)
+
+	idx.handleEvent(&chainEvent{
+		Raw: eth.Log{BlockNumber: 11, TxHash: txh},
+		BatchTrade: &contractsinterfaces.ExchangeLogBatchTrades{
+			MakerOrderHashes: [][32]byte{mo1, mo2},
+			TakerOrderHashes: [][32]byte{to1, to2},
+		},
+	})
+
+	tradeService.AssertExpectations(t)
+	tradeService.AssertNumberOfCalls(t, "UpdateTradeTxHash", 1)
+	configDao.AssertExpectations(t)
+}
+
+func TestIndexerHandleErrorEvent(t *testing.T) {
+	makerOrderHash := common.HexToHash("0x1")
+	takerOrderHash := common.HexToHash("0x2")
+
+	tests := []struct {
+		name       string
+		trade      *types.Trade
+		reconciled bool
+	}{
+		{
+			"pending trade",
+			&types.Trade{MakerOrderHash: makerOrderHash, TakerOrderHash: takerOrderHash, Status: types.PENDING},
+			true,
+		},
+		{
+			"trade already failed",
+			&types.Trade{MakerOrderHash: makerOrderHash, TakerOrderHash: takerOrderHash, Status: types.ERROR_STATUS, SettlementError: &types.SettlementError{}},
+			false,
+		},
+		{
+			"trade of another taker order",
+			&types.Trade{MakerOrderHash: makerOrderHash, TakerOrderHash: common.HexToHash("0x3"), Status: types.PENDING},
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		configDao, tradeService, orderService, _, idx := SetupIndexerTest()
+
+		tradeService.On("GetByMakerOrderHash", makerOrderHash).Return([]*types.Trade{test.trade}, nil)
+		configDao.On("SaveLastProcessedBlock", types.ChainTomochain, uint64(12)).Return(nil)
+
+		idx.handleEvent(&chainEvent{
+			Raw:   eth.Log{BlockNumber: 12},
+			Error: &contractsinterfaces.ExchangeLogError{ErrorId: 1, MakerOrderHash: makerOrderHash, TakerOrderHash: takerOrderHash},
+		})
+
+		// the orders of the trade are only read to publish the settlement error
+		if test.reconciled {
+			orderService.AssertCalled(t, "GetByHash", makerOrderHash)
+		} else {
+			orderService.AssertNotCalled(t, "GetByHash", mock.Anything)
+		}
+
+		configDao.AssertExpectations(t)
+	}
+}
+
+func TestIndexerHandleCancelEvent(t *testing.T) {
+	configDao, _, orderService, _, idx := SetupIndexerTest()
+	h := common.HexToHash("0x1")
+
+	configDao.On("SaveLastProcessedBlock", types.ChainTomochain, uint64(13)).Return(nil)
+
+	idx.handleEvent(&chainEvent{
+		Raw:    eth.Log{BlockNumber: 13},
+		Cancel: &contractsinterfaces.ExchangeLogCancelOrder{OrderHash: h},
+	})
+
+	orderService.AssertCalled(t, "GetByHash", h)
+	configDao.AssertExpectations(t)
+}
+
+func TestIndexerIgnoresRemovedLogs(t *testing.T) {
+	configDao, tradeService, _, _, idx := SetupIndexerTest()
+
+	idx.handleEvent(&chainEvent{
+		Raw:   eth.Log{BlockNumber: 14, Removed: true},
+		Trade: &contractsinterfaces.ExchangeLogTrade{OrderHash: common.HexToHash("0x1")},
+	})
+
+	tradeService.AssertNotCalled(t, "GetByMakerOrderHash", mock.Anything)
+	configDao.AssertNotCalled(t, "SaveLastProcessedBlock", mock.Anything, mock.Anything)
+}
+
+func TestIndexerCatchUp(t *testing.T) {
+	configDao, tradeService, _, exchange, idx := SetupIndexerTest()
+
+	exchange.On("FilterTrades", uint64(10)).Return([]*contractsinterfaces.ExchangeLogTrade{
+		{OrderHash: common.HexToHash("0x1"), Raw: eth.Log{BlockNumber: 12}},
+	}, nil)
+
+	exchange.On("FilterBatchTrades", uint64(10)).Return([]*contractsinterfaces.ExchangeLogBatchTrades{
+		{Raw: eth.Log{BlockNumber: 11}},
+	}, nil)
+
+	exchange.On("FilterErrors", uint64(10)).Return([]*contractsinterfaces.ExchangeLogError{
+		{MakerOrderHash: common.HexToHash("0x2"), Raw: eth.Log{BlockNumber: 10, Index: 1}},
+	}, nil)
+
+	exchange.On("FilterCancelOrders", uint64(10)).Return([]*contractsinterfaces.ExchangeLogCancelOrder{
+		{OrderHash: common.HexToHash("0x3"), Raw: eth.Log{BlockNumber: 10, Index: 0}},
+	}, nil)
+
+	tradeService.On("GetByMakerOrderHash", mock.Anything).Return([]*types.Trade{}, nil)
+
+	blocks := []uint64{}
+	configDao.On("SaveLastProcessedBlock", types.ChainTomochain, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		blocks = append(blocks, args.Get(1).(uint64))
+	})
+
+	err := idx.catchUp(10)
+	if err != nil {
+		t.Fatalf("Could not catch up: %v", err)
+	}
+
+	expected := []uint64{10, 10, 11, 12}
+	if len(blocks) != len(expected) {
+		t.Fatalf("Expected %v processed blocks, got %v", expected, blocks)
+	}
+
+	for i := range expected {
+		if blocks[i] != expected[i] {
+			t.Errorf("Expected %v processed blocks, got %v", expected, blocks)
+			break
+		}
+	}
+}
+
+func TestIndexerStart(t *testing.T) {
+	deploy := app.Config.Ethereum
+	defer func() { app.Config.Ethereum = deploy }()
+
+	app.Config.Ethereum = map[string]string{"deploy_block": "7"}
+
+	tests := []struct {
+		name      string
+		lastBlock uint64
+		err       error
+		start     uint64
+	}{
+		{"last processed block", 42, nil, 42},
+		{"deploy block", 0, mgo.ErrNotFound, 7},
+	}
+
+	for _, test := range tests {
+		configDao, _, _, exchange, idx := SetupIndexerTest()
+
+		configDao.On("GetBlockToProcess", types.ChainTomochain).Return(test.lastBlock, test.err)
+		exchange.On("ListenToTrades").Return(make(chan *contractsinterfaces.ExchangeLogTrade), nil)
+		exchange.On("ListenToBatchTrades").Return(make(chan *contractsinterfaces.ExchangeLogBatchTrades), nil)
+		exchange.On("ListenToErrors").Return(make(chan *contractsinterfaces.ExchangeLogError), nil)
+		exchange.On("ListenToCancelOrders").Return(make(chan *contractsinterfaces.ExchangeLogCancelOrder), nil)
+		exchange.On("FilterTrades", test.start).Return([]*contractsinterfaces.ExchangeLogTrade{}, nil)
+		exchange.On("FilterBatchTrades", test.start).Return([]*contractsinterfaces.ExchangeLogBatchTrades{}, nil)
+		exchange.On("FilterErrors", test.start).Return([]*contractsinterfaces.ExchangeLogError{}, nil)
+		exchange.On("FilterCancelOrders", test.start).Return([]*contractsinterfaces.ExchangeLogCancelOrder{}, nil)
+
+		err := idx.Start()
+		if err != nil {
+			t.Fatalf("%v: could not start the indexer: %v", test.name, err)
+		}
+
+		exchange.AssertExpectations(t)
+	}
+}
+
+func TestIndexerLiveCancelEvent(t *testing.T) {
+	ethereumConfig := app.Config.Ethereum
+	defer func() { app.Config.Ethereum = ethereumConfig }()
+
+	app.Config.Ethereum = map[string]string{}
+
+	configDao := new(mocks.ConfigDao)
+	orderService := new(mocks.OrderService)
+	exchange := new(mocks.Exchange)
+	idx := NewIndexer(configDao, new(mocks.TradeService), orderService, exchange, nil)
+
+	cancelEvents := make(chan *contractsinterfaces.ExchangeLogCancelOrder)
+	configDao.On("GetBlockToProcess", types.ChainTomochain).Return(uint64(0), mgo.ErrNotFound)
+	exchange.On("ListenToTrades").Return(make(chan *contractsinterfaces.ExchangeLogTrade), nil)
+	exchange.On("ListenToBatchTrades").Return(make(chan *contractsinterfaces.ExchangeLogBatchTrades), nil)
+	exchange.On("ListenToErrors").Return(make(chan *contractsinterfaces.ExchangeLogError), nil)
+	exchange.On("ListenToCancelOrders").Return(cancelEvents, nil)
+
+	// the order is already filled so that no cancel message is published to the broker
+	h := common.HexToHash("0x1")
+	reconciled := make(chan common.Hash, 2)
+	orderService.On("GetByHash", mock.Anything).Return(&types.Order{Hash: h, Status: types.FILLED}, nil).Run(func(args mock.Arguments) {
+		reconciled <- args.Get(0).(common.Hash)
+	})
+
+	err := idx.Start()
+	if err != nil {
+		t.Fatalf("Could not start the indexer: %v", err)
+	}
+
+	cancelEvents <- &contractsinterfaces.ExchangeLogCancelOrder{OrderHash: common.HexToHash("0x2"), Raw: eth.Log{Removed: true}}
+	cancelEvents <- &contractsinterfaces.ExchangeLogCancelOrder{OrderHash: h}
+
+	// live cancellations are reconciled without waiting for the reconciliation delay
+	select {
+	case got := <-reconciled:
+		if got != h {
+			t.Errorf("Expected order %v to be reconciled, got %v", h.Hex(), got.Hex())
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the cancelled order to be reconciled")
+	}
+
+	orderService.AssertNumberOfCalls(t, "GetByHash", 1)
+	exchange.AssertNotCalled(t, "FilterCancelOrders", mock.Anything)
+}
@@ -29,15 +29,19 @@ type Operator struct {
 	OrderService      interfaces.OrderService
 	EthereumProvider  interfaces.EthereumProvider
 	Exchange          interfaces.Exchange
+	HardCancelDao     interfaces.HardCancelDao
 	TxQueues          []*TxQueue
 	QueueAddressIndex map[common.Address]*TxQueue
 	Broker            *rabbitmq.Connection
 	mutex             *sync.Mutex
+	hardCancels       []*types.Order
+	cancelMutex       *sync.Mutex
 }
 
 type OperatorInterface interface {
 	SubscribeOperatorMessages(fn func(*types.OperatorMessage) error) error
 	QueueTrade(o *types.Order, t *types.Trade) error
+	HandleHardCancel(o *types.Order) error
 	GetShortestQueue() (*TxQueue, int, error)
 	SetFeeAccount(account common.Address) (*eth.Transaction, error)
 	SetOperator(account common.Address, isOperator bool) (*eth.Transaction, error)
@@ -56,6 +60,7 @@ func NewOperator(
 	orderService interfaces.OrderService,
 	provider interfaces.EthereumProvider,
 	exchange interfaces.Exchange,
+	hardCancelDao interfaces.HardCancelDao,
 	conn *rabbitmq.Connection,
 ) (*Operator, error) {
 	txqueues := []*TxQueue{}
@@ -98,12 +103,23 @@ func NewOperator(
 		OrderService:      orderService,
 		EthereumProvider:  provider,
 		Exchange:          exchange,
+		HardCancelDao:     hardCancelDao,
 		TxQueues:          txqueues,
 		QueueAddressIndex: addressIndex,
+		Broker:            conn,
 		mutex:             &sync.Mutex{},
+		hardCancels:       []*types.Order{},
+		cancelMutex:       &sync.Mutex{},
+	}
+
+	// the hard cancels that were not confirmed before a restart are queued again
+	err = op.restoreHardCancels()
+	if err != nil {
+		panic(err)
 	}
 
 	go op.HandleEvents()
+	go op.handleHardCancels()
 	return op, nil
 }
 
@@ -151,8 +167,7 @@ func (op *Operator) SubscribeOperatorMessages(fn func(*types.OperatorMessage) er
 }
 
 func (op *Operator) HandleTxError(m *types.Matches, id int) {
-	errType := getErrorType(id)
-	err := op.Broker.PublishTxErrorMessage(m, errType)
+	err := op.Broker.PublishTxErrorMessage(m, types.NewContractSettlementError(id))
 	if err != nil {
 		logger.Error(err)
 	}
@@ -56,6 +56,8 @@ func SetupTest(t *testing.T) (
 
 	tradeService := new(mocks.TradeService)
 	orderService := new(mocks.OrderService)
+	hardCancelDao := new(mocks.HardCancelDao)
+	hardCancelDao.On("GetPending").Return([]*types.HardCancel{}, nil)
 
 	wallets := []*types.Wallet{wallet1, wallet2, wallet3, wallet4, wallet5}
 	admin := wallet1
@@ -159,6 +161,7 @@ func SetupTest(t *testing.T) (
 		orderService,
 		provider,
 		exchange,
+		hardCancelDao,
 		rabbitConn,
 	)
 
@@ -3,11 +3,14 @@ package operator
 import (
 	"encoding/json"
 	"math/big"
+	"sync"
+	"time"
 
 	"github.com/tomochain/dex-server/errors"
 
 	ethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	eth "github.com/ethereum/go-ethereum/core/types"
 	"github.com/streadway/amqp"
 	"github.com/tomochain/dex-server/interfaces"
@@ -15,6 +18,14 @@ import (
 	"github.com/tomochain/dex-server/types"
 )
 
+// settlementTimeout is the time after which the queue stops waiting for a sent trade
+// transaction. The trades stay pending while the transaction is awaited in the background
+const settlementTimeout = 5 * time.Minute
+
+// txDropTimeout is the time during which a trade transaction that was not mined within the
+// settlement timeout is still awaited. After it, the transaction is considered as dropped
+const txDropTimeout = time.Hour
+
 type TxQueue struct {
 	Name             string
 	Wallet           *types.Wallet
@@ -23,6 +34,8 @@ type TxQueue struct {
 	EthereumProvider interfaces.EthereumProvider
 	Exchange         interfaces.Exchange
 	Broker           *rabbitmq.Connection
+	// sendMutex serializes the transactions sent from the queue wallet
+	sendMutex sync.Mutex
 }
 
 // NewTxQueue
@@ -76,6 +89,24 @@ func (txq *TxQueue) GetTxSendOptions() *bind.TransactOpts {
 	return bind.NewKeyedTransactor(txq.Wallet.PrivateKey)
 }
 
+// SendTx sends a transaction from the queue wallet with the next nonce of the wallet. The trades
+// of the queue and the other transactions sent from its wallet, like hard cancels, are sent one
+// at a time so that two transactions never get the same nonce
+func (txq *TxQueue) SendTx(send func(txOpts *bind.TransactOpts) (*eth.Transaction, error)) (*eth.Transaction, error) {
+	txq.sendMutex.Lock()
+	defer txq.sendMutex.Unlock()
+
+	nonce, err := txq.EthereumProvider.GetPendingNonceAt(txq.Wallet.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	txOpts := txq.GetTxSendOptions()
+	txOpts.Nonce = big.NewInt(int64(nonce))
+
+	return send(txOpts)
+}
+
 func (txq *TxQueue) GetTxCallOptions() *ethereum.CallMsg {
 	address := txq.Exchange.GetAddress()
 
@@ -103,7 +134,7 @@ func (txq *TxQueue) ExecuteTrade(m *types.Matches, tag uint64) error {
 	callOpts := txq.GetTxCallOptions()
 	gasLimit, err := txq.Exchange.CallBatchTrades(m, callOpts)
 	if err != nil {
-		txq.HandleTradeInvalid(m)
+		txq.HandleTradeInvalid(m, types.NewSettlementError(types.ErrCodeGasEstimationFailed))
 		logger.Error(err)
 		return err
 	}
@@ -112,26 +143,19 @@ func (txq *TxQueue) ExecuteTrade(m *types.Matches, tag uint64) error {
 	//and is therefore not valid.
 	if gasLimit < 140000 {
 		logger.Warning("GAS LIMIT: ", gasLimit)
-		txq.HandleTradeInvalid(m)
+		txq.HandleTradeInvalid(m, types.NewSettlementError(types.ErrCodeInvalidTrade))
 		logger.Error(err)
 		return errors.New("Invalid Trade")
 	}
 
-	nonce, err := txq.EthereumProvider.GetPendingNonceAt(txq.Wallet.Address)
+	tx, err := txq.SendTx(func(txOpts *bind.TransactOpts) (*eth.Transaction, error) {
+		// TODO: Fix this line later
+		txOpts.GasLimit = gasLimit
+		// *****
+		return txq.Exchange.ExecuteBatchTrades(m, txOpts)
+	})
 	if err != nil {
-		txq.HandleError(m)
-		logger.Error(err)
-		return err
-	}
-
-	txOpts := txq.GetTxSendOptions()
-	txOpts.Nonce = big.NewInt(int64(nonce))
-	// TODO: Fix these 2 lines later
-	txOpts.GasLimit = gasLimit
-	// *****
-	tx, err := txq.Exchange.ExecuteBatchTrades(m, txOpts)
-	if err != nil {
-		txq.HandleError(m)
+		txq.HandleError(m, types.NewSettlementError(types.ErrCodeTxSendFailed))
 		logger.Error(err)
 		return err
 	}
@@ -153,17 +177,26 @@ func (txq *TxQueue) ExecuteTrade(m *types.Matches, tag uint64) error {
 		return errors.New("Could not update")
 	}
 
-	receipt, err := txq.EthereumProvider.WaitMined(tx.Hash())
+	// the transaction might still be mined after the timeout, so the trades are left pending
+	// and the transaction is awaited without blocking the queue
+	receipt, err := txq.EthereumProvider.WaitMinedWithTimeout(tx.Hash(), settlementTimeout)
 	if err != nil {
 		logger.Error(err)
+		txq.HandleTxTimeout(m, tx.Hash())
+		go txq.resolveTimedOutTx(m, tx.Hash())
 		return err
 	}
 
+	return txq.handleReceipt(m, receipt)
+}
+
+// handleReceipt publishes the outcome of a mined trade transaction
+func (txq *TxQueue) handleReceipt(m *types.Matches, receipt *eth.Receipt) error {
 	// len(receipt.PostState) == 0 so it can work with dex-protocol
 	// Because only transaction after Byzantium hard fork has Status field
 	if receipt.Status == 0 && len(receipt.PostState) == 0 {
 		logger.Errorf("Reverted transaction: %v", receipt)
-		err := txq.HandleTxError(m)
+		err := txq.HandleTxError(m, types.NewSettlementError(types.ErrCodeTxReverted))
 		if err != nil {
 			logger.Error(err)
 			return err
@@ -172,7 +205,7 @@ func (txq *TxQueue) ExecuteTrade(m *types.Matches, tag uint64) error {
 		return errors.New("Reverted Transaction")
 	}
 
-	err = txq.HandleTxSuccess(m, receipt)
+	err := txq.HandleTxSuccess(m, receipt)
 	if err != nil {
 		logger.Error(err)
 		return err
@@ -181,10 +214,70 @@ func (txq *TxQueue) ExecuteTrade(m *types.Matches, tag uint64) error {
 	return nil
 }
 
-func (txq *TxQueue) HandleTradeInvalid(m *types.Matches) error {
-	logger.Errorf("Trade invalid: %v", m)
+// resolveTimedOutTx keeps waiting for a trade transaction that was not mined within the settlement
+// timeout. If it is still not mined after txDropTimeout, it is considered as dropped: the trades
+// settled on the exchange contract in the meantime are marked successful and the others are failed.
+// If the Traded mapping can not be read, the trades are left to the fill reconciliation
+func (txq *TxQueue) resolveTimedOutTx(m *types.Matches, h common.Hash) {
+	receipt, err := txq.EthereumProvider.WaitMinedWithTimeout(h, txDropTimeout)
+	if err == nil {
+		txq.handleReceipt(m, receipt)
+		return
+	}
+
+	settled, dropped, err := txq.splitTradedMatches(m)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	if settled.Length() > 0 {
+		txq.HandleTxSuccess(settled, nil)
+	}
+
+	if dropped.Length() > 0 {
+		logger.Errorf("Dropped transaction: %v", h.Hex())
+		txq.HandleTxError(dropped, types.NewSettlementError(types.ErrCodeTxDropped))
+	}
+}
+
+// splitTradedMatches splits matches between the trades that are settled on the exchange contract
+// and the trades that are not
+func (txq *TxQueue) splitTradedMatches(m *types.Matches) (*types.Matches, *types.Matches, error) {
+	settled := &types.Matches{TakerOrder: m.TakerOrder}
+	notSettled := &types.Matches{TakerOrder: m.TakerOrder}
+
+	for i, t := range m.Trades {
+		traded, err := txq.Exchange.Traded(t.Hash)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if traded {
+			settled.AppendMatch(m.MakerOrders[i], t)
+		} else {
+			notSettled.AppendMatch(m.MakerOrders[i], t)
+		}
+	}
+
+	return settled, notSettled, nil
+}
+
+func (txq *TxQueue) HandleTradeInvalid(m *types.Matches, e *types.SettlementError) error {
+	logger.Errorf("Trade invalid: %v (%v)", m, e)
+
+	err := txq.Broker.PublishTradeInvalidMessage(m, e)
+	if err != nil {
+		logger.Error(err)
+	}
+
+	return nil
+}
+
+func (txq *TxQueue) HandleTxError(m *types.Matches, e *types.SettlementError) error {
+	logger.Errorf("Transaction Error: %v (%v)", m, e)
 
-	err := txq.Broker.PublishTradeInvalidMessage(m)
+	err := txq.Broker.PublishTxErrorMessage(m, e)
 	if err != nil {
 		logger.Error(err)
 	}
@@ -192,11 +285,10 @@ func (txq *TxQueue) HandleTradeInvalid(m *types.Matches) error {
 	return nil
 }
 
-func (txq *TxQueue) HandleTxError(m *types.Matches) error {
-	logger.Errorf("Transaction Error: %v", m)
+func (txq *TxQueue) HandleTxTimeout(m *types.Matches, h common.Hash) error {
+	logger.Warningf("Transaction timeout: %v (tx: %v)", m, h.Hex())
 
-	errType := "Transaction error"
-	err := txq.Broker.PublishTxErrorMessage(m, errType)
+	err := txq.Broker.PublishTxTimeoutMessage(m, h)
 	if err != nil {
 		logger.Error(err)
 	}
@@ -216,11 +308,10 @@ func (txq *TxQueue) HandleTxSuccess(m *types.Matches, receipt *eth.Receipt) erro
 	return nil
 }
 
-func (txq *TxQueue) HandleError(m *types.Matches) error {
-	logger.Errorf("Operator Error: %v", m)
+func (txq *TxQueue) HandleError(m *types.Matches, e *types.SettlementError) error {
+	logger.Errorf("Operator Error: %v (%v)", m, e)
 
-	errType := "Server error"
-	err := txq.Broker.PublishErrorMessage(m, errType)
+	err := txq.Broker.PublishErrorMessage(m, e)
 	if err != nil {
 		logger.Error(err)
 	}
@@ -0,0 +1,38 @@
+package operator
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/testutils/mocks"
+)
+
+func TestSplitTradedMatches(t *testing.T) {
+	exchange := new(mocks.Exchange)
+	txq := &TxQueue{Exchange: exchange}
+
+	taker := &types.Order{Hash: common.HexToHash("0x1")}
+	mo1 := &types.Order{Hash: common.HexToHash("0x2")}
+	mo2 := &types.Order{Hash: common.HexToHash("0x3")}
+	t1 := &types.Trade{Hash: common.HexToHash("0x4")}
+	t2 := &types.Trade{Hash: common.HexToHash("0x5")}
+
+	exchange.On("Traded", t1.Hash).Return(true, nil)
+	exchange.On("Traded", t2.Hash).Return(false, nil)
+
+	m := types.NewMatches([]*types.Order{mo1, mo2}, taker, []*types.Trade{t1, t2})
+
+	settled, dropped, err := txq.splitTradedMatches(m)
+	if err != nil {
+		t.Fatalf("Could not split matches: %v", err)
+	}
+
+	if settled.Length() != 1 || settled.Trades[0] != t1 || settled.MakerOrders[0] != mo1 || settled.TakerOrder != taker {
+		t.Errorf("Unexpected settled matches %v", settled)
+	}
+
+	if dropped.Length() != 1 || dropped.Trades[0] != t2 || dropped.MakerOrders[0] != mo2 || dropped.TakerOrder != taker {
+		t.Errorf("Unexpected dropped matches %v", dropped)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"log"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/streadway/amqp"
 	"github.com/tomochain/dex-server/types"
 )
@@ -134,13 +135,40 @@ func (c *Connection) PublishTradeSuccessMessage(matches *types.Matches) error {
 	return nil
 }
 
-func (c *Connection) PublishErrorMessage(matches *types.Matches, errType string) error {
+func (c *Connection) PublishErrorMessage(matches *types.Matches, settlementError *types.SettlementError) error {
 	ch := c.GetChannel("OPERATOR_PUB")
 	q := c.GetQueue(ch, "TX_MESSAGES")
 	msg := &types.OperatorMessage{
-		MessageType: types.TRADE_ERROR,
-		Matches:     matches,
-		ErrorType:   errType,
+		MessageType:     types.TRADE_ERROR,
+		Matches:         matches,
+		SettlementError: settlementError,
+	}
+
+	bytes, err := json.Marshal(msg)
+	if err != nil {
+		logger.Infof("Failed to marshal %s: %s", msg.MessageType, err)
+	}
+
+	err = c.Publish(ch, q, bytes)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	logger.Infof("PUBLISHED TRADE ERROR MESSAGE. Error Type: %v", settlementError)
+	return nil
+}
+
+// PublishTxTimeoutMessage publishes a message when a trade transaction is not mined in time. The
+// outcome of the transaction is unknown until the indexer finds its logs
+func (c *Connection) PublishTxTimeoutMessage(matches *types.Matches, h common.Hash) error {
+	ch := c.GetChannel("OPERATOR_PUB")
+	q := c.GetQueue(ch, "TX_MESSAGES")
+	msg := &types.OperatorMessage{
+		MessageType:     types.TRADE_TX_TIMEOUT,
+		Matches:         matches,
+		SettlementError: types.NewSettlementError(types.ErrCodeTxTimeout),
+		TxHash:          h,
 	}
 
 	bytes, err := json.Marshal(msg)
@@ -154,18 +182,17 @@ func (c *Connection) PublishErrorMessage(matches *types.Matches, errType string)
 		return err
 	}
 
-	logger.Info("PUBLISHED TRADE ERROR MESSAGE. Error Type: %v", errType)
 	return nil
 }
 
 // PublishTxErrorMessage publishes a messages when a trade execution fails
-func (c *Connection) PublishTxErrorMessage(matches *types.Matches, errType string) error {
+func (c *Connection) PublishTxErrorMessage(matches *types.Matches, settlementError *types.SettlementError) error {
 	ch := c.GetChannel("OPERATOR_PUB")
 	q := c.GetQueue(ch, "TX_MESSAGES")
 	msg := &types.OperatorMessage{
-		MessageType: types.TRADE_ERROR,
-		Matches:     matches,
-		ErrorType:   errType,
+		MessageType:     types.TRADE_TX_ERROR,
+		Matches:         matches,
+		SettlementError: settlementError,
 	}
 
 	bytes, err := json.Marshal(msg)
@@ -179,16 +206,17 @@ func (c *Connection) PublishTxErrorMessage(matches *types.Matches, errType strin
 		return err
 	}
 
-	logger.Info("PUBLISHED TRADE ERROR MESSAGE. Error Type: %v", errType)
+	logger.Infof("PUBLISHED TRADE TX ERROR MESSAGE. Error Type: %v", settlementError)
 	return nil
 }
 
-func (c *Connection) PublishTradeInvalidMessage(matches *types.Matches) error {
+func (c *Connection) PublishTradeInvalidMessage(matches *types.Matches, settlementError *types.SettlementError) error {
 	ch := c.GetChannel("OPERATOR_PUB")
 	q := c.GetQueue(ch, "TX_MESSAGES")
 	msg := &types.OperatorMessage{
-		MessageType: types.TRADE_INVALID,
-		Matches:     matches,
+		MessageType:     types.TRADE_INVALID,
+		Matches:         matches,
+		SettlementError: settlementError,
 	}
 
 	bytes, err := json.Marshal(msg)
@@ -230,6 +258,47 @@ func (c *Connection) PublishTradeSentMessage(matches *types.Matches) error {
 	return nil
 }
 
+// PublishHardCancelTxPendingMessage publishes a message when a hard cancel transaction is sent
+func (c *Connection) PublishHardCancelTxPendingMessage(orders []*types.Order, h common.Hash) error {
+	return c.publishHardCancelTxMessage(types.HARD_CANCEL_TX_PENDING, orders, h)
+}
+
+// PublishHardCancelTxSuccessMessage publishes a message when a hard cancel transaction is mined
+func (c *Connection) PublishHardCancelTxSuccessMessage(orders []*types.Order, h common.Hash) error {
+	return c.publishHardCancelTxMessage(types.HARD_CANCEL_TX_SUCCESS, orders, h)
+}
+
+// PublishHardCancelTxErrorMessage publishes a message when a hard cancel transaction
+// could not be sent or was not successfully mined
+func (c *Connection) PublishHardCancelTxErrorMessage(orders []*types.Order, h common.Hash) error {
+	return c.publishHardCancelTxMessage(types.HARD_CANCEL_TX_ERROR, orders, h)
+}
+
+func (c *Connection) publishHardCancelTxMessage(msgType string, orders []*types.Order, h common.Hash) error {
+	ch := c.GetChannel("OPERATOR_PUB")
+	q := c.GetQueue(ch, "TX_MESSAGES")
+	msg := &types.OperatorMessage{
+		MessageType: msgType,
+		Orders:      orders,
+		TxHash:      h,
+	}
+
+	bytes, err := json.Marshal(msg)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	err = c.Publish(ch, q, bytes)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	logger.Infof("PUBLISHED %v MESSAGE", msgType)
+	return nil
+}
+
 func (c *Connection) ConsumeQueuedTrades(ch *amqp.Channel, q *amqp.Queue, fn func(*types.Matches, uint64) error) error {
 	go func() {
 		msgs, err := ch.Consume(
@@ -136,6 +136,28 @@ func (c *Connection) PublishCancelOrderMessage(o *types.Order) error {
 	return nil
 }
 
+// PublishCorrectOrderMessage sends an order whose filled amount was corrected from the exchange
+// contract state to the engine
+func (c *Connection) PublishCorrectOrderMessage(o *types.Order) error {
+	b, err := json.Marshal(o)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	err = c.PublishOrder(&Message{
+		Type: "CORRECT_ORDER",
+		Data: b,
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
 func (c *Connection) PublishInvalidateMakerOrdersMessage(m types.Matches) error {
 	utils.PrintJSON("In publish invalidate")
 
@@ -196,3 +218,54 @@ func (c *Connection) PublishOrder(order *Message) error {
 
 	return nil
 }
+
+// SubscribeHardCancels consumes the orders that need to be cancelled on the exchange contract
+func (c *Connection) SubscribeHardCancels(fn func(*types.Order) error) error {
+	ch := c.GetChannel("hardCancelSubscribe")
+	q := c.GetQueue(ch, "hardCancels")
+
+	go func() {
+		msgs, err := c.Consume(ch, q)
+		if err != nil {
+			logger.Error(err)
+		}
+
+		forever := make(chan bool)
+
+		go func() {
+			for d := range msgs {
+				o := &types.Order{}
+				err := json.Unmarshal(d.Body, o)
+				if err != nil {
+					logger.Error(err)
+					continue
+				}
+
+				go fn(o)
+			}
+		}()
+
+		<-forever
+	}()
+	return nil
+}
+
+// PublishHardCancelMessage publishes an order that needs to be cancelled on the exchange contract
+func (c *Connection) PublishHardCancelMessage(o *types.Order) error {
+	ch := c.GetChannel("hardCancelPublish")
+	q := c.GetQueue(ch, "hardCancels")
+
+	b, err := json.Marshal(o)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	err = c.Publish(ch, q, b)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
@@ -54,7 +54,7 @@ func Start() {
 	address := fmt.Sprintf(":%v", app.Config.ServerPort)
 	log.Printf("server %v is started at %v\n", app.Version, address)
 
-	allowedHeaders := handlers.AllowedHeaders([]string{"Content-Type", "Accept", "Authorization", "Access-Control-Allow-Origin"})
+	allowedHeaders := handlers.AllowedHeaders([]string{"Content-Type", "Accept", "Authorization", "Access-Control-Allow-Origin", "X-Admin-Key", "X-API-Key", "X-API-Timestamp", "X-API-Signature"})
 	allowedOrigins := handlers.AllowedOrigins([]string{"*"})
 	allowedMethods := handlers.AllowedMethods([]string{"GET", "HEAD", "POST", "PUT", "DELETE", "OPTIONS"})
 
@@ -82,36 +82,33 @@ func NewRouter(
 	walletDao := daos.NewWalletDao()
 	configDao := daos.NewConfigDao()
 	associationDao := daos.NewAssociationDao()
+	gasTopUpDao := daos.NewGasTopUpDao()
+	adminTransactionDao := daos.NewAdminTransactionDao()
+	orderLockDao := daos.NewOrderLockDao()
+	accountNonceDao := daos.NewAccountNonceDao()
+	accountRestrictionDao := daos.NewAccountRestrictionDao()
+	feeScheduleDao := daos.NewFeeScheduleDao()
+	feeOverrideDao := daos.NewFeeOverrideDao()
+	feeLedgerDao := daos.NewFeeLedgerDao()
+	referralDao := daos.NewReferralDao()
+	referralRebateDao := daos.NewReferralRebateDao()
+	orderEventDao := daos.NewOrderEventDao()
+	hardCancelDao := daos.NewHardCancelDao()
+	heldDepositDao := daos.NewHeldDepositDao()
+	authChallengeDao := daos.NewAuthChallengeDao()
+	apiKeyDao := daos.NewAPIKeyDao()
 
 	// instantiate engine
 	eng := engine.NewEngine(rabbitConn, orderDao, tradeDao, pairDao, provider)
 	swapEngine := NewSwapEngine()
 
 	// get services for injection
-	accountService := services.NewAccountService(accountDao, tokenDao)
+	accountService := services.NewAccountService(accountDao, tokenDao, accountRestrictionDao)
 	ohlcvService := services.NewOHLCVService(tradeDao)
 	tokenService := services.NewTokenService(tokenDao)
 	tradeService := services.NewTradeService(tradeDao)
-	validatorService := services.NewValidatorService(provider, accountDao, orderDao, pairDao)
-	pairService := services.NewPairService(pairDao, tokenDao, tradeDao, orderDao, eng, provider)
-	orderService := services.NewOrderService(orderDao, pairDao, accountDao, tradeDao, eng, validatorService, rabbitConn)
-	orderBookService := services.NewOrderBookService(pairDao, tokenDao, orderDao, eng)
-
 	walletService := services.NewWalletService(walletDao)
 
-	// txservice for deposit
-	// wallet := &types.NewWalletFromPrivateKey(app.Config.Deposit.Tomochain.SignerPrivateKey)
-	// we already have them so no need to re-calculate
-	wallet := &types.Wallet{
-		Address:    app.Config.Deposit.Tomochain.GetPublicKey(),
-		PrivateKey: app.Config.Deposit.Tomochain.GetPrivateKey(),
-	}
-	txService := services.NewTxService(walletDao, wallet)
-	depositService := services.NewDepositService(configDao, associationDao, pairDao, orderDao, swapEngine, eng, rabbitConn)
-
-	// start cron service
-	cronService := crons.NewCronService(ohlcvService)
-
 	// get exchange contract instance
 	exchangeAddress := common.HexToAddress(app.Config.Ethereum["exchange_address"])
 	exchange, err := contracts.NewExchange(
@@ -124,6 +121,37 @@ func NewRouter(
 		panic(err)
 	}
 
+	// the order hashing and the trade encoding must match the deployed exchange contract
+	contractStatus := checkExchangeContract(exchange)
+
+	// token balances and allowances are cached from the ERC20 logs
+	balanceService := services.NewBalanceService(accountDao, tokenDao, provider, provider.Client)
+	go balanceService.Start()
+
+	// locked balances are maintained as the orders are updated and recomputed every night
+	lockedBalanceService := services.NewLockedBalanceService(orderDao, pairDao, accountDao, orderLockDao)
+	go lockedBalanceService.Recompute()
+
+	feeService := services.NewFeeService(feeScheduleDao, feeOverrideDao, tradeDao, pairDao, tokenDao, feeLedgerDao)
+	referralService := services.NewReferralService(referralDao, referralRebateDao, pairDao)
+	authService := services.NewAuthService(authChallengeDao)
+	apiKeyService := services.NewAPIKeyService(apiKeyDao)
+	validatorService := services.NewValidatorService(balanceService, lockedBalanceService, accountDao, pairDao, exchange)
+	orderService := services.NewOrderService(orderDao, pairDao, accountDao, tradeDao, accountNonceDao, orderEventDao, eng, validatorService, lockedBalanceService, feeService, referralService, rabbitConn)
+	orderBookService := services.NewOrderBookService(pairDao, tokenDao, orderDao, eng)
+
+	gasTopUpService := services.NewGasTopUpService(gasTopUpDao, walletService, provider)
+
+	// txservice for deposit
+	// wallet := &types.NewWalletFromPrivateKey(app.Config.Deposit.Tomochain.SignerPrivateKey)
+	// we already have them so no need to re-calculate
+	wallet := &types.Wallet{
+		Address:    app.Config.Deposit.Tomochain.GetPublicKey(),
+		PrivateKey: app.Config.Deposit.Tomochain.GetPrivateKey(),
+	}
+	txService := services.NewTxService(walletDao, wallet)
+	depositService := services.NewDepositService(configDao, associationDao, heldDepositDao, pairDao, orderDao, swapEngine, eng, rabbitConn)
+
 	// deploy operator
 	op, err := operator.NewOperator(
 		walletService,
@@ -131,6 +159,7 @@ func NewRouter(
 		orderService,
 		provider,
 		exchange,
+		hardCancelDao,
 		rabbitConn,
 	)
 
@@ -138,24 +167,92 @@ func NewRouter(
 		panic(err)
 	}
 
+	governanceService := services.NewGovernanceService(exchange, walletDao, provider, adminTransactionDao)
+	err = governanceService.SyncOperatorWallets()
+	if err != nil {
+		panic(err)
+	}
+
+	// pairs are registered on the exchange contract through the governance service
+	pairService := services.NewPairService(pairDao, tokenDao, tradeDao, orderDao, eng, provider, exchange, governanceService)
+
+	// reconcile trades with the exchange contract logs
+	indexer := operator.NewIndexer(configDao, tradeService, orderService, exchange, rabbitConn)
+	err = indexer.Start()
+	if err != nil {
+		panic(err)
+	}
+
+	// compare the orders and trades with the Filled and Traded mappings of the exchange contract
+	reconciliationService := services.NewReconciliationService(orderDao, tradeDao, orderService, exchange, rabbitConn)
+
+	// start cron service
+	cronService := crons.NewCronService(ohlcvService, gasTopUpService, reconciliationService, lockedBalanceService)
+
+	// requests are authenticated with a JWT or with the signature of an API key
+	r.Use(endpoints.Authenticator(apiKeyService))
+
 	// deploy http and ws endpoints
-	endpoints.ServeInfoResource(r, walletService, tokenService)
-	endpoints.ServeAccountResource(r, accountService)
+	endpoints.ServeInfoResource(r, walletService, tokenService, feeService, contractStatus)
+	endpoints.ServeAccountResource(r, accountService, authService)
 	endpoints.ServeTokenResource(r, tokenService)
 	endpoints.ServePairResource(r, pairService)
 	endpoints.ServeOrderBookResource(r, orderBookService)
 	endpoints.ServeOHLCVResource(r, ohlcvService)
 	endpoints.ServeTradeResource(r, tradeService)
-	endpoints.ServeOrderResource(r, orderService, accountService)
+	endpoints.ServeOrderResource(r, orderService, accountService, authService, contractStatus)
+	endpoints.ServeGasTopUpResource(r, gasTopUpService)
+	endpoints.ServeGovernanceResource(r, governanceService)
+	endpoints.ServeReconciliationResource(r, reconciliationService)
+	endpoints.ServeFeeResource(r, feeService)
+	endpoints.ServeReferralResource(r, referralService)
+	endpoints.ServeAuthResource(r, authService)
+	endpoints.ServeAPIKeyResource(r, apiKeyService)
+	endpoints.ServeWebsocketResource(r)
 
-	endpoints.ServeDepositResource(r, depositService, walletService, txService)
+	endpoints.ServeDepositResource(r, depositService, walletService, txService, accountService)
 
 	//initialize rabbitmq subscriptions
 	rabbitConn.SubscribeOrders(eng.HandleOrders)
-	rabbitConn.SubscribeTrades(op.HandleTrades)
+
+	// trades are not settled with an incompatible exchange contract
+	if !contractStatus.IsReadOnly() {
+		rabbitConn.SubscribeTrades(op.HandleTrades)
+		rabbitConn.SubscribeHardCancels(op.HandleHardCancel)
+	}
+
 	rabbitConn.SubscribeOperator(orderService.HandleOperatorMessages)
 	rabbitConn.SubscribeEngineResponses(orderService.HandleEngineResponse)
 
 	cronService.InitCrons()
 	return r
 }
+
+// checkExchangeContract compares the deployed exchange contract with the supported versions and
+// the configuration. On mismatch, the server refuses to start unless the on_contract_mismatch
+// configuration is set to read_only, in which case orders can not be placed or cancelled
+func checkExchangeContract(exchange *contracts.Exchange) *types.ContractStatus {
+	weth := common.HexToAddress(app.Config.Ethereum["weth_address"])
+	rewardAccount := common.HexToAddress(app.Config.Ethereum["reward_account"])
+
+	status, err := exchange.CheckCompatibility(weth, rewardAccount)
+	if err != nil {
+		panic(err)
+	}
+
+	if status.IsCompatible() {
+		return status
+	}
+
+	for _, m := range status.Mismatches {
+		log.Printf("Exchange contract mismatch: %v\n", m)
+	}
+
+	if app.Config.Ethereum["on_contract_mismatch"] != "read_only" {
+		panic(errors.New("Exchange contract is not compatible with the server"))
+	}
+
+	log.Println("Starting in read-only mode")
+	status.ReadOnly = true
+	return status
+}
@@ -2,24 +2,28 @@ package services
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/tomochain/dex-server/interfaces"
 	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/ws"
 	"gopkg.in/mgo.v2/bson"
 )
 
 type AccountService struct {
-	accountDao interfaces.AccountDao
-	tokenDao   interfaces.TokenDao
+	accountDao     interfaces.AccountDao
+	tokenDao       interfaces.TokenDao
+	restrictionDao interfaces.AccountRestrictionDao
 }
 
 // NewAddressService returns a new instance of accountService
 func NewAccountService(
 	accountDao interfaces.AccountDao,
 	tokenDao interfaces.TokenDao,
+	restrictionDao interfaces.AccountRestrictionDao,
 ) *AccountService {
-	return &AccountService{accountDao, tokenDao}
+	return &AccountService{accountDao, tokenDao, restrictionDao}
 }
 
 func (s *AccountService) Create(a *types.Account) error {
@@ -44,13 +48,11 @@ func (s *AccountService) Create(a *types.Account) error {
 	a.IsBlocked = false
 	a.TokenBalances = make(map[common.Address]*types.TokenBalance)
 
-	// currently by default, the tokens balances are set to 0
+	// balances and allowances are left unset until they are read from the chain
 	for _, token := range tokens {
 		a.TokenBalances[token.ContractAddress] = &types.TokenBalance{
 			Address:        token.ContractAddress,
 			Symbol:         token.Symbol,
-			Balance:        big.NewInt(0),
-			Allowance:      big.NewInt(0),
 			LockedBalance:  big.NewInt(0),
 			PendingBalance: big.NewInt(0),
 		}
@@ -90,13 +92,11 @@ func (s *AccountService) FindOrCreate(addr common.Address) (*types.Account, erro
 		TokenBalances: make(map[common.Address]*types.TokenBalance),
 	}
 
-	// currently by default, the tokens balances are set to 0
+	// balances and allowances are left unset until they are read from the chain
 	for _, t := range tokens {
 		a.TokenBalances[t.ContractAddress] = &types.TokenBalance{
 			Address:        t.ContractAddress,
 			Symbol:         t.Symbol,
-			Balance:        big.NewInt(0),
-			Allowance:      big.NewInt(0),
 			LockedBalance:  big.NewInt(0),
 			PendingBalance: big.NewInt(0),
 		}
@@ -130,3 +130,73 @@ func (s *AccountService) GetTokenBalance(owner common.Address, token common.Addr
 func (s *AccountService) GetTokenBalances(owner common.Address) (map[common.Address]*types.TokenBalance, error) {
 	return s.accountDao.GetTokenBalances(owner)
 }
+
+// SetRestriction changes the restriction level of an account and records the change in the audit
+// log. The account is created if it does not exist yet so that addresses can be restricted before
+// they start trading
+func (s *AccountService) SetRestriction(a common.Address, r *types.AccountRestriction, source string) (*types.Account, error) {
+	err := r.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := s.FindOrCreate(a)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	previousLevel := acc.RestrictionLevel()
+	r.UpdatedAt = time.Now()
+
+	err = s.accountDao.UpdateRestriction(a, r)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	err = s.restrictionDao.Create(&types.AccountRestrictionChange{
+		Address:       a,
+		PreviousLevel: previousLevel,
+		Level:         r.Level,
+		Reason:        r.Reason,
+		ExpiresAt:     r.ExpiresAt,
+		Source:        source,
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	logger.Infof("Account %v restriction changed from %v to %v (%v)", a.Hex(), previousLevel, r.Level, r.Reason)
+
+	acc.Restriction = r
+	acc.IsBlocked = r.Level == types.ACCOUNT_BLOCKED
+	return acc, nil
+}
+
+// GetRestrictionHistory returns the restriction changes of an account from the most recent one
+func (s *AccountService) GetRestrictionHistory(a common.Address, limit ...int) ([]*types.AccountRestrictionChange, error) {
+	return s.restrictionDao.GetByAddress(a, limit...)
+}
+
+// sendTokenBalance pushes the stored balance, allowance and locked balance of a token to the
+// connections subscribed to the account channel of its owner
+func sendTokenBalance(accountDao interfaces.AccountDao, owner, token common.Address) {
+	if len(ws.GetAccountConnections(owner)) == 0 {
+		return
+	}
+
+	b, err := accountDao.GetTokenBalance(owner, token)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	if b == nil {
+		return
+	}
+
+	ws.SendAccountMessage(types.UPDATE, owner, b)
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/testutils/mocks"
+)
+
+func SetupAccountServiceTest() (*mocks.AccountDao, *mocks.TokenDao, *mocks.AccountRestrictionDao, *AccountService) {
+	accountDao := new(mocks.AccountDao)
+	tokenDao := new(mocks.TokenDao)
+	restrictionDao := new(mocks.AccountRestrictionDao)
+
+	s := NewAccountService(accountDao, tokenDao, restrictionDao)
+	return accountDao, tokenDao, restrictionDao, s
+}
+
+func TestSetRestriction(t *testing.T) {
+	addr := common.HexToAddress("0x1")
+
+	tests := []struct {
+		name          string
+		account       *types.Account
+		previousLevel string
+	}{
+		{"new account", nil, types.ACCOUNT_ACTIVE},
+		{"active account", &types.Account{Address: addr}, types.ACCOUNT_ACTIVE},
+		{"blocked account", &types.Account{Address: addr, IsBlocked: true}, types.ACCOUNT_BLOCKED},
+	}
+
+	for _, test := range tests {
+		accountDao, tokenDao, restrictionDao, s := SetupAccountServiceTest()
+		r := &types.AccountRestriction{Level: types.ACCOUNT_CANCEL_ONLY, Reason: "compliance review"}
+
+		accountDao.On("GetByAddress", addr).Return(test.account, nil)
+		accountDao.On("Create", mock.Anything).Return(nil)
+		tokenDao.On("GetAll").Return([]types.Token{}, nil)
+		accountDao.On("UpdateRestriction", addr, r).Return(nil)
+		restrictionDao.On("Create", mock.MatchedBy(func(c *types.AccountRestrictionChange) bool {
+			return c.Address == addr &&
+				c.PreviousLevel == test.previousLevel &&
+				c.Level == types.ACCOUNT_CANCEL_ONLY &&
+				c.Reason == r.Reason &&
+				c.Source == "admin"
+		})).Return(nil)
+
+		acc, err := s.SetRestriction(addr, r, "admin")
+		if err != nil {
+			t.Fatalf("%v: could not set the restriction: %v", test.name, err)
+		}
+
+		if acc.RestrictionLevel() != types.ACCOUNT_CANCEL_ONLY || acc.IsBlocked {
+			t.Errorf("%v: expected the account to be restricted to %v, got %v", test.name, types.ACCOUNT_CANCEL_ONLY, acc.RestrictionLevel())
+		}
+
+		if test.account == nil {
+			accountDao.AssertCalled(t, "Create", mock.Anything)
+		} else {
+			accountDao.AssertNotCalled(t, "Create", mock.Anything)
+		}
+
+		restrictionDao.AssertExpectations(t)
+	}
+}
+
+func TestSetInvalidRestriction(t *testing.T) {
+	accountDao, _, restrictionDao, s := SetupAccountServiceTest()
+	addr := common.HexToAddress("0x1")
+
+	tests := map[string]*types.AccountRestriction{
+		"unknown level":  {Level: "FROZEN", Reason: "compliance review"},
+		"missing reason": {Level: types.ACCOUNT_BLOCKED},
+	}
+
+	for name, r := range tests {
+		_, err := s.SetRestriction(addr, r, "admin")
+		if err == nil {
+			t.Errorf("%v: expected the restriction to be refused", name)
+		}
+	}
+
+	accountDao.AssertNotCalled(t, "UpdateRestriction", mock.Anything, mock.Anything)
+	restrictionDao.AssertNotCalled(t, "Create", mock.Anything)
+}
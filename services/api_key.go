@@ -0,0 +1,185 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/interfaces"
+	"github.com/tomochain/dex-server/types"
+)
+
+// MaxAPIKeysPerAccount is the number of API keys an account can have at the same time
+const MaxAPIKeysPerAccount = 10
+
+// APIKeyTimestampTolerance is the maximum difference between the timestamp of a signed request
+// and the time it is received
+const APIKeyTimestampTolerance = 30 * time.Second
+
+// APIKeyService manages the API keys of the accounts and authenticates the requests signed with them.
+// The signatures of the requests are remembered by key until their timestamp expires so that they can
+// not be replayed
+type APIKeyService struct {
+	apiKeyDao  interfaces.APIKeyDao
+	signatures map[string]time.Time
+	mutex      *sync.Mutex
+}
+
+// NewAPIKeyService returns a new instance of APIKeyService
+func NewAPIKeyService(apiKeyDao interfaces.APIKeyDao) *APIKeyService {
+	return &APIKeyService{apiKeyDao, map[string]time.Time{}, &sync.Mutex{}}
+}
+
+// Create generates the public key and the secret of a new API key of an account. The secret is
+// returned once and is stored encrypted. The keys are counted and inserted under the lock of the
+// service so that concurrent creations can not exceed MaxAPIKeysPerAccount
+func (s *APIKeyService) Create(k *types.APIKey) (string, error) {
+	err := k.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	keys, err := s.apiKeyDao.GetByUserAddress(k.UserAddress)
+	if err != nil {
+		logger.Error(err)
+		return "", err
+	}
+
+	if len(keys) >= MaxAPIKeysPerAccount {
+		return "", ErrTooManyAPIKeys
+	}
+
+	key, err := randomHex(16)
+	if err != nil {
+		logger.Error(err)
+		return "", err
+	}
+
+	secret, err := randomHex(32)
+	if err != nil {
+		logger.Error(err)
+		return "", err
+	}
+
+	k.Key = key
+	k.CreatedAt = time.Now()
+
+	err = k.SetSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.apiKeyDao.Create(k)
+	if err != nil {
+		logger.Error(err)
+		return "", err
+	}
+
+	logger.Infof("API key %v with the %v scope created for %v", k.Key, k.Scope, k.UserAddress.Hex())
+	return secret, nil
+}
+
+// GetByUserAddress returns the API keys of an account
+func (s *APIKeyService) GetByUserAddress(addr common.Address) ([]*types.APIKey, error) {
+	return s.apiKeyDao.GetByUserAddress(addr)
+}
+
+// Revoke deletes an API key of an account. The requests signed with it are refused immediately
+func (s *APIKeyService) Revoke(addr common.Address, key string) error {
+	k, err := s.apiKeyDao.Delete(addr, key)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if k == nil {
+		return ErrAPIKeyNotFound
+	}
+
+	logger.Infof("API key %v of %v revoked", key, addr.Hex())
+	return nil
+}
+
+// Authenticate checks the timestamp, the signature and the origin of a request signed with an API
+// key, and returns the account and the scope of the key
+func (s *APIKeyService) Authenticate(req *types.APIKeyRequest) (*types.Authentication, error) {
+	ts, err := strconv.ParseInt(req.Timestamp, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidAPIKeyTimestamp
+	}
+
+	d := time.Since(time.Unix(ts, 0))
+	if d > APIKeyTimestampTolerance || d < -APIKeyTimestampTolerance {
+		return nil, ErrInvalidAPIKeyTimestamp
+	}
+
+	k, err := s.apiKeyDao.GetByKey(req.Key)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if k == nil {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if !k.VerifySignature(req.Signature, req.Timestamp, req.Method, req.URI, req.Body) {
+		return nil, ErrInvalidSignature
+	}
+
+	if !s.useSignature(k.Key, req.Signature, time.Unix(ts, 0)) {
+		return nil, ErrAPIKeySignatureReused
+	}
+
+	if !k.AllowsIP(req.IP) {
+		return nil, ErrAPIKeyIPNotAllowed
+	}
+
+	return &types.Authentication{
+		Address: k.UserAddress,
+		Role:    types.AUTH_ROLE_USER,
+		APIKey:  k.Key,
+		Scope:   k.Scope,
+	}, nil
+}
+
+// useSignature records the signature of a request signed with a key and returns false if it was
+// already used. The signatures are forgotten once their timestamp is refused anyway
+func (s *APIKeyService) useSignature(key, signature string, ts time.Time) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	for sig, expiry := range s.signatures {
+		if now.After(expiry) {
+			delete(s.signatures, sig)
+		}
+	}
+
+	signature = key + ":" + strings.ToLower(signature)
+	if _, ok := s.signatures[signature]; ok {
+		return false
+	}
+
+	s.signatures[signature] = ts.Add(APIKeyTimestampTolerance)
+	return true
+}
+
+// randomHex returns n random bytes encoded in hexadecimal
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/testutils/mocks"
+)
+
+func TestAuthenticateReplayedSignature(t *testing.T) {
+	app.Config.APIKeyEncryptionKey = "test-api-key-encryption-key"
+
+	apiKeyDao := new(mocks.APIKeyDao)
+	s := NewAPIKeyService(apiKeyDao)
+
+	k := &types.APIKey{Key: "key", UserAddress: common.HexToAddress("0x1"), Scope: types.API_KEY_SCOPE_READ}
+	err := k.SetSecret("secret")
+	if err != nil {
+		t.Fatalf("Could not set secret: %v", err)
+	}
+
+	apiKeyDao.On("GetByKey", k.Key).Return(k, nil)
+
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req := &types.APIKeyRequest{
+		Key:       k.Key,
+		Timestamp: ts,
+		Signature: types.ComputeAPISignature("secret", ts, "GET", "/orders", nil),
+		Method:    "GET",
+		URI:       "/orders",
+	}
+
+	auth, err := s.Authenticate(req)
+	if err != nil {
+		t.Fatalf("Could not authenticate request: %v", err)
+	}
+
+	if auth.Address != k.UserAddress || auth.Scope != k.Scope {
+		t.Errorf("Unexpected authentication %v", auth)
+	}
+
+	_, err = s.Authenticate(req)
+	if err != ErrAPIKeySignatureReused {
+		t.Errorf("Expected ErrAPIKeySignatureReused, got %v", err)
+	}
+
+	// another request signed with the same key is accepted
+	req.URI = "/orders?nonce=1"
+	req.Signature = types.ComputeAPISignature("secret", ts, "GET", req.URI, nil)
+
+	_, err = s.Authenticate(req)
+	if err != nil {
+		t.Errorf("Could not authenticate another request: %v", err)
+	}
+}
+
+func TestCreateAPIKeyLimit(t *testing.T) {
+	app.Config.APIKeyEncryptionKey = "test-api-key-encryption-key"
+
+	apiKeyDao := new(mocks.APIKeyDao)
+	s := NewAPIKeyService(apiKeyDao)
+
+	addr := common.HexToAddress("0x1")
+	mutex := &sync.Mutex{}
+	stored := []*types.APIKey{}
+	for i := 0; i < MaxAPIKeysPerAccount-1; i++ {
+		stored = append(stored, &types.APIKey{})
+	}
+
+	apiKeyDao.On("GetByUserAddress", addr).Return(func(common.Address) []*types.APIKey {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]*types.APIKey{}, stored...)
+	}, nil)
+
+	apiKeyDao.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+		// leave time for a concurrent creation to count the keys
+		time.Sleep(10 * time.Millisecond)
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		stored = append(stored, args.Get(0).(*types.APIKey))
+	}).Return(nil)
+
+	wg := sync.WaitGroup{}
+	created := make(chan bool, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := s.Create(&types.APIKey{UserAddress: addr, Scope: types.API_KEY_SCOPE_READ})
+			created <- err == nil
+		}()
+	}
+
+	wg.Wait()
+	close(created)
+
+	count := 0
+	for ok := range created {
+		if ok {
+			count++
+		}
+	}
+
+	if count != 1 || len(stored) != MaxAPIKeysPerAccount {
+		t.Errorf("Expected a single key to be created, got %v keys created and %v keys stored", count, len(stored))
+	}
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"crypto/rand"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/interfaces"
+	"github.com/tomochain/dex-server/types"
+)
+
+// Default lifetimes of the login challenges and of the issued tokens
+const (
+	DefaultAuthChallengeDuration = 5 * time.Minute
+	DefaultAuthTokenDuration     = 24 * time.Hour
+)
+
+// AuthService issues the JWTs of the accounts that prove the ownership of their address
+// by signing a login challenge
+type AuthService struct {
+	authChallengeDao interfaces.AuthChallengeDao
+}
+
+// NewAuthService returns a new instance of AuthService
+func NewAuthService(authChallengeDao interfaces.AuthChallengeDao) *AuthService {
+	return &AuthService{authChallengeDao}
+}
+
+// NewChallenge returns a new login challenge for an account, replacing its previous one
+func (s *AuthService) NewChallenge(addr common.Address) (*types.AuthChallenge, error) {
+	nonce := make([]byte, common.HashLength)
+
+	_, err := rand.Read(nonce)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	c := &types.AuthChallenge{
+		Address:   addr,
+		Nonce:     common.BytesToHash(nonce),
+		ExpiresAt: time.Now().Add(authDuration("challenge_duration", DefaultAuthChallengeDuration)),
+	}
+
+	err = s.authChallengeDao.Upsert(c)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Login checks the signature of a login challenge and returns a JWT authenticating the account,
+// with the admin role if its address is a configured admin address
+func (s *AuthService) Login(l *types.AuthLogin) (string, *types.AuthClaims, error) {
+	role, err := s.verifyLogin(l)
+	if err != nil {
+		return "", nil, err
+	}
+
+	claims := types.NewAuthClaims(l.Address, role, time.Now().Add(authDuration("token_duration", DefaultAuthTokenDuration)))
+	token, err := claims.SignedString()
+	if err != nil {
+		logger.Error(err)
+		return "", nil, err
+	}
+
+	logger.Infof("%v logged in with the %v role", l.Address.Hex(), role)
+	return token, claims, nil
+}
+
+// AuthenticateSubscription checks the JWT or the signed login challenge of a websocket subscription
+// to the private events of an account
+func (s *AuthService) AuthenticateSubscription(sub *types.PrivateSubscription) (*types.Authentication, error) {
+	if sub.Token != "" {
+		claims, err := types.ParseAuthToken(sub.Token)
+		if err != nil {
+			return nil, ErrInvalidAuthToken
+		}
+
+		return types.NewTokenAuthentication(claims), nil
+	}
+
+	if sub.Login == nil {
+		return nil, ErrInvalidAuthToken
+	}
+
+	role, err := s.verifyLogin(sub.Login)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Authentication{Address: sub.Login.Address, Role: role}, nil
+}
+
+// verifyLogin checks and consumes a signed login challenge, and returns the role of the account
+func (s *AuthService) verifyLogin(l *types.AuthLogin) (string, error) {
+	err := l.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	// the signature is checked first so that an invalid login does not consume the challenge
+	ok, err := l.VerifySignature()
+	if err != nil {
+		logger.Error(err)
+	}
+
+	if !ok {
+		return "", ErrInvalidSignature
+	}
+
+	c, err := s.authChallengeDao.Consume(l.Address, l.Nonce)
+	if err != nil {
+		logger.Error(err)
+		return "", err
+	}
+
+	if c == nil || c.IsExpired() {
+		return "", ErrInvalidAuthChallenge
+	}
+
+	if isAdminAddress(l.Address) {
+		return types.AUTH_ROLE_ADMIN, nil
+	}
+
+	return types.AUTH_ROLE_USER, nil
+}
+
+// isAdminAddress returns true if an address is one of the configured admin addresses
+func isAdminAddress(addr common.Address) bool {
+	for _, a := range strings.Split(app.Config.Auth["admin_addresses"], ",") {
+		a = strings.TrimSpace(a)
+		if common.IsHexAddress(a) && common.HexToAddress(a) == addr {
+			return true
+		}
+	}
+
+	return false
+}
+
+// authDuration returns a configured duration of the auth section, or its default value
+func authDuration(key string, def time.Duration) time.Duration {
+	d, err := time.ParseDuration(app.Config.Auth[key])
+	if err != nil || d <= 0 {
+		return def
+	}
+
+	return d
+}
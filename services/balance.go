@@ -0,0 +1,300 @@
+package services
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/contracts/contractsinterfaces"
+	"github.com/tomochain/dex-server/interfaces"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils"
+)
+
+// balanceWatchRetryInterval is the time waited before resubscribing to the logs of a token
+const balanceWatchRetryInterval = 5 * time.Second
+
+// BalanceService keeps the token balances and exchange allowances of the accounts cached in the
+// accounts collection. The cache of a token is followed with its ERC20 Transfer and Approval logs
+// and is only used while these logs are watched. Cache misses are read from the chain.
+type BalanceService struct {
+	accountDao interfaces.AccountDao
+	tokenDao   interfaces.TokenDao
+	provider   interfaces.EthereumProvider
+	filterer   bind.ContractFilterer
+	following  map[common.Address]bool
+	watched    map[common.Address]bool
+	mutex      *sync.Mutex
+}
+
+// NewBalanceService returns a new instance of BalanceService
+func NewBalanceService(
+	accountDao interfaces.AccountDao,
+	tokenDao interfaces.TokenDao,
+	provider interfaces.EthereumProvider,
+	filterer bind.ContractFilterer,
+) *BalanceService {
+	return &BalanceService{
+		accountDao,
+		tokenDao,
+		provider,
+		filterer,
+		make(map[common.Address]bool),
+		make(map[common.Address]bool),
+		&sync.Mutex{},
+	}
+}
+
+// Start watches the Transfer and Approval logs of the registered tokens
+func (s *BalanceService) Start() error {
+	tokens, err := s.tokenDao.GetAll()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	for _, t := range tokens {
+		s.watchToken(t.ContractAddress)
+	}
+
+	return nil
+}
+
+// GetBalance returns the token balance of an account
+func (s *BalanceService) GetBalance(owner, token common.Address) (*big.Int, error) {
+	return s.get(owner, token, false)
+}
+
+// GetAllowance returns the amount of tokens of an account the exchange contract is allowed to transfer
+func (s *BalanceService) GetAllowance(owner, token common.Address) (*big.Int, error) {
+	return s.get(owner, token, true)
+}
+
+func (s *BalanceService) get(owner, token common.Address, allowance bool) (*big.Int, error) {
+	cached := s.isWatched(token)
+	if !cached {
+		s.watchRegisteredToken(token)
+	}
+
+	var balances map[common.Address]*types.TokenBalance
+	if cached {
+		var err error
+		balances, err = s.accountDao.GetTokenBalances(owner)
+		if err != nil {
+			logger.Error(err)
+		}
+
+		if tb := balances[token]; tb != nil {
+			if allowance && tb.Allowance != nil {
+				return tb.Allowance, nil
+			}
+
+			if !allowance && tb.Balance != nil {
+				return tb.Balance, nil
+			}
+		}
+	}
+
+	var value *big.Int
+	err := utils.Retry(3, func() error {
+		var err error
+		if allowance {
+			value, err = s.provider.ExchangeAllowance(owner, token)
+		} else {
+			value, err = s.provider.BalanceOf(owner, token)
+		}
+
+		return err
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	// only the accounts that are stored are cached
+	if balances != nil {
+		s.store(owner, token, value, allowance)
+	}
+
+	return value, nil
+}
+
+func (s *BalanceService) store(owner, token common.Address, value *big.Int, allowance bool) {
+	var err error
+	if allowance {
+		err = s.accountDao.UpdateAllowance(owner, token, value)
+	} else {
+		err = s.accountDao.UpdateBalance(owner, token, value)
+	}
+
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	sendTokenBalance(s.accountDao, owner, token)
+}
+
+func (s *BalanceService) isWatched(token common.Address) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.watched[token]
+}
+
+func (s *BalanceService) setWatched(token common.Address, watched bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.watched[token] = watched
+}
+
+// watchRegisteredToken starts watching a token that was registered after the service started
+func (s *BalanceService) watchRegisteredToken(token common.Address) {
+	t, err := s.tokenDao.GetByAddress(token)
+	if err != nil || t == nil {
+		return
+	}
+
+	s.watchToken(token)
+}
+
+// watchToken follows the logs of a token in the background. WETH balances are not cached since
+// wrapping and unwrapping ether does not emit Transfer logs
+func (s *BalanceService) watchToken(token common.Address) {
+	if token == common.HexToAddress(app.Config.Ethereum["weth_address"]) {
+		return
+	}
+
+	s.mutex.Lock()
+	if s.following[token] {
+		s.mutex.Unlock()
+		return
+	}
+
+	s.following[token] = true
+	s.mutex.Unlock()
+
+	go func() {
+		for {
+			err := s.followToken(token)
+			if err != nil {
+				logger.Error(err)
+			}
+
+			time.Sleep(balanceWatchRetryInterval)
+		}
+	}()
+}
+
+// followToken updates the cache of a token until its logs subscription fails. The cache of the token
+// is cleared each time the subscription is established since logs might have been missed before, and
+// it is not read while the token is not followed
+func (s *BalanceService) followToken(token common.Address) error {
+	defer s.setWatched(token, false)
+
+	filterer, err := contractsinterfaces.NewTokenFilterer(token, s.filterer)
+	if err != nil {
+		return err
+	}
+
+	transfers := make(chan *contractsinterfaces.TokenTransfer)
+	approvals := make(chan *contractsinterfaces.TokenApproval)
+	opts := &bind.WatchOpts{nil, nil}
+
+	transferSub, err := filterer.WatchTransfer(opts, transfers, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	defer transferSub.Unsubscribe()
+
+	approvalSub, err := filterer.WatchApproval(opts, approvals, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	defer approvalSub.Unsubscribe()
+
+	err = s.accountDao.ClearBalanceCache(token)
+	if err != nil {
+		return err
+	}
+
+	s.setWatched(token, true)
+
+	exchangeAddress := common.HexToAddress(app.Config.Ethereum["exchange_address"])
+
+	for {
+		select {
+		case ev := <-transfers:
+			s.handleTransfer(token, ev)
+		case ev := <-approvals:
+			if ev.Spender == exchangeAddress {
+				s.handleApproval(token, ev)
+			}
+		case err := <-transferSub.Err():
+			return err
+		case err := <-approvalSub.Err():
+			return err
+		}
+	}
+}
+
+// handleTransfer refreshes the balances of the sender and the receiver of a transfer. The
+// allowance of the sender is refreshed as well since transfers sent by the exchange contract
+// consume it without emitting an Approval log
+func (s *BalanceService) handleTransfer(token common.Address, ev *contractsinterfaces.TokenTransfer) {
+	for _, a := range []common.Address{ev.From, ev.To} {
+		if !s.isActive(a) {
+			continue
+		}
+
+		balance, err := s.provider.BalanceOf(a, token)
+		if err != nil {
+			logger.Error(err)
+			continue
+		}
+
+		s.store(a, token, balance, false)
+	}
+
+	if !s.isActive(ev.From) {
+		return
+	}
+
+	allowance, err := s.provider.ExchangeAllowance(ev.From, token)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	s.store(ev.From, token, allowance, true)
+}
+
+func (s *BalanceService) handleApproval(token common.Address, ev *contractsinterfaces.TokenApproval) {
+	if !s.isActive(ev.Owner) {
+		return
+	}
+
+	s.store(ev.Owner, token, ev.Value, true)
+}
+
+// isActive returns true if an address corresponds to a stored account
+func (s *BalanceService) isActive(a common.Address) bool {
+	if a == (common.Address{}) {
+		return false
+	}
+
+	acc, err := s.accountDao.GetByAddress(a)
+	if err != nil {
+		logger.Error(err)
+		return false
+	}
+
+	return acc != nil
+}
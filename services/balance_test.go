@@ -0,0 +1,144 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/tomochain/dex-server/contracts/contractsinterfaces"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/testutils/mocks"
+)
+
+func SetupBalanceServiceTest() (*mocks.AccountDao, *mocks.TokenDao, *mocks.EthereumProvider, *BalanceService) {
+	accountDao := new(mocks.AccountDao)
+	tokenDao := new(mocks.TokenDao)
+	provider := new(mocks.EthereumProvider)
+
+	s := NewBalanceService(accountDao, tokenDao, provider, nil)
+	return accountDao, tokenDao, provider, s
+}
+
+func TestGetBalanceFromCache(t *testing.T) {
+	accountDao, _, provider, s := SetupBalanceServiceTest()
+	owner := common.HexToAddress("0x1")
+	token := common.HexToAddress("0x2")
+
+	s.setWatched(token, true)
+	accountDao.On("GetTokenBalances", owner).Return(map[common.Address]*types.TokenBalance{
+		token: {Balance: big.NewInt(100), Allowance: big.NewInt(50)},
+	}, nil)
+
+	balance, err := s.GetBalance(owner, token)
+	if err != nil {
+		t.Fatalf("Could not get the balance: %v", err)
+	}
+
+	allowance, err := s.GetAllowance(owner, token)
+	if err != nil {
+		t.Fatalf("Could not get the allowance: %v", err)
+	}
+
+	if balance.Cmp(big.NewInt(100)) != 0 || allowance.Cmp(big.NewInt(50)) != 0 {
+		t.Errorf("Expected the cached balance and allowance, got %v and %v", balance, allowance)
+	}
+
+	provider.AssertNotCalled(t, "BalanceOf", mock.Anything, mock.Anything)
+	provider.AssertNotCalled(t, "ExchangeAllowance", mock.Anything, mock.Anything)
+}
+
+func TestGetBalanceCacheMiss(t *testing.T) {
+	accountDao, _, provider, s := SetupBalanceServiceTest()
+	owner := common.HexToAddress("0x1")
+	token := common.HexToAddress("0x2")
+
+	s.setWatched(token, true)
+	accountDao.On("GetTokenBalances", owner).Return(map[common.Address]*types.TokenBalance{}, nil)
+	accountDao.On("UpdateBalance", owner, token, big.NewInt(100)).Return(nil)
+	provider.On("BalanceOf", owner, token).Return(big.NewInt(100), nil)
+
+	balance, err := s.GetBalance(owner, token)
+	if err != nil {
+		t.Fatalf("Could not get the balance: %v", err)
+	}
+
+	if balance.Cmp(big.NewInt(100)) != 0 {
+		t.Errorf("Expected the balance read from the chain, got %v", balance)
+	}
+
+	accountDao.AssertExpectations(t)
+}
+
+func TestGetBalanceOfUnwatchedToken(t *testing.T) {
+	accountDao, tokenDao, provider, s := SetupBalanceServiceTest()
+	owner := common.HexToAddress("0x1")
+	token := common.HexToAddress("0x2")
+
+	tokenDao.On("GetByAddress", token).Return((*types.Token)(nil), nil)
+	provider.On("BalanceOf", owner, token).Return(big.NewInt(100), nil)
+
+	balance, err := s.GetBalance(owner, token)
+	if err != nil {
+		t.Fatalf("Could not get the balance: %v", err)
+	}
+
+	if balance.Cmp(big.NewInt(100)) != 0 {
+		t.Errorf("Expected the balance read from the chain, got %v", balance)
+	}
+
+	// the cache of a token is not read nor written while its logs are not watched
+	accountDao.AssertNotCalled(t, "GetTokenBalances", mock.Anything)
+	accountDao.AssertNotCalled(t, "UpdateBalance", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandleTransfer(t *testing.T) {
+	token := common.HexToAddress("0x2")
+	from := common.HexToAddress("0x3")
+	to := common.HexToAddress("0x4")
+
+	tests := []struct {
+		name      string
+		from      common.Address
+		refreshed []common.Address
+	}{
+		{"transfer", from, []common.Address{from, to}},
+		{"mint", common.Address{}, []common.Address{to}},
+	}
+
+	for _, test := range tests {
+		accountDao, _, provider, s := SetupBalanceServiceTest()
+
+		accountDao.On("GetByAddress", mock.Anything).Return(&types.Account{}, nil)
+		accountDao.On("UpdateBalance", mock.Anything, token, mock.Anything).Return(nil)
+		accountDao.On("UpdateAllowance", test.from, token, big.NewInt(10)).Return(nil)
+		provider.On("BalanceOf", mock.Anything, token).Return(big.NewInt(100), nil)
+		provider.On("ExchangeAllowance", test.from, token).Return(big.NewInt(10), nil)
+
+		s.handleTransfer(token, &contractsinterfaces.TokenTransfer{From: test.from, To: to, Value: big.NewInt(1)})
+
+		provider.AssertNumberOfCalls(t, "BalanceOf", len(test.refreshed))
+		for _, a := range test.refreshed {
+			accountDao.AssertCalled(t, "UpdateBalance", a, token, big.NewInt(100))
+		}
+
+		// the allowance consumed by the exchange contract is refreshed for the sender only
+		if test.from == (common.Address{}) {
+			provider.AssertNotCalled(t, "ExchangeAllowance", mock.Anything, mock.Anything)
+		} else {
+			accountDao.AssertCalled(t, "UpdateAllowance", test.from, token, big.NewInt(10))
+		}
+	}
+}
+
+func TestHandleApprovalOfUnknownAccount(t *testing.T) {
+	accountDao, _, _, s := SetupBalanceServiceTest()
+	owner := common.HexToAddress("0x1")
+	token := common.HexToAddress("0x2")
+
+	accountDao.On("GetByAddress", owner).Return((*types.Account)(nil), nil)
+
+	s.handleApproval(token, &contractsinterfaces.TokenApproval{Owner: owner, Value: big.NewInt(10)})
+
+	accountDao.AssertNotCalled(t, "UpdateAllowance", mock.Anything, mock.Anything, mock.Anything)
+}
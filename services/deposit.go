@@ -23,6 +23,7 @@ import (
 type DepositService struct {
 	configDao      interfaces.ConfigDao
 	associationDao interfaces.AssociationDao
+	heldDepositDao interfaces.HeldDepositDao
 	pairDao        interfaces.PairDao
 	orderDao       interfaces.OrderDao
 	swapEngine     *swap.Engine
@@ -34,6 +35,7 @@ type DepositService struct {
 func NewDepositService(
 	configDao interfaces.ConfigDao,
 	associationDao interfaces.AssociationDao,
+	heldDepositDao interfaces.HeldDepositDao,
 	pairDao interfaces.PairDao,
 	orderDao interfaces.OrderDao,
 	swapEngine *swap.Engine,
@@ -41,7 +43,7 @@ func NewDepositService(
 	broker *rabbitmq.Connection,
 ) *DepositService {
 
-	depositService := &DepositService{configDao, associationDao, pairDao, orderDao, swapEngine, engine, broker}
+	depositService := &DepositService{configDao, associationDao, heldDepositDao, pairDao, orderDao, swapEngine, engine, broker}
 
 	// set storage engine to this service
 	swapEngine.SetStorage(depositService)
@@ -119,6 +121,79 @@ func (s *DepositService) QueueAdd(transaction *types.DepositTransaction) error {
 	return nil
 }
 
+// HoldTransaction records a deposit of an account whose restriction level does not allow deposits.
+// The deposit is not queued until it is released
+func (s *DepositService) HoldTransaction(queueTx *types.DepositTransaction, addressAssociation *types.AddressAssociationRecord) error {
+	d := &types.HeldDeposit{
+		Chain:             addressAssociation.Chain,
+		Address:           addressAssociation.Address,
+		AssociatedAddress: common.HexToAddress(addressAssociation.AssociatedAddress).Hex(),
+		Transaction:       queueTx,
+		Status:            types.DEPOSIT_HELD,
+	}
+
+	err := s.heldDepositDao.Hold(d)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return s.SaveAssociationStatusByChainAddress(addressAssociation, types.DEPOSIT_HELD)
+}
+
+// GetHeldTransactions returns the held and released deposits of an account
+func (s *DepositService) GetHeldTransactions(addr common.Address) ([]*types.HeldDeposit, error) {
+	return s.heldDepositDao.GetByAssociatedAddress(addr)
+}
+
+// ReleaseHeldTransactions queues the held deposits of an account and returns them. The caller
+// checks that the account is allowed to deposit
+func (s *DepositService) ReleaseHeldTransactions(addr common.Address) ([]*types.HeldDeposit, error) {
+	held, err := s.heldDepositDao.GetByAssociatedAddress(addr, types.DEPOSIT_HELD)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	released := []*types.HeldDeposit{}
+	for _, d := range held {
+		// the deposit is marked as released before it is queued so that it is never credited twice
+		err = s.heldDepositDao.UpdateStatus(d.ID, types.DEPOSIT_RELEASED)
+		if err != nil {
+			logger.Error(err)
+			return released, err
+		}
+
+		err = s.QueueAdd(d.Transaction)
+		if err != nil {
+			logger.Error(err)
+
+			if err := s.heldDepositDao.UpdateStatus(d.ID, types.DEPOSIT_HELD); err != nil {
+				logger.Error(err)
+			}
+
+			return released, err
+		}
+
+		association, err := s.GetAssociationByChainAddress(d.Chain, common.HexToAddress(d.Address))
+		if err != nil {
+			logger.Error(err)
+		}
+
+		if association != nil {
+			err = s.SaveAssociationStatusByChainAddress(association, types.PENDING)
+			if err != nil {
+				logger.Error(err)
+			}
+		}
+
+		d.Status = types.DEPOSIT_RELEASED
+		released = append(released, d)
+	}
+
+	return released, nil
+}
+
 // QueuePool receives and removes the head of this queue. Returns nil if no elements found.
 func (s *DepositService) QueuePool() (<-chan *types.DepositTransaction, error) {
 	return s.broker.QueuePoolDepositTransactions()
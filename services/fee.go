@@ -0,0 +1,330 @@
+package services
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/interfaces"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/math"
+)
+
+// FeeVolumeWindow is the trailing period over which the traded volume of an account is
+// computed to select its fee tier
+const FeeVolumeWindow = 30 * 24 * time.Hour
+
+// feeVolumeCacheTTL is the time during which the volume of an account is reused to select
+// its fee tier, so that the trades of the account are not aggregated for every order
+const feeVolumeCacheTTL = 5 * time.Minute
+
+// cachedVolume is the trailing volume of an account in a quote token
+type cachedVolume struct {
+	volume    *big.Int
+	expiresAt time.Time
+}
+
+// FeeService resolves the maker and taker fees of the accounts. The fees of an account
+// for a quote token are, by order of precedence, its fee override, the tier of the fee
+// schedule of the quote token reached by its trailing 30-day volume, and the fees of the pair
+type FeeService struct {
+	feeScheduleDao interfaces.FeeScheduleDao
+	feeOverrideDao interfaces.FeeOverrideDao
+	tradeDao       interfaces.TradeDao
+	pairDao        interfaces.PairDao
+	tokenDao       interfaces.TokenDao
+	feeLedgerDao   interfaces.FeeLedgerDao
+	volumes        map[string]*cachedVolume
+	mutex          *sync.Mutex
+}
+
+// NewFeeService returns a new instance of FeeService
+func NewFeeService(
+	feeScheduleDao interfaces.FeeScheduleDao,
+	feeOverrideDao interfaces.FeeOverrideDao,
+	tradeDao interfaces.TradeDao,
+	pairDao interfaces.PairDao,
+	tokenDao interfaces.TokenDao,
+	feeLedgerDao interfaces.FeeLedgerDao,
+) *FeeService {
+	return &FeeService{
+		feeScheduleDao,
+		feeOverrideDao,
+		tradeDao,
+		pairDao,
+		tokenDao,
+		feeLedgerDao,
+		map[string]*cachedVolume{},
+		&sync.Mutex{},
+	}
+}
+
+// ResolveFees returns the minimum fees an account has to sign on the orders of a pair
+func (s *FeeService) ResolveFees(addr common.Address, p *types.Pair) (*types.ResolvedFee, error) {
+	return s.resolve(addr, p.QuoteTokenAddress, p.MakeFee, p.TakeFee)
+}
+
+// GetEffectiveFees returns the fees of an account for each quote token
+func (s *FeeService) GetEffectiveFees(addr common.Address) ([]*types.ResolvedFee, error) {
+	quotes, err := s.tokenDao.GetQuoteTokens()
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	fees := []*types.ResolvedFee{}
+	for _, q := range quotes {
+		f, err := s.resolve(addr, q.ContractAddress, q.MakeFee, q.TakeFee)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		fees = append(fees, f)
+	}
+
+	return fees, nil
+}
+
+// GetUserVolume returns the volume traded by an account in a quote token over the last 30 days,
+// denominated in quote token units. The volume is cached for a few minutes
+func (s *FeeService) GetUserVolume(addr, quoteToken common.Address) (*big.Int, error) {
+	key := addr.Hex() + quoteToken.Hex()
+
+	s.mutex.Lock()
+	cached := s.volumes[key]
+	s.mutex.Unlock()
+
+	if cached != nil && time.Now().Before(cached.expiresAt) {
+		return cached.volume, nil
+	}
+
+	volume, err := s.computeUserVolume(addr, quoteToken)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for k, v := range s.volumes {
+		if time.Now().After(v.expiresAt) {
+			delete(s.volumes, k)
+		}
+	}
+
+	s.volumes[key] = &cachedVolume{volume, time.Now().Add(feeVolumeCacheTTL)}
+	return volume, nil
+}
+
+// computeUserVolume aggregates the trades of an account in a quote token over the last 30 days
+// and converts the volumes of each pair to quote token units
+func (s *FeeService) computeUserVolume(addr, quoteToken common.Address) (*big.Int, error) {
+	volumes, err := s.tradeDao.GetUserVolumes(addr, quoteToken, time.Now().Add(-FeeVolumeWindow))
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	total := big.NewInt(0)
+	for baseToken, v := range volumes {
+		p, err := s.pairDao.GetByTokenAddress(baseToken, quoteToken)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		if p == nil {
+			logger.Warningf("Pair %v/%v of traded volume not found", baseToken.Hex(), quoteToken.Hex())
+			continue
+		}
+
+		total = math.Add(total, math.Div(v, p.PairMultiplier()))
+	}
+
+	return total, nil
+}
+
+// GetSchedules returns the fee schedules of all the quote tokens
+func (s *FeeService) GetSchedules() ([]*types.FeeSchedule, error) {
+	return s.feeScheduleDao.GetAll()
+}
+
+// SetSchedule creates or replaces the fee schedule of a quote token
+func (s *FeeService) SetSchedule(schedule *types.FeeSchedule) error {
+	err := schedule.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = s.checkQuoteToken(schedule.QuoteToken)
+	if err != nil {
+		return err
+	}
+
+	err = s.feeScheduleDao.Upsert(schedule)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	logger.Infof("Fee schedule of %v updated with %v tiers", schedule.QuoteToken.Hex(), len(schedule.Tiers))
+	return nil
+}
+
+// DeleteSchedule removes the fee schedule of a quote token. The orders of pairs quoted in this
+// token are then charged the fees of the pair
+func (s *FeeService) DeleteSchedule(quoteToken common.Address) error {
+	return s.feeScheduleDao.DeleteByQuoteToken(quoteToken)
+}
+
+// GetOverrides returns the fee overrides of an account
+func (s *FeeService) GetOverrides(addr common.Address) ([]*types.FeeOverride, error) {
+	return s.feeOverrideDao.GetByUserAddress(addr)
+}
+
+// SetOverride creates or replaces the fee override of an account for a quote token
+func (s *FeeService) SetOverride(o *types.FeeOverride) error {
+	err := o.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = s.checkQuoteToken(o.QuoteToken)
+	if err != nil {
+		return err
+	}
+
+	err = s.feeOverrideDao.Upsert(o)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	logger.Infof("Fee override of %v for %v set (%v)", o.UserAddress.Hex(), o.QuoteToken.Hex(), o.Reason)
+	return nil
+}
+
+// DeleteOverride removes the fee override of an account for a quote token
+func (s *FeeService) DeleteOverride(addr, quoteToken common.Address) error {
+	return s.feeOverrideDao.Delete(addr, quoteToken)
+}
+
+// RecordSettledTrades writes the fees of the trades of a settled match to the fee ledger. Each trade
+// is charged the make fee of its maker order and the take fee of the taker order
+func (s *FeeService) RecordSettledTrades(m *types.Matches) {
+	if m == nil || m.TakerOrder == nil {
+		return
+	}
+
+	to := m.TakerOrder
+	for i, t := range m.Trades {
+		if i >= len(m.MakerOrders) {
+			break
+		}
+
+		mo := m.MakerOrders[i]
+		e := &types.FeeLedgerEntry{
+			TradeHash:  t.Hash,
+			PairName:   t.PairName,
+			BaseToken:  t.BaseToken,
+			QuoteToken: t.QuoteToken,
+			FeeToken:   t.QuoteToken,
+			Maker:      t.Maker,
+			Taker:      t.Taker,
+			MakeFee:    mo.MakeFee,
+			TakeFee:    to.TakeFee,
+			SettledAt:  time.Now(),
+		}
+
+		err := s.feeLedgerDao.Upsert(e)
+		if err != nil {
+			logger.Error(err)
+		}
+	}
+}
+
+// GetDailyRevenues returns the fees of the trades settled between two dates by day, pair and fee token
+func (s *FeeService) GetDailyRevenues(from, to time.Time) ([]*types.FeeRevenue, error) {
+	return s.feeLedgerDao.GetDailyRevenues(from, to)
+}
+
+// resolve returns the fees of an account for a quote token, falling back on the given default
+// fees, or on the configured fees if they are not set
+func (s *FeeService) resolve(addr, quoteToken common.Address, makeFee, takeFee *big.Int) (*types.ResolvedFee, error) {
+	f := &types.ResolvedFee{
+		UserAddress: addr,
+		QuoteToken:  quoteToken,
+		MakeFee:     makeFee,
+		TakeFee:     takeFee,
+		Source:      types.FEE_SOURCE_DEFAULT,
+	}
+
+	if f.MakeFee == nil {
+		f.MakeFee = big.NewInt(int64(app.Config.MakeFee))
+	}
+
+	if f.TakeFee == nil {
+		f.TakeFee = big.NewInt(int64(app.Config.TakeFee))
+	}
+
+	override, err := s.feeOverrideDao.GetByQuoteToken(addr, quoteToken)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if override != nil && !override.IsExpired() {
+		f.MakeFee = override.MakeFee
+		f.TakeFee = override.TakeFee
+		f.Source = types.FEE_SOURCE_OVERRIDE
+		return f, nil
+	}
+
+	schedule, err := s.feeScheduleDao.GetByQuoteToken(quoteToken)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	// the traded volume is only needed to select a tier
+	if schedule == nil {
+		return f, nil
+	}
+
+	f.Volume, err = s.GetUserVolume(addr, quoteToken)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	tier := schedule.Tier(f.Volume)
+	if tier != nil {
+		f.MakeFee = tier.MakeFee
+		f.TakeFee = tier.TakeFee
+		f.Source = types.FEE_SOURCE_TIER
+	}
+
+	return f, nil
+}
+
+func (s *FeeService) checkQuoteToken(addr common.Address) error {
+	token, err := s.tokenDao.GetByAddress(addr)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if token == nil {
+		return ErrQuoteTokenNotFound
+	}
+
+	if !token.Quote {
+		return ErrQuoteTokenInvalid
+	}
+
+	return nil
+}
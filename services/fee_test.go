@@ -0,0 +1,130 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/math"
+	"github.com/tomochain/dex-server/utils/testutils/mocks"
+)
+
+func newTestFeeTier(minVolume, makeFee, takeFee int64) *types.FeeTier {
+	return &types.FeeTier{
+		MinVolume: big.NewInt(minVolume),
+		MakeFee:   big.NewInt(makeFee),
+		TakeFee:   big.NewInt(takeFee),
+	}
+}
+
+func TestResolveFees(t *testing.T) {
+	addr := common.HexToAddress("0x1")
+	baseToken := common.HexToAddress("0x2")
+	quoteToken := common.HexToAddress("0x3")
+
+	pair := &types.Pair{
+		BaseTokenAddress:  baseToken,
+		QuoteTokenAddress: quoteToken,
+		MakeFee:           big.NewInt(10),
+		TakeFee:           big.NewInt(20),
+	}
+
+	schedule := &types.FeeSchedule{
+		QuoteToken: quoteToken,
+		Tiers:      []*types.FeeTier{newTestFeeTier(100, 8, 15), newTestFeeTier(1000, 5, 10)},
+	}
+
+	override := &types.FeeOverride{
+		UserAddress: addr,
+		QuoteToken:  quoteToken,
+		MakeFee:     big.NewInt(1),
+		TakeFee:     big.NewInt(2),
+		Reason:      "market maker",
+	}
+
+	expired := *override
+	expired.ExpiresAt = time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name     string
+		override *types.FeeOverride
+		schedule *types.FeeSchedule
+		volume   int64
+		makeFee  int64
+		takeFee  int64
+		source   string
+	}{
+		{"pair default", nil, nil, 0, 10, 20, types.FEE_SOURCE_DEFAULT},
+		{"volume below the tiers", nil, schedule, 50, 10, 20, types.FEE_SOURCE_DEFAULT},
+		{"first tier", nil, schedule, 500, 8, 15, types.FEE_SOURCE_TIER},
+		{"highest tier", nil, schedule, 1000, 5, 10, types.FEE_SOURCE_TIER},
+		{"override", override, schedule, 1000, 1, 2, types.FEE_SOURCE_OVERRIDE},
+		{"expired override", &expired, schedule, 1000, 5, 10, types.FEE_SOURCE_TIER},
+		{"expired override without schedule", &expired, nil, 0, 10, 20, types.FEE_SOURCE_DEFAULT},
+	}
+
+	for _, test := range tests {
+		feeScheduleDao := new(mocks.FeeScheduleDao)
+		feeOverrideDao := new(mocks.FeeOverrideDao)
+		tradeDao := new(mocks.TradeDao)
+		pairDao := new(mocks.PairDao)
+		s := NewFeeService(feeScheduleDao, feeOverrideDao, tradeDao, pairDao, nil, nil)
+
+		volumes := map[common.Address]*big.Int{
+			baseToken: math.Mul(big.NewInt(test.volume), pair.PairMultiplier()),
+		}
+
+		feeOverrideDao.On("GetByQuoteToken", addr, quoteToken).Return(test.override, nil)
+		feeScheduleDao.On("GetByQuoteToken", quoteToken).Return(test.schedule, nil)
+		tradeDao.On("GetUserVolumes", addr, quoteToken, mock.Anything).Return(volumes, nil)
+		pairDao.On("GetByTokenAddress", baseToken, quoteToken).Return(pair, nil)
+
+		f, err := s.ResolveFees(addr, pair)
+		if err != nil {
+			t.Fatalf("%v: could not resolve the fees: %v", test.name, err)
+		}
+
+		if f.Source != test.source {
+			t.Errorf("%v: expected source %v, got %v", test.name, test.source, f.Source)
+		}
+
+		if f.MakeFee.Cmp(big.NewInt(test.makeFee)) != 0 || f.TakeFee.Cmp(big.NewInt(test.takeFee)) != 0 {
+			t.Errorf("%v: expected fees %v/%v, got %v/%v", test.name, test.makeFee, test.takeFee, f.MakeFee, f.TakeFee)
+		}
+
+		if test.source == types.FEE_SOURCE_OVERRIDE {
+			feeScheduleDao.AssertNotCalled(t, "GetByQuoteToken", mock.Anything)
+		}
+
+		if test.schedule == nil {
+			tradeDao.AssertNotCalled(t, "GetUserVolumes", mock.Anything, mock.Anything, mock.Anything)
+		}
+	}
+}
+
+func TestRecordSettledTrades(t *testing.T) {
+	feeLedgerDao := new(mocks.FeeLedgerDao)
+	s := NewFeeService(nil, nil, nil, nil, nil, feeLedgerDao)
+
+	quoteToken := common.HexToAddress("0x1")
+	mo1 := &types.Order{MakeFee: big.NewInt(10)}
+	mo2 := &types.Order{MakeFee: big.NewInt(20)}
+	to := &types.Order{TakeFee: big.NewInt(30)}
+	t1 := &types.Trade{Hash: common.HexToHash("0x2"), QuoteToken: quoteToken}
+	t2 := &types.Trade{Hash: common.HexToHash("0x3"), QuoteToken: quoteToken}
+
+	expected := map[common.Hash]int64{t1.Hash: 10, t2.Hash: 20}
+	feeLedgerDao.On("Upsert", mock.MatchedBy(func(e *types.FeeLedgerEntry) bool {
+		return e.FeeToken == quoteToken &&
+			e.MakeFee.Cmp(big.NewInt(expected[e.TradeHash])) == 0 &&
+			e.TakeFee.Cmp(big.NewInt(30)) == 0
+	})).Return(nil)
+
+	s.RecordSettledTrades(types.NewMatches([]*types.Order{mo1, mo2}, to, []*types.Trade{t1, t2}))
+	s.RecordSettledTrades(nil)
+
+	feeLedgerDao.AssertNumberOfCalls(t, "Upsert", 2)
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/interfaces"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/math"
+)
+
+// GasTopUpService keeps the operator wallets funded with native tokens sent
+// from the default admin wallet and records every transfer in a ledger
+type GasTopUpService struct {
+	gasTopUpDao   interfaces.GasTopUpDao
+	walletService interfaces.WalletService
+	provider      interfaces.EthereumProvider
+	mutex         *sync.Mutex
+}
+
+// NewGasTopUpService returns a new instance of GasTopUpService
+func NewGasTopUpService(
+	gasTopUpDao interfaces.GasTopUpDao,
+	walletService interfaces.WalletService,
+	provider interfaces.EthereumProvider,
+) *GasTopUpService {
+	return &GasTopUpService{gasTopUpDao, walletService, provider, &sync.Mutex{}}
+}
+
+// TopUpOperatorWallets sends native tokens from the admin wallet to every operator
+// wallet whose balance is below the configured floor, up to the configured target.
+// Transfers are limited by the per-wallet and global daily caps (a zero cap means no limit)
+func (s *GasTopUpService) TopUpOperatorWallets() ([]*types.GasTopUp, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	topUps := []*types.GasTopUp{}
+	floor := math.ToBigInt(app.Config.GasTopUp["floor"])
+	target := math.ToBigInt(app.Config.GasTopUp["target"])
+	walletDailyCap := math.ToBigInt(app.Config.GasTopUp["wallet_daily_cap"])
+	dailyCap := math.ToBigInt(app.Config.GasTopUp["daily_cap"])
+
+	if math.IsZero(floor) || math.IsSmallerThan(target, floor) {
+		logger.Warning("Gas top-up is not configured")
+		return topUps, nil
+	}
+
+	admin, err := s.walletService.GetDefaultAdminWallet()
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	wallets, err := s.walletService.GetOperatorWallets()
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	startOfDay := time.Now().UTC().Truncate(24 * time.Hour)
+	sentToday, err := s.gasTopUpDao.GetTotalSince(startOfDay)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	adminBalance, err := s.provider.GetBalanceAt(admin.Address)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	for _, w := range wallets {
+		balance, err := s.provider.GetBalanceAt(w.Address)
+		if err != nil {
+			logger.Error(err)
+			continue
+		}
+
+		if !math.IsStrictlySmallerThan(balance, floor) {
+			continue
+		}
+
+		amount := math.Sub(target, balance)
+
+		if !math.IsZero(walletDailyCap) {
+			walletSentToday, err := s.gasTopUpDao.GetTotalSince(startOfDay, w.Address)
+			if err != nil {
+				logger.Error(err)
+				continue
+			}
+
+			amount = math.Min(amount, math.Sub(walletDailyCap, walletSentToday))
+		}
+
+		if !math.IsZero(dailyCap) {
+			amount = math.Min(amount, math.Sub(dailyCap, sentToday))
+		}
+
+		if !math.IsStrictlyGreaterThan(amount, big.NewInt(0)) {
+			logger.Warningf("Daily gas top-up cap reached for operator wallet %v", w.Address.Hex())
+			continue
+		}
+
+		if math.IsStrictlyGreaterThan(amount, adminBalance) {
+			logger.Errorf("Admin wallet balance is too low to top up operator wallet %v", w.Address.Hex())
+			break
+		}
+
+		// the top-up is recorded before it is sent, so that it counts in the daily caps
+		// even if the transaction hash can not be recorded afterwards
+		topUp := &types.GasTopUp{
+			Wallet:        w.Address,
+			Funder:        admin.Address,
+			Amount:        amount,
+			BalanceBefore: balance,
+			Status:        types.GAS_TOP_UP_CREATED,
+		}
+
+		err = s.gasTopUpDao.Create(topUp)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		hash, err := s.provider.SendEther(admin.PrivateKey, w.Address, amount)
+		if err != nil {
+			logger.Error(err)
+
+			err = s.gasTopUpDao.UpdateTransaction(topUp.ID, common.Hash{}, types.FAILED)
+			if err != nil {
+				logger.Error(err)
+			}
+
+			continue
+		}
+
+		topUp.TxHash = hash
+		topUp.Status = types.PENDING
+
+		err = s.gasTopUpDao.UpdateTransaction(topUp.ID, hash, types.PENDING)
+		if err != nil {
+			logger.Error(err)
+		}
+
+		logger.Infof("Sent %v wei to operator wallet %v (tx: %v)", amount, w.Address.Hex(), hash.Hex())
+
+		sentToday = math.Add(sentToday, amount)
+		adminBalance = math.Sub(adminBalance, amount)
+		topUps = append(topUps, topUp)
+
+		go s.handleTopUpReceipt(hash)
+	}
+
+	return topUps, nil
+}
+
+// GetAll returns the top-up ledger
+func (s *GasTopUpService) GetAll(limit ...int) ([]*types.GasTopUp, error) {
+	return s.gasTopUpDao.GetAll(limit...)
+}
+
+// GetByWalletAddress returns the top-ups sent to a given operator wallet
+func (s *GasTopUpService) GetByWalletAddress(a common.Address, limit ...int) ([]*types.GasTopUp, error) {
+	return s.gasTopUpDao.GetByWalletAddress(a, limit...)
+}
+
+func (s *GasTopUpService) handleTopUpReceipt(hash common.Hash) {
+	status := types.SUCCESS
+
+	receipt, err := s.provider.WaitMined(hash)
+	if err != nil || receipt.Status == 0 {
+		logger.Errorf("Gas top-up transaction failed: %v", hash.Hex())
+		status = types.FAILED
+	}
+
+	err = s.gasTopUpDao.UpdateStatus(hash, status)
+	if err != nil {
+		logger.Error(err)
+	}
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
+	"github.com/tomochain/dex-server/interfaces"
+	"github.com/tomochain/dex-server/types"
+)
+
+// GovernanceService sends the administration transactions of the exchange smart contract
+// from the default admin wallet, records them in the admin transactions ledger and keeps
+// the operator flags of the wallets collection in sync with the contract
+type GovernanceService struct {
+	exchange            interfaces.Exchange
+	walletDao           interfaces.WalletDao
+	provider            interfaces.EthereumProvider
+	adminTransactionDao interfaces.AdminTransactionDao
+}
+
+// NewGovernanceService returns a new instance of GovernanceService
+func NewGovernanceService(
+	exchange interfaces.Exchange,
+	walletDao interfaces.WalletDao,
+	provider interfaces.EthereumProvider,
+	adminTransactionDao interfaces.AdminTransactionDao,
+) *GovernanceService {
+	return &GovernanceService{exchange, walletDao, provider, adminTransactionDao}
+}
+
+// GetExchangeInfo returns the governance parameters currently set on the exchange contract
+func (s *GovernanceService) GetExchangeInfo() (map[string]interface{}, error) {
+	owner, err := s.exchange.Owner()
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	feeAccount, err := s.exchange.FeeAccount()
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	weth, err := s.exchange.WethToken()
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	res := map[string]interface{}{
+		"exchangeAddress": s.exchange.GetAddress().Hex(),
+		"owner":           owner.Hex(),
+		"feeAccount":      feeAccount.Hex(),
+		"wethToken":       weth.Hex(),
+	}
+
+	return res, nil
+}
+
+// IsOperator returns true if the given address is an operator of the exchange contract
+func (s *GovernanceService) IsOperator(a common.Address) (bool, error) {
+	return s.exchange.Operator(a)
+}
+
+// SetFeeAccount sets the account receiving the trading fees
+func (s *GovernanceService) SetFeeAccount(a common.Address) (*types.AdminTransaction, error) {
+	params := map[string]string{"feeAccount": a.Hex()}
+
+	return s.send(types.SET_FEE_ACCOUNT, params, func(opts *bind.TransactOpts) (*eth.Transaction, error) {
+		return s.exchange.SetFeeAccount(a, opts)
+	})
+}
+
+// SetOperator grants or revokes the operator rights of a given address
+func (s *GovernanceService) SetOperator(a common.Address, isOperator bool) (*types.AdminTransaction, error) {
+	params := map[string]string{
+		"operator":   a.Hex(),
+		"isOperator": strconv.FormatBool(isOperator),
+	}
+
+	return s.send(types.SET_OPERATOR, params, func(opts *bind.TransactOpts) (*eth.Transaction, error) {
+		return s.exchange.SetOperator(a, isOperator, opts)
+	})
+}
+
+// SetOwner transfers the ownership of the exchange contract
+func (s *GovernanceService) SetOwner(a common.Address) (*types.AdminTransaction, error) {
+	params := map[string]string{"owner": a.Hex()}
+
+	return s.send(types.SET_OWNER, params, func(opts *bind.TransactOpts) (*eth.Transaction, error) {
+		return s.exchange.SetOwner(a, opts)
+	})
+}
+
+// SetWethToken sets the WETH token used by the exchange contract
+func (s *GovernanceService) SetWethToken(a common.Address) (*types.AdminTransaction, error) {
+	params := map[string]string{"wethToken": a.Hex()}
+
+	return s.send(types.SET_WETH_TOKEN, params, func(opts *bind.TransactOpts) (*eth.Transaction, error) {
+		return s.exchange.SetWethToken(a, opts)
+	})
+}
+
+// RegisterPair registers a token pair and its pricepoint multiplier on the exchange contract
+func (s *GovernanceService) RegisterPair(bt, qt common.Address, multiplier *big.Int) (*types.AdminTransaction, error) {
+	params := map[string]string{
+		"baseToken":            bt.Hex(),
+		"quoteToken":           qt.Hex(),
+		"pricepointMultiplier": multiplier.String(),
+	}
+
+	return s.send(types.REGISTER_PAIR, params, func(opts *bind.TransactOpts) (*eth.Transaction, error) {
+		return s.exchange.RegisterPair(bt, qt, multiplier, opts)
+	})
+}
+
+// GetTransactions returns the admin transactions ledger
+func (s *GovernanceService) GetTransactions(limit ...int) ([]*types.AdminTransaction, error) {
+	return s.adminTransactionDao.GetAll(limit...)
+}
+
+// GetTransactionByHash returns the admin transaction corresponding to a given hash
+func (s *GovernanceService) GetTransactionByHash(h common.Hash) (*types.AdminTransaction, error) {
+	return s.adminTransactionDao.GetByHash(h)
+}
+
+// SyncOperatorWallets aligns the operator flags of the wallets collection with the
+// exchange contract and then keeps them in sync with the LogOperatorUpdate events
+func (s *GovernanceService) SyncOperatorWallets() error {
+	wallets, err := s.walletDao.GetAll()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	for _, w := range wallets {
+		isOperator, err := s.exchange.Operator(w.Address)
+		if err != nil {
+			logger.Error(err)
+			continue
+		}
+
+		if isOperator != w.Operator {
+			s.updateOperatorWallet(w.Address, isOperator)
+		}
+	}
+
+	events, err := s.exchange.ListenToOperatorUpdates()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	go func() {
+		for ev := range events {
+			if ev.Raw.Removed {
+				continue
+			}
+
+			s.updateOperatorWallet(ev.Operator, ev.IsOperator)
+		}
+	}()
+
+	return nil
+}
+
+func (s *GovernanceService) updateOperatorWallet(a common.Address, isOperator bool) {
+	w, err := s.walletDao.GetByAddress(a)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	// addresses without a stored wallet can not be used to send trades
+	if w == nil {
+		logger.Warningf("Operator update received for unknown wallet %v", a.Hex())
+		return
+	}
+
+	err = s.walletDao.SetOperator(a, isOperator)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	logger.Infof("Wallet %v operator flag set to %v", a.Hex(), isOperator)
+}
+
+// send signs a governance transaction with the default admin wallet, records it as
+// pending and tracks it until it is mined
+func (s *GovernanceService) send(
+	method string,
+	params map[string]string,
+	fn func(opts *bind.TransactOpts) (*eth.Transaction, error),
+) (*types.AdminTransaction, error) {
+	admin, err := s.walletDao.GetDefaultAdminWallet()
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	opts, err := s.provider.NewTransactor(admin.PrivateKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	tx, err := fn(opts)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	t := &types.AdminTransaction{
+		Method: method,
+		Params: params,
+		Sender: admin.Address,
+		TxHash: tx.Hash(),
+		Status: types.PENDING,
+	}
+
+	err = s.adminTransactionDao.Create(t)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	logger.Infof("Sent %v transaction (tx: %v)", method, t.TxHash.Hex())
+
+	go s.handleTransactionReceipt(t.TxHash)
+	return t, nil
+}
+
+func (s *GovernanceService) handleTransactionReceipt(hash common.Hash) {
+	status := types.SUCCESS
+
+	receipt, err := s.provider.WaitMined(hash)
+	if err != nil || receipt.Status == 0 {
+		logger.Errorf("Admin transaction failed: %v", hash.Hex())
+		status = types.FAILED
+	}
+
+	err = s.adminTransactionDao.UpdateStatus(hash, status)
+	if err != nil {
+		logger.Error(err)
+	}
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/tomochain/dex-server/contracts/contractsinterfaces"
+	"github.com/tomochain/dex-server/errors"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/testutils"
+	"github.com/tomochain/dex-server/utils/testutils/mocks"
+)
+
+func SetupGovernanceServiceTest() (*GovernanceService, *mocks.Exchange, *mocks.WalletDao, *mocks.EthereumProvider, *mocks.AdminTransactionDao) {
+	exchange := new(mocks.Exchange)
+	walletDao := new(mocks.WalletDao)
+	provider := new(mocks.EthereumProvider)
+	adminTransactionDao := new(mocks.AdminTransactionDao)
+
+	s := NewGovernanceService(exchange, walletDao, provider, adminTransactionDao)
+	return s, exchange, walletDao, provider, adminTransactionDao
+}
+
+func TestGovernanceSetOperator(t *testing.T) {
+	s, exchange, walletDao, provider, adminTransactionDao := SetupGovernanceServiceTest()
+
+	admin := testutils.GetTestWallet1()
+	operator := common.HexToAddress("0x1")
+	opts := &bind.TransactOpts{From: admin.Address}
+	tx := eth.NewTransaction(1, common.HexToAddress("0x2"), nil, 0, nil, nil)
+
+	walletDao.On("GetDefaultAdminWallet").Return(admin, nil)
+	provider.On("NewTransactor", admin.PrivateKey).Return(opts, nil)
+	provider.On("WaitMined", tx.Hash()).Return(&eth.Receipt{Status: 1}, nil)
+	exchange.On("SetOperator", operator, true, opts).Return(tx, nil)
+	adminTransactionDao.On("Create", mock.Anything).Return(nil)
+	adminTransactionDao.On("UpdateStatus", tx.Hash(), types.SUCCESS).Return(nil)
+
+	res, err := s.SetOperator(operator, true)
+	if err != nil {
+		t.Fatalf("Could not set operator: %v", err)
+	}
+
+	if res.Method != types.SET_OPERATOR || res.Sender != admin.Address || res.TxHash != tx.Hash() || res.Status != types.PENDING {
+		t.Errorf("Unexpected admin transaction %v", res)
+	}
+
+	if res.Params["operator"] != operator.Hex() || res.Params["isOperator"] != "true" {
+		t.Errorf("Unexpected admin transaction params %v", res.Params)
+	}
+
+	// the transaction is signed with the transactor of the provider, for the chain ID
+	exchange.AssertCalled(t, "SetOperator", operator, true, opts)
+	adminTransactionDao.AssertCalled(t, "Create", res)
+}
+
+func TestGovernanceSendWithoutChainID(t *testing.T) {
+	s, exchange, walletDao, provider, adminTransactionDao := SetupGovernanceServiceTest()
+
+	admin := testutils.GetTestWallet1()
+	walletDao.On("GetDefaultAdminWallet").Return(admin, nil)
+	provider.On("NewTransactor", admin.PrivateKey).Return(nil, errors.New("Chain ID configuration not found"))
+
+	_, err := s.SetFeeAccount(common.HexToAddress("0x1"))
+	if err == nil {
+		t.Error("Expected an error without chain ID")
+	}
+
+	exchange.AssertNotCalled(t, "SetFeeAccount", mock.Anything, mock.Anything)
+	adminTransactionDao.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestSyncOperatorWallets(t *testing.T) {
+	s, exchange, walletDao, _, _ := SetupGovernanceServiceTest()
+
+	w1 := types.Wallet{Address: common.HexToAddress("0x1"), Operator: false}
+	w2 := types.Wallet{Address: common.HexToAddress("0x2"), Operator: true}
+	events := make(chan *contractsinterfaces.ExchangeLogOperatorUpdate)
+	close(events)
+
+	walletDao.On("GetAll").Return([]types.Wallet{w1, w2}, nil)
+	walletDao.On("GetByAddress", w1.Address).Return(&w1, nil)
+	walletDao.On("SetOperator", w1.Address, true).Return(nil)
+	exchange.On("Operator", w1.Address).Return(true, nil)
+	exchange.On("Operator", w2.Address).Return(true, nil)
+	exchange.On("ListenToOperatorUpdates").Return(events, nil)
+
+	err := s.SyncOperatorWallets()
+	if err != nil {
+		t.Fatalf("Could not sync operator wallets: %v", err)
+	}
+
+	walletDao.AssertCalled(t, "SetOperator", w1.Address, true)
+	walletDao.AssertNotCalled(t, "SetOperator", w2.Address, mock.Anything)
+}
@@ -0,0 +1,299 @@
+package services
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/interfaces"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/math"
+)
+
+// LockedBalanceService maintains the locked balances of the accounts. The amount of sell token
+// locked by each open order is stored in the order_locks collection, and the difference with
+// the previous lock of an order is applied to the locked balance of its maker each time the
+// order is updated
+type LockedBalanceService struct {
+	orderDao     interfaces.OrderDao
+	pairDao      interfaces.PairDao
+	accountDao   interfaces.AccountDao
+	orderLockDao interfaces.OrderLockDao
+	mutex        *sync.Mutex
+}
+
+// NewLockedBalanceService returns a new instance of LockedBalanceService
+func NewLockedBalanceService(
+	orderDao interfaces.OrderDao,
+	pairDao interfaces.PairDao,
+	accountDao interfaces.AccountDao,
+	orderLockDao interfaces.OrderLockDao,
+) *LockedBalanceService {
+	return &LockedBalanceService{orderDao, pairDao, accountDao, orderLockDao, &sync.Mutex{}}
+}
+
+// UpdateOrders updates the locked balances after orders were added, filled, cancelled or
+// invalidated. The orders are read from the db since the engine responses do not always
+// carry their latest state
+func (s *LockedBalanceService) UpdateOrders(hashes ...common.Hash) {
+	if len(hashes) == 0 {
+		return
+	}
+
+	updated, err := s.orderDao.GetByHashes(hashes)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	pairs := make(map[string]*types.Pair)
+	for _, o := range updated {
+		p, err := s.getPair(pairs, o)
+		if err != nil {
+			logger.Error(err)
+			continue
+		}
+
+		err = s.updateOrder(o, p)
+		if err != nil {
+			logger.Error(err)
+		}
+	}
+}
+
+// GetLockedBalance returns the amount of a token locked by the open orders of an account
+func (s *LockedBalanceService) GetLockedBalance(owner, token common.Address) (*big.Int, error) {
+	balances, err := s.accountDao.GetTokenBalances(owner)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if tb := balances[token]; tb != nil && tb.LockedBalance != nil {
+		return tb.LockedBalance, nil
+	}
+
+	return big.NewInt(0), nil
+}
+
+// Recompute computes the locked balances from all the open orders and compares them with the
+// incremental ones. The mismatches are logged, and the locked balances and order locks are
+// replaced with the recomputed values
+func (s *LockedBalanceService) Recompute() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	orders, err := s.orderDao.GetOpenOrders()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	locks := make(map[common.Hash]*types.OrderLock)
+	totals := make(map[common.Address]map[common.Address]*big.Int)
+	pairs := make(map[string]*types.Pair)
+
+	for _, o := range orders {
+		p, err := s.getPair(pairs, o)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		l := &types.OrderLock{
+			OrderHash:   o.Hash,
+			UserAddress: o.UserAddress,
+			Token:       o.SellToken(),
+			Amount:      orderLockAmount(o, p),
+		}
+
+		locks[o.Hash] = l
+
+		if totals[l.UserAddress] == nil {
+			totals[l.UserAddress] = make(map[common.Address]*big.Int)
+		}
+
+		total := totals[l.UserAddress][l.Token]
+		if total == nil {
+			total = big.NewInt(0)
+		}
+
+		totals[l.UserAddress][l.Token] = math.Add(total, l.Amount)
+	}
+
+	accounts, err := s.accountDao.GetAll()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	mismatches := 0
+	for _, a := range accounts {
+		tokens := make(map[common.Address]bool)
+		for token := range a.TokenBalances {
+			tokens[token] = true
+		}
+
+		for token := range totals[a.Address] {
+			tokens[token] = true
+		}
+
+		for token := range tokens {
+			current := big.NewInt(0)
+			if tb := a.TokenBalances[token]; tb != nil && tb.LockedBalance != nil {
+				current = tb.LockedBalance
+			}
+
+			expected := totals[a.Address][token]
+			if expected == nil {
+				expected = big.NewInt(0)
+			}
+
+			if current.Cmp(expected) == 0 {
+				continue
+			}
+
+			mismatches++
+			logger.Warningf(
+				"Locked balance mismatch for %v (%v): %v maintained, %v recomputed",
+				a.Address.Hex(),
+				token.Hex(),
+				current,
+				expected,
+			)
+
+			err := s.accountDao.UpdateLockedBalance(a.Address, token, expected)
+			if err != nil {
+				logger.Error(err)
+				continue
+			}
+
+			sendTokenBalance(s.accountDao, a.Address, token)
+		}
+	}
+
+	stored, err := s.orderLockDao.GetAll()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	for _, l := range stored {
+		expected := locks[l.OrderHash]
+		if expected != nil && expected.Amount.Cmp(l.Amount) == 0 {
+			delete(locks, l.OrderHash)
+			continue
+		}
+
+		if expected == nil {
+			err := s.orderLockDao.DeleteByHash(l.OrderHash)
+			if err != nil {
+				logger.Error(err)
+			}
+		}
+	}
+
+	for _, l := range locks {
+		if l.Amount.Sign() == 0 {
+			continue
+		}
+
+		err := s.orderLockDao.Upsert(l)
+		if err != nil {
+			logger.Error(err)
+		}
+	}
+
+	logger.Infof("Locked balance recomputation: %v open orders checked, %v mismatches found", len(orders), mismatches)
+	return nil
+}
+
+// updateOrder applies the difference between the current and the stored lock of an order to the
+// locked balance of its maker
+func (s *LockedBalanceService) updateOrder(o *types.Order, p *types.Pair) error {
+	amount := orderLockAmount(o, p)
+
+	previous := big.NewInt(0)
+	l, err := s.orderLockDao.GetByHash(o.Hash)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if l != nil && l.Amount != nil {
+		previous = l.Amount
+	}
+
+	delta := math.Sub(amount, previous)
+	if delta.Sign() == 0 {
+		return nil
+	}
+
+	token := o.SellToken()
+	locked, err := s.GetLockedBalance(o.UserAddress, token)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	locked = math.Add(locked, delta)
+	if locked.Sign() < 0 {
+		locked = big.NewInt(0)
+	}
+
+	err = s.accountDao.UpdateLockedBalance(o.UserAddress, token, locked)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	sendTokenBalance(s.accountDao, o.UserAddress, token)
+
+	if amount.Sign() == 0 {
+		return s.orderLockDao.DeleteByHash(o.Hash)
+	}
+
+	return s.orderLockDao.Upsert(&types.OrderLock{
+		OrderHash:   o.Hash,
+		UserAddress: o.UserAddress,
+		Token:       token,
+		Amount:      amount,
+	})
+}
+
+func (s *LockedBalanceService) getPair(pairs map[string]*types.Pair, o *types.Order) (*types.Pair, error) {
+	key := o.BaseToken.Hex() + o.QuoteToken.Hex()
+	if p := pairs[key]; p != nil {
+		return p, nil
+	}
+
+	p, err := s.pairDao.GetByTokenAddress(o.BaseToken, o.QuoteToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if p == nil {
+		return nil, ErrPairNotFound
+	}
+
+	pairs[key] = p
+	return p, nil
+}
+
+// orderLockAmount returns the amount of sell token locked by an order, which is the remaining
+// sell amount of the open and partially filled orders
+func orderLockAmount(o *types.Order, p *types.Pair) *big.Int {
+	if o.Status != "OPEN" && o.Status != "PARTIAL_FILLED" {
+		return big.NewInt(0)
+	}
+
+	amount := o.RemainingSellAmount(p)
+	if amount.Sign() < 0 {
+		return big.NewInt(0)
+	}
+
+	return amount
+}
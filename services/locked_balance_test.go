@@ -0,0 +1,170 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/testutils/mocks"
+)
+
+var (
+	testLockOwner      = common.HexToAddress("0x1")
+	testLockBaseToken  = common.HexToAddress("0x2")
+	testLockQuoteToken = common.HexToAddress("0x3")
+)
+
+func SetupLockedBalanceServiceTest() (
+	*mocks.OrderDao,
+	*mocks.PairDao,
+	*mocks.AccountDao,
+	*mocks.OrderLockDao,
+	*LockedBalanceService,
+) {
+	orderDao := new(mocks.OrderDao)
+	pairDao := new(mocks.PairDao)
+	accountDao := new(mocks.AccountDao)
+	orderLockDao := new(mocks.OrderLockDao)
+
+	pair := &types.Pair{
+		BaseTokenAddress:  testLockBaseToken,
+		QuoteTokenAddress: testLockQuoteToken,
+		BaseTokenDecimals: 18,
+	}
+
+	pairDao.On("GetByTokenAddress", testLockBaseToken, testLockQuoteToken).Return(pair, nil)
+
+	s := NewLockedBalanceService(orderDao, pairDao, accountDao, orderLockDao)
+	return orderDao, pairDao, accountDao, orderLockDao, s
+}
+
+func newTestLockOrder(hash string, owner common.Address, status string, amount, filled int64) *types.Order {
+	return &types.Order{
+		Hash:         common.HexToHash(hash),
+		UserAddress:  owner,
+		BaseToken:    testLockBaseToken,
+		QuoteToken:   testLockQuoteToken,
+		Side:         types.SELL,
+		Status:       status,
+		Amount:       big.NewInt(amount),
+		FilledAmount: big.NewInt(filled),
+	}
+}
+
+func bigIntArg(v int64) interface{} {
+	return mock.MatchedBy(func(b *big.Int) bool {
+		return b != nil && b.Cmp(big.NewInt(v)) == 0
+	})
+}
+
+func TestUpdateOrdersLockedBalance(t *testing.T) {
+	tests := []struct {
+		name     string
+		order    *types.Order
+		previous int64
+		locked   int64
+		expected int64
+		lock     int64
+	}{
+		{"add", newTestLockOrder("0x1", testLockOwner, "OPEN", 100, 0), 0, 50, 150, 100},
+		{"partial fill", newTestLockOrder("0x1", testLockOwner, "PARTIAL_FILLED", 100, 40), 100, 150, 90, 60},
+		{"cancel", newTestLockOrder("0x1", testLockOwner, "CANCELLED", 100, 40), 60, 90, 30, 0},
+		{"invalidate", newTestLockOrder("0x1", testLockOwner, "INVALIDATED", 100, 40), 60, 40, 0, 0},
+	}
+
+	for _, test := range tests {
+		orderDao, _, accountDao, orderLockDao, s := SetupLockedBalanceServiceTest()
+		o := test.order
+
+		orderDao.On("GetByHashes", []common.Hash{o.Hash}).Return([]*types.Order{o}, nil)
+
+		var previous *types.OrderLock
+		if test.previous != 0 {
+			previous = &types.OrderLock{OrderHash: o.Hash, UserAddress: o.UserAddress, Token: testLockBaseToken, Amount: big.NewInt(test.previous)}
+		}
+
+		orderLockDao.On("GetByHash", o.Hash).Return(previous, nil)
+		accountDao.On("GetTokenBalances", testLockOwner).Return(map[common.Address]*types.TokenBalance{
+			testLockBaseToken: {LockedBalance: big.NewInt(test.locked)},
+		}, nil)
+		accountDao.On("UpdateLockedBalance", testLockOwner, testLockBaseToken, bigIntArg(test.expected)).Return(nil)
+
+		if test.lock == 0 {
+			orderLockDao.On("DeleteByHash", o.Hash).Return(nil)
+		} else {
+			orderLockDao.On("Upsert", mock.MatchedBy(func(l *types.OrderLock) bool {
+				return l.OrderHash == o.Hash && l.Token == testLockBaseToken && l.Amount.Cmp(big.NewInt(test.lock)) == 0
+			})).Return(nil)
+		}
+
+		s.UpdateOrders(o.Hash)
+
+		accountDao.AssertExpectations(t)
+		orderLockDao.AssertExpectations(t)
+
+		if test.lock == 0 {
+			orderLockDao.AssertNotCalled(t, "Upsert", mock.Anything)
+		} else {
+			orderLockDao.AssertNotCalled(t, "DeleteByHash", mock.Anything)
+		}
+	}
+}
+
+func TestUpdateOrdersUnchangedLock(t *testing.T) {
+	orderDao, _, accountDao, orderLockDao, s := SetupLockedBalanceServiceTest()
+	o := newTestLockOrder("0x1", testLockOwner, "OPEN", 100, 0)
+
+	orderDao.On("GetByHashes", []common.Hash{o.Hash}).Return([]*types.Order{o}, nil)
+	orderLockDao.On("GetByHash", o.Hash).Return(&types.OrderLock{OrderHash: o.Hash, Amount: big.NewInt(100)}, nil)
+
+	s.UpdateOrders(o.Hash)
+
+	accountDao.AssertNotCalled(t, "UpdateLockedBalance", mock.Anything, mock.Anything, mock.Anything)
+	orderLockDao.AssertNotCalled(t, "Upsert", mock.Anything)
+	orderLockDao.AssertNotCalled(t, "DeleteByHash", mock.Anything)
+}
+
+func TestRecomputeLockedBalances(t *testing.T) {
+	orderDao, _, accountDao, orderLockDao, s := SetupLockedBalanceServiceTest()
+
+	other := common.HexToAddress("0x4")
+	open := newTestLockOrder("0x1", testLockOwner, "OPEN", 100, 0)
+	partial := newTestLockOrder("0x2", testLockOwner, "PARTIAL_FILLED", 50, 20)
+
+	orderDao.On("GetOpenOrders").Return([]*types.Order{open, partial}, nil)
+	accountDao.On("GetAll").Return([]types.Account{
+		{
+			Address:       testLockOwner,
+			TokenBalances: map[common.Address]*types.TokenBalance{testLockBaseToken: {LockedBalance: big.NewInt(100)}},
+		},
+		{
+			Address:       other,
+			TokenBalances: map[common.Address]*types.TokenBalance{testLockBaseToken: {LockedBalance: big.NewInt(10)}},
+		},
+	}, nil)
+
+	accountDao.On("UpdateLockedBalance", testLockOwner, testLockBaseToken, bigIntArg(130)).Return(nil)
+	accountDao.On("UpdateLockedBalance", other, testLockBaseToken, bigIntArg(0)).Return(nil)
+
+	stale := common.HexToHash("0x3")
+	orderLockDao.On("GetAll").Return([]*types.OrderLock{
+		{OrderHash: open.Hash, UserAddress: testLockOwner, Token: testLockBaseToken, Amount: big.NewInt(100)},
+		{OrderHash: stale, UserAddress: testLockOwner, Token: testLockBaseToken, Amount: big.NewInt(10)},
+	}, nil)
+
+	orderLockDao.On("DeleteByHash", stale).Return(nil)
+	orderLockDao.On("Upsert", mock.MatchedBy(func(l *types.OrderLock) bool {
+		return l.OrderHash == partial.Hash && l.Amount.Cmp(big.NewInt(30)) == 0
+	})).Return(nil)
+
+	err := s.Recompute()
+	if err != nil {
+		t.Fatalf("Could not recompute the locked balances: %v", err)
+	}
+
+	accountDao.AssertExpectations(t)
+	orderLockDao.AssertExpectations(t)
+	orderLockDao.AssertNumberOfCalls(t, "Upsert", 1)
+}
@@ -18,3 +18,15 @@ var ErrTokenExists = errors.New("Token already exists")
 var ErrAccountNotFound = errors.New("Account not found")
 var ErrAccountExists = errors.New("Account already Exists")
 var ErrNoContractCode = errors.New("Contract not found at given address")
+var ErrPricepointMultiplierMismatch = errors.New("Pair pricepoint multiplier does not match the exchange contract")
+var ErrReferralExists = errors.New("Account was already referred")
+var ErrReferralCycle = errors.New("Referrer was referred by the referee")
+var ErrInvalidSignature = errors.New("Invalid Signature")
+var ErrInvalidAuthChallenge = errors.New("Login challenge not found or expired")
+var ErrInvalidAuthToken = errors.New("Invalid token")
+var ErrInvalidAPIKey = errors.New("Invalid API key")
+var ErrInvalidAPIKeyTimestamp = errors.New("API request timestamp is invalid or expired")
+var ErrAPIKeyIPNotAllowed = errors.New("IP address not allowed for this API key")
+var ErrAPIKeySignatureReused = errors.New("API request signature was already used")
+var ErrAPIKeyNotFound = errors.New("API key not found")
+var ErrTooManyAPIKeys = errors.New("Maximum number of API keys reached")
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"math/big"
+	"sync"
+	"time"
 
 	"github.com/tomochain/dex-server/errors"
 
@@ -22,14 +24,27 @@ import (
 // OrderService
 type OrderService struct {
 	// tokenDao      interfaces.TokenDao
-	orderDao      interfaces.OrderDao
-	pairDao       interfaces.PairDao
-	accountDao    interfaces.AccountDao
-	tradeDao      interfaces.TradeDao
-	engine        interfaces.Engine
-	validator     interfaces.ValidatorService
-	broker        *rabbitmq.Connection
-	orderChannels map[string]chan *types.WebsocketEvent
+	orderDao       interfaces.OrderDao
+	pairDao        interfaces.PairDao
+	accountDao     interfaces.AccountDao
+	tradeDao       interfaces.TradeDao
+	nonceDao       interfaces.AccountNonceDao
+	orderEventDao  interfaces.OrderEventDao
+	engine         interfaces.Engine
+	validator      interfaces.ValidatorService
+	lockedBalances interfaces.LockedBalanceService
+	fees           interfaces.FeeService
+	referrals      interfaces.ReferralService
+	broker         *rabbitmq.Connection
+	orderChannels  map[string]chan *types.WebsocketEvent
+	waiters        *engineResponseWaiters
+}
+
+// engineResponseWaiters holds the channels of the requests waiting for the next engine
+// response about an order
+type engineResponseWaiters struct {
+	sync.Mutex
+	channels map[common.Hash][]chan *types.EngineResponse
 }
 
 // NewOrderService returns a new instance of orderservice
@@ -39,8 +54,13 @@ func NewOrderService(
 	pairDao interfaces.PairDao,
 	accountDao interfaces.AccountDao,
 	tradeDao interfaces.TradeDao,
+	nonceDao interfaces.AccountNonceDao,
+	orderEventDao interfaces.OrderEventDao,
 	engine interfaces.Engine,
 	validator interfaces.ValidatorService,
+	lockedBalances interfaces.LockedBalanceService,
+	fees interfaces.FeeService,
+	referrals interfaces.ReferralService,
 	broker *rabbitmq.Connection,
 ) *OrderService {
 
@@ -52,10 +72,16 @@ func NewOrderService(
 		pairDao,
 		accountDao,
 		tradeDao,
+		nonceDao,
+		orderEventDao,
 		engine,
 		validator,
+		lockedBalances,
+		fees,
+		referrals,
 		broker,
 		orderChannels,
+		&engineResponseWaiters{channels: make(map[common.Hash][]chan *types.EngineResponse)},
 	}
 }
 
@@ -116,6 +142,17 @@ func (s *OrderService) GetHistoryByUserAddress(addr common.Address, limit ...int
 	return s.orderDao.GetHistoryByUserAddress(addr, limit...)
 }
 
+// GetPage returns a page of orders matching a history filter and the cursor of the next page
+func (s *OrderService) GetPage(f *types.HistoryFilter) ([]*types.Order, *types.Cursor, error) {
+	return s.orderDao.GetPage(f)
+}
+
+// GetHistoryPage returns a page of the orders matching a history filter that are not open
+// anymore and the cursor of the next page
+func (s *OrderService) GetHistoryPage(f *types.HistoryFilter) ([]*types.Order, *types.Cursor, error) {
+	return s.orderDao.GetHistoryPage(f)
+}
+
 // NewOrder validates if the passed order is valid or not based on user's available
 // funds and order data.
 // If valid: Order is inserted in DB with order status as new and order is publiched
@@ -135,6 +172,32 @@ func (s *OrderService) NewOrder(o *types.Order) error {
 		return errors.New("Invalid Signature")
 	}
 
+	err = s.checkAccountRestriction(o.UserAddress, types.ACCOUNT_ACTION_TRADE)
+	if err != nil {
+		return err
+	}
+
+	// a cancelled or filled order can not be submitted again
+	existing, err := s.orderDao.GetByHash(o.Hash)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if existing != nil {
+		return errors.New("Order already exists")
+	}
+
+	minNonce, err := s.nonceDao.GetMinNonce(o.UserAddress)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if math.IsStrictlySmallerThan(o.Nonce, minNonce) {
+		return errors.New("Order nonce is lower than the minimum valid nonce")
+	}
+
 	p, err := s.pairDao.GetByTokenAddress(o.BaseToken, o.QuoteToken)
 	if err != nil {
 		logger.Error(err)
@@ -145,6 +208,10 @@ func (s *OrderService) NewOrder(o *types.Order) error {
 		return errors.New("Pair not found")
 	}
 
+	if !p.Active {
+		return errors.New("Pair is not active")
+	}
+
 	if math.IsStrictlySmallerThan(o.QuoteAmount(p), p.MinQuoteAmount()) {
 		return errors.New("Order amount too low")
 	}
@@ -156,6 +223,18 @@ func (s *OrderService) NewOrder(o *types.Order) error {
 		return err
 	}
 
+	fee, err := s.fees.ResolveFees(o.UserAddress, p)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	err = fee.Check(o)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
 	err = s.validator.ValidateAvailableBalance(o)
 	if err != nil {
 		logger.Error(err)
@@ -171,9 +250,87 @@ func (s *OrderService) NewOrder(o *types.Order) error {
 	return nil
 }
 
+// NewOrderAndWait submits a new order and waits for the first response of the engine, which
+// reports whether the order was added to the orderbook, filled or partially filled. A nil
+// response is returned if the engine did not respond before the timeout
+func (s *OrderService) NewOrderAndWait(o *types.Order, timeout time.Duration) (*types.EngineResponse, error) {
+	return s.waitEngineResponse(o.Hash, timeout, func() error {
+		return s.NewOrder(o)
+	})
+}
+
+// CancelOrderAndWait requests the cancellation of an order and waits for the response of the
+// engine. A nil response is returned if the engine did not respond before the timeout, which
+// is the case of hard cancels of orders that were already cancelled
+func (s *OrderService) CancelOrderAndWait(oc *types.OrderCancel, timeout time.Duration) (*types.EngineResponse, error) {
+	return s.waitEngineResponse(oc.OrderHash, timeout, func() error {
+		return s.CancelOrder(oc)
+	})
+}
+
+// waitEngineResponse registers a waiter for the next engine response about an order before
+// submitting the request, so that a response received before the request returns is not missed
+func (s *OrderService) waitEngineResponse(h common.Hash, timeout time.Duration, submit func() error) (*types.EngineResponse, error) {
+	ch := make(chan *types.EngineResponse, 1)
+
+	s.waiters.Lock()
+	s.waiters.channels[h] = append(s.waiters.channels[h], ch)
+	s.waiters.Unlock()
+
+	defer func() {
+		s.waiters.Lock()
+		defer s.waiters.Unlock()
+
+		channels := s.waiters.channels[h]
+		for i, c := range channels {
+			if c == ch {
+				channels = append(channels[:i], channels[i+1:]...)
+				break
+			}
+		}
+
+		if len(channels) == 0 {
+			delete(s.waiters.channels, h)
+		} else {
+			s.waiters.channels[h] = channels
+		}
+	}()
+
+	err := submit()
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case res := <-ch:
+		return res, nil
+	case <-time.After(timeout):
+		return nil, nil
+	}
+}
+
+// notifyEngineResponse sends an engine response to the requests waiting for it
+func (s *OrderService) notifyEngineResponse(res *types.EngineResponse) {
+	if res.Order == nil {
+		return
+	}
+
+	s.waiters.Lock()
+	defer s.waiters.Unlock()
+
+	for _, ch := range s.waiters.channels[res.Order.Hash] {
+		select {
+		case ch <- res:
+		default:
+		}
+	}
+}
+
 // CancelOrder handles the cancellation order requests.
 // Only Orders which are OPEN or NEW i.e. Not yet filled/partially filled
-// can be cancelled
+// can be cancelled. The request must be signed by the maker of the order over the hash of
+// the cancellation. A hard cancel also cancels the order on the exchange contract,
+// which can be requested for orders that were already cancelled off-chain
 func (s *OrderService) CancelOrder(oc *types.OrderCancel) error {
 	o, err := s.orderDao.GetByHash(oc.OrderHash)
 	if err != nil {
@@ -185,6 +342,26 @@ func (s *OrderService) CancelOrder(oc *types.OrderCancel) error {
 		return errors.New("No order with corresponding hash")
 	}
 
+	// the hash is checked so that another message signed by the maker, like the order
+	// itself, can not be replayed as a cancellation
+	if oc.Hash != oc.ComputeHash() {
+		return errors.New("Invalid Hash")
+	}
+
+	ok, err := oc.VerifySignature(o)
+	if err != nil || !ok {
+		return errors.New("Invalid Signature")
+	}
+
+	err = s.checkAccountRestriction(o.UserAddress, types.ACCOUNT_ACTION_CANCEL)
+	if err != nil {
+		return err
+	}
+
+	if oc.HardCancel {
+		return s.hardCancelOrder(o)
+	}
+
 	if o.Status == types.FILLED || o.Status == types.ERROR_STATUS || o.Status == types.CANCELLED {
 		return fmt.Errorf("Cannot cancel order. Status is %v", o.Status)
 	}
@@ -198,9 +375,154 @@ func (s *OrderService) CancelOrder(oc *types.OrderCancel) error {
 	return nil
 }
 
+// GetMinNonce returns the minimum valid nonce of an account
+func (s *OrderService) GetMinNonce(addr common.Address) (*big.Int, error) {
+	return s.nonceDao.GetMinNonce(addr)
+}
+
+// SetMinNonce sets the minimum valid nonce of an account from a signed message and cancels all
+// the open orders of the account with a lower nonce. The minimum valid nonce can only be raised.
+// It returns the orders for which a cancellation was requested
+func (s *OrderService) SetMinNonce(m *types.MinNonce) ([]*types.Order, error) {
+	if err := m.Validate(); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	ok, err := m.VerifySignature()
+	if err != nil {
+		logger.Error(err)
+	}
+
+	if !ok {
+		return nil, errors.New("Invalid Signature")
+	}
+
+	err = s.checkAccountRestriction(m.UserAddress, types.ACCOUNT_ACTION_CANCEL)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.nonceDao.GetMinNonce(m.UserAddress)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if math.IsEqualOrSmallerThan(m.Nonce, current) {
+		return nil, fmt.Errorf("Nonce should be greater than the current minimum valid nonce (%v)", current)
+	}
+
+	err = s.nonceDao.SetMinNonce(m.UserAddress, m.Nonce)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	orders, err := s.orderDao.GetCurrentByUserAddress(m.UserAddress)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	cancelled := []*types.Order{}
+	for _, o := range orders {
+		if !math.IsStrictlySmallerThan(o.Nonce, m.Nonce) {
+			continue
+		}
+
+		err := s.broker.PublishCancelOrderMessage(o)
+		if err != nil {
+			logger.Error(err)
+			continue
+		}
+
+		cancelled = append(cancelled, o)
+	}
+
+	return cancelled, nil
+}
+
+// checkAccountRestriction returns an error if the restriction level of an account does not allow an action
+func (s *OrderService) checkAccountRestriction(addr common.Address, action string) error {
+	acc, err := s.accountDao.GetByAddress(addr)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return acc.CheckRestriction(action)
+}
+
+// hardCancelOrder removes the order from the orderbook and queues its cancellation on the
+// exchange contract. The cancel transaction is sent from an operator wallet, so the cancel
+// request must have been checked to be signed by the maker of the order
+func (s *OrderService) hardCancelOrder(o *types.Order) error {
+	if o.Status == types.FILLED || o.Status == types.ERROR_STATUS {
+		return fmt.Errorf("Cannot cancel order. Status is %v", o.Status)
+	}
+
+	if o.Status != types.CANCELLED {
+		err := s.broker.PublishCancelOrderMessage(o)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+	}
+
+	err := s.broker.PublishHardCancelMessage(o)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateFilledAmount sets the filled amount of an order and its status accordingly, and sends
+// the corrected order to the engine, which updates the orderbook. It is used to correct orders
+// that drifted from the exchange contract state
+func (s *OrderService) UpdateFilledAmount(o *types.Order, filledAmount *big.Int) error {
+	switch {
+	case math.IsEqualOrSmallerThan(filledAmount, big.NewInt(0)):
+		o.FilledAmount = big.NewInt(0)
+		o.Status = "OPEN"
+	case math.IsEqualOrGreaterThan(filledAmount, o.Amount):
+		o.FilledAmount = o.Amount
+		o.Status = types.FILLED
+	default:
+		o.FilledAmount = filledAmount
+		o.Status = "PARTIAL_FILLED"
+	}
+
+	err := s.broker.PublishCorrectOrderMessage(o)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetEvents returns the lifecycle events of an order from the oldest one
+func (s *OrderService) GetEvents(h common.Hash) ([]*types.OrderEvent, error) {
+	return s.orderEventDao.GetByOrderHash(h)
+}
+
+// recordOrderEvents adds events to the order timelines. The timelines are informational,
+// so a failure is logged and does not interrupt the handling of the message
+func (s *OrderService) recordOrderEvents(events ...*types.OrderEvent) {
+	err := s.orderEventDao.Create(events...)
+	if err != nil {
+		logger.Error(err)
+	}
+}
+
 // HandleEngineResponse listens to messages incoming from the engine and handles websocket
 // responses and database updates accordingly
 func (s *OrderService) HandleEngineResponse(res *types.EngineResponse) error {
+	defer s.notifyEngineResponse(res)
+
 	switch res.Status {
 	case types.ORDER_ADDED:
 		s.handleEngineOrderAdded(res)
@@ -210,6 +532,8 @@ func (s *OrderService) HandleEngineResponse(res *types.EngineResponse) error {
 		s.handleEngineOrderMatched(res)
 	case types.ORDER_CANCELLED:
 		s.handleOrderCancelled(res)
+	case types.ORDER_CORRECTED:
+		s.handleOrderCorrected(res)
 	case types.TRADES_CANCELLED:
 		s.handleOrdersInvalidated(res)
 	case types.ERROR_STATUS:
@@ -225,10 +549,41 @@ func (s *OrderService) HandleEngineResponse(res *types.EngineResponse) error {
 // to the orderbook (but currently not matched)
 func (s *OrderService) handleEngineOrderAdded(res *types.EngineResponse) {
 	o := res.Order
+	s.lockedBalances.UpdateOrders(o.Hash)
+
+	previous, err := s.orderEventDao.GetByOrderHash(o.Hash)
+	if err != nil {
+		logger.Error(err)
+	}
+
+	s.recordOrderEvents(types.NewOrderEvent(o, types.AddedEventType(previous)))
+
 	ws.SendOrderMessage("ORDER_ADDED", o.UserAddress, o)
 
 	s.broadcastOrderBookUpdate([]*types.Order{o})
 	s.broadcastRawOrderBookUpdate([]*types.Order{o})
+
+	s.cancelIfBelowMinNonce(o)
+}
+
+// cancelIfBelowMinNonce cancels an order added to the orderbook with a nonce lower than the
+// minimum valid nonce of its account. The order was accepted before the minimum was raised, but
+// was not stored yet when SetMinNonce cancelled the open orders of the account
+func (s *OrderService) cancelIfBelowMinNonce(o *types.Order) {
+	minNonce, err := s.nonceDao.GetMinNonce(o.UserAddress)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	if !math.IsStrictlySmallerThan(o.Nonce, minNonce) {
+		return
+	}
+
+	err = s.broker.PublishCancelOrderMessage(o)
+	if err != nil {
+		logger.Error(err)
+	}
 }
 
 // handleEngineOrderMatched returns a websocket message informing the client that his order has been added.
@@ -238,15 +593,67 @@ func (s *OrderService) handleEngineOrderMatched(res *types.EngineResponse) {
 	taker := o.UserAddress
 	matches := *res.Matches
 
+	// the engine has already updated the filled amounts of the taker and maker orders
+	hashes := []common.Hash{o.Hash}
+	for _, mo := range matches.MakerOrders {
+		hashes = append(hashes, mo.Hash)
+	}
+
+	s.lockedBalances.UpdateOrders(hashes...)
+
+	takerEvent := types.ORDER_EVENT_PARTIALLY_FILLED
+	if res.Status == types.ORDER_FILLED {
+		takerEvent = types.ORDER_EVENT_FILLED
+	}
+
+	events := []*types.OrderEvent{types.NewOrderEvent(o, takerEvent)}
+	for i, mo := range matches.MakerOrders {
+		e := types.NewOrderEvent(mo, types.ORDER_EVENT_PARTIALLY_FILLED)
+		if mo.Status == types.FILLED {
+			e.Type = types.ORDER_EVENT_FILLED
+		}
+
+		if i < len(matches.Trades) {
+			e.TradeHash = matches.Trades[i].Hash
+		}
+
+		events = append(events, e)
+	}
+
+	s.recordOrderEvents(events...)
+
 	orders := []*types.Order{o}
 	validMatches := types.Matches{TakerOrder: o}
 	invalidMatches := types.Matches{TakerOrder: o}
 
+	// the taker order was validated when it was received, so a taker failing the settlement
+	// pre-flight checks invalidates all its matches and the maker orders are put back in the orderbook
+	matchedAmount := big.NewInt(0)
+	for _, t := range matches.Trades {
+		matchedAmount = math.Add(matchedAmount, t.Amount)
+	}
+
+	err := s.validator.ValidateTakerSettlement(o, matchedAmount)
+	if err != nil {
+		logger.Errorf("Taker order %v failed settlement validation: %v", o.Hash.Hex(), err)
+
+		err = s.broker.PublishInvalidateTakerOrdersMessage(matches)
+		if err != nil {
+			logger.Error(err)
+		}
+
+		return
+	}
+
 	//res.Matches is an array of (order, trade) pairs where each order is an "maker" order that is being matched
 	for i, _ := range matches.Trades {
 		err := s.validator.ValidateBalance(matches.MakerOrders[i])
+		if err == nil {
+			err = s.validator.ValidateMakerSettlement(matches.MakerOrders[i], matches.Trades[i])
+		}
+
 		if err != nil {
-			logger.Error(err)
+			logger.Errorf("Maker order %v failed settlement validation: %v", matches.MakerOrders[i].Hash.Hex(), err)
 			invalidMatches.AppendMatch(matches.MakerOrders[i], matches.Trades[i])
 
 		} else {
@@ -292,16 +699,61 @@ func (s *OrderService) handleEngineOrderMatched(res *types.EngineResponse) {
 }
 
 func (s *OrderService) handleOrderCancelled(res *types.EngineResponse) {
+	s.lockedBalances.UpdateOrders(res.Order.Hash)
+	s.recordOrderEvents(types.NewOrderEvent(res.Order, types.ORDER_EVENT_CANCELLED))
+
 	ws.SendOrderMessage("ORDER_CANCELLED", res.Order.UserAddress, res.Order)
 	s.broadcastOrderBookUpdate([]*types.Order{res.Order})
 	s.broadcastRawOrderBookUpdate([]*types.Order{res.Order})
 	return
 }
 
+// handleOrderCorrected sends the updates of an order whose filled amount was corrected from the
+// exchange contract state and which can not be filled anymore
+func (s *OrderService) handleOrderCorrected(res *types.EngineResponse) {
+	s.lockedBalances.UpdateOrders(res.Order.Hash)
+	s.recordOrderEvents(types.NewOrderEvent(res.Order, types.ORDER_EVENT_CORRECTED))
+
+	ws.SendOrderMessage("ORDER_UPDATED", res.Order.UserAddress, res.Order)
+	s.broadcastOrderBookUpdate([]*types.Order{res.Order})
+	s.broadcastRawOrderBookUpdate([]*types.Order{res.Order})
+}
+
 func (s *OrderService) handleOrdersInvalidated(res *types.EngineResponse) error {
 	orders := res.InvalidatedOrders
 	trades := res.CancelledTrades
 
+	// the orders of the cancelled trades had their filled amounts restored by the engine
+	hashes := []common.Hash{}
+	if orders != nil {
+		for _, o := range *orders {
+			hashes = append(hashes, o.Hash)
+		}
+	}
+
+	if trades != nil {
+		for _, t := range *trades {
+			hashes = append(hashes, t.MakerOrderHash, t.TakerOrderHash)
+		}
+	}
+
+	s.lockedBalances.UpdateOrders(hashes...)
+
+	events := []*types.OrderEvent{}
+	if orders != nil {
+		for _, o := range *orders {
+			events = append(events, types.NewOrderEvent(o, types.ORDER_EVENT_INVALIDATED))
+		}
+	}
+
+	if trades != nil {
+		for _, t := range *trades {
+			events = append(events, types.NewTradeOrderEvents(t, types.ORDER_EVENT_TRADE_CANCELLED)...)
+		}
+	}
+
+	s.recordOrderEvents(events...)
+
 	for _, o := range *orders {
 		ws.SendOrderMessage("ORDER_INVALIDATED", o.UserAddress, o)
 	}
@@ -324,6 +776,9 @@ func (s *OrderService) handleOrdersInvalidated(res *types.EngineResponse) error
 // handleEngineError returns an websocket error message to the client and recovers orders on the
 func (s *OrderService) handleEngineError(res *types.EngineResponse) {
 	o := res.Order
+	s.lockedBalances.UpdateOrders(o.Hash)
+	s.recordOrderEvents(types.NewOrderEvent(o, types.ORDER_EVENT_REJECTED))
+
 	ws.SendOrderMessage("ERROR", o.UserAddress, nil)
 }
 
@@ -343,8 +798,16 @@ func (s *OrderService) HandleOperatorMessages(msg *types.OperatorMessage) error
 		s.handleOperatorTradeTxSuccess(msg)
 	case types.TRADE_TX_ERROR:
 		s.handleOperatorTradeTxError(msg)
+	case types.TRADE_TX_TIMEOUT:
+		s.handleOperatorTradeTxTimeout(msg)
 	case types.TRADE_INVALID:
 		s.handleOperatorTradeInvalid(msg)
+	case types.HARD_CANCEL_TX_PENDING:
+		s.handleOperatorHardCancelTx("ORDER_HARD_CANCEL_PENDING", types.ORDER_EVENT_HARD_CANCEL_PENDING, msg)
+	case types.HARD_CANCEL_TX_SUCCESS:
+		s.handleOperatorHardCancelTx("ORDER_HARD_CANCELLED", types.ORDER_EVENT_HARD_CANCELLED, msg)
+	case types.HARD_CANCEL_TX_ERROR:
+		s.handleOperatorHardCancelTx("ORDER_HARD_CANCEL_ERROR", types.ORDER_EVENT_HARD_CANCEL_ERROR, msg)
 	default:
 		s.handleOperatorUnknownMessage(msg)
 	}
@@ -352,11 +815,35 @@ func (s *OrderService) HandleOperatorMessages(msg *types.OperatorMessage) error
 	return nil
 }
 
+// handleOperatorHardCancelTx informs the makers of the orders included in a hard cancel
+// transaction of the status of this transaction
+func (s *OrderService) handleOperatorHardCancelTx(msgType types.SubscriptionEvent, eventType string, msg *types.OperatorMessage) {
+	events := []*types.OrderEvent{}
+	for _, o := range msg.Orders {
+		e := types.NewOrderEvent(o, eventType)
+		e.TxHash = msg.TxHash
+		events = append(events, e)
+	}
+
+	s.recordOrderEvents(events...)
+
+	for _, o := range msg.Orders {
+		ws.SendOrderMessage(msgType, o.UserAddress, types.OrderHardCancelPayload{o, msg.TxHash})
+	}
+}
+
 func (s *OrderService) handleOperatorTradeTxPending(msg *types.OperatorMessage) {
 	matches := msg.Matches
 	trades := matches.Trades
 	orders := matches.MakerOrders
 
+	events := []*types.OrderEvent{}
+	for _, t := range trades {
+		events = append(events, types.NewTradeOrderEvents(t, types.ORDER_EVENT_TRADE_PENDING)...)
+	}
+
+	s.recordOrderEvents(events...)
+
 	taker := trades[0].Taker
 	ws.SendOrderMessage("ORDER_PENDING", taker, types.OrderPendingPayload{matches})
 
@@ -388,6 +875,18 @@ func (s *OrderService) handleOperatorTradeTxSuccess(msg *types.OperatorMessage)
 		logger.Error(err)
 	}
 
+	events := []*types.OrderEvent{}
+	for _, t := range trades {
+		events = append(events, types.NewTradeOrderEvents(t, types.ORDER_EVENT_TRADE_SUCCESS)...)
+	}
+
+	s.recordOrderEvents(events...)
+
+	s.fees.RecordSettledTrades(matches)
+
+	// the referrer of the taker earns a share of the taker fees of the settled trades
+	s.referrals.HandleTradesSettled(matches)
+
 	// Send ORDER_SUCCESS message to order takers
 	taker := trades[0].Taker
 	ws.SendOrderMessage("ORDER_SUCCESS", taker, types.OrderSuccessPayload{matches})
@@ -404,66 +903,45 @@ func (s *OrderService) handleOperatorTradeTxSuccess(msg *types.OperatorMessage)
 
 // handleOperatorTradeTxError handles cases where a blockchain transaction is reverted
 func (s *OrderService) handleOperatorTradeTxError(msg *types.OperatorMessage) {
+	s.handleOperatorSettlementError(msg, types.ErrCodeTxReverted)
+}
+
+// handleOperatorTradeTxTimeout records that the transaction of the trades was not mined in time and
+// notifies the taker and makers with a TX_TIMEOUT error. The trades are left pending, and the orders
+// are not put back in the orderbook, since the transaction can still be mined. The operator fails the
+// trades once the transaction is considered as dropped
+func (s *OrderService) handleOperatorTradeTxTimeout(msg *types.OperatorMessage) {
 	matches := msg.Matches
-	trades := matches.Trades
-	orders := matches.MakerOrders
 
-	errType := msg.ErrorType
-	if errType != "" {
-		logger.Error("")
+	settlementError := msg.SettlementError
+	if settlementError == nil {
+		settlementError = types.NewSettlementError(types.ErrCodeTxTimeout)
 	}
 
-	for _, t := range trades {
-		err := s.tradeDao.UpdateTradeStatus(t.Hash, "ERROR")
-		if err != nil {
-			logger.Error(err)
+	events := []*types.OrderEvent{}
+	for _, t := range matches.Trades {
+		for _, e := range types.NewTradeOrderEvents(t, types.ORDER_EVENT_TRADE_TIMEOUT) {
+			e.TxHash = msg.TxHash
+			e.Error = settlementError.Code
+			events = append(events, e)
 		}
-
-		t.Status = "ERROR"
 	}
 
-	taker := trades[0].Taker
-	ws.SendOrderMessage("ORDER_ERROR", taker, matches)
+	s.recordOrderEvents(events...)
+	logger.Warningf("%v: trades left pending (tx: %v)", msg.MessageType, msg.TxHash.Hex())
 
-	for _, o := range orders {
-		maker := o.UserAddress
-		ws.SendOrderMessage("ORDER_ERROR", maker, o)
-	}
+	ws.SendOrderMessage("ORDER_ERROR", matches.Taker(), types.OrderErrorPayload{Matches: matches, SettlementError: settlementError})
 
-	s.broadcastTradeUpdate(trades)
+	for _, o := range matches.MakerOrders {
+		ws.SendOrderMessage("ORDER_ERROR", o.UserAddress, types.OrderErrorPayload{Order: o, SettlementError: settlementError})
+	}
 }
 
 // handleOperatorTradeError handles error messages from the operator (case where the blockchain tx was made
 // but ended up failing. It updates the trade status in the db.
 // orderbook.
 func (s *OrderService) handleOperatorTradeError(msg *types.OperatorMessage) {
-	matches := msg.Matches
-	trades := matches.Trades
-	orders := matches.MakerOrders
-
-	errType := msg.ErrorType
-	if errType != "" {
-		logger.Error("")
-	}
-
-	for _, t := range trades {
-		err := s.tradeDao.UpdateTradeStatus(t.Hash, "ERROR")
-		if err != nil {
-			logger.Error(err)
-		}
-
-		t.Status = "ERROR"
-	}
-
-	taker := trades[0].Taker
-	ws.SendOrderMessage("ORDER_ERROR", taker, matches)
-
-	for _, o := range orders {
-		maker := o.UserAddress
-		ws.SendOrderMessage("ORDER_ERROR", maker, o)
-	}
-
-	s.broadcastTradeUpdate(trades)
+	s.handleOperatorSettlementError(msg, types.ErrCodeServerError)
 }
 
 // handleOperatorTradeInvalid handles the case where one of the two orders is invalid
@@ -471,30 +949,48 @@ func (s *OrderService) handleOperatorTradeError(msg *types.OperatorMessage) {
 // not have enough tokens to satisfy the order. Ultimately, the goal would be to
 // reinclude the non-invalid orders in the orderbook
 func (s *OrderService) handleOperatorTradeInvalid(msg *types.OperatorMessage) {
+	s.handleOperatorSettlementError(msg, types.ErrCodeInvalidTrade)
+}
+
+// handleOperatorSettlementError records the settlement error on the trades and
+// forwards it to the taker and makers of the failed trades. The given code is used
+// when the operator message does not carry a settlement error
+func (s *OrderService) handleOperatorSettlementError(msg *types.OperatorMessage, defaultCode string) {
 	matches := msg.Matches
 	trades := matches.Trades
 	orders := matches.MakerOrders
 
-	errType := msg.ErrorType
-	if errType != "" {
-		logger.Error("")
+	settlementError := msg.SettlementError
+	if settlementError == nil {
+		settlementError = types.NewSettlementError(defaultCode)
 	}
 
+	logger.Errorf("%v: %v", msg.MessageType, settlementError)
+
+	events := []*types.OrderEvent{}
 	for _, t := range trades {
-		err := s.tradeDao.UpdateTradeStatus(t.Hash, "ERROR")
+		err := s.tradeDao.UpdateTradeSettlementError(t.Hash, settlementError)
 		if err != nil {
 			logger.Error(err)
 		}
 
 		t.Status = "ERROR"
+		t.SettlementError = settlementError
+
+		for _, e := range types.NewTradeOrderEvents(t, types.ORDER_EVENT_TRADE_ERROR) {
+			e.Error = settlementError.Code
+			events = append(events, e)
+		}
 	}
 
+	s.recordOrderEvents(events...)
+
 	taker := trades[0].Taker
-	ws.SendOrderMessage("ORDER_ERROR", taker, matches)
+	ws.SendOrderMessage("ORDER_ERROR", taker, types.OrderErrorPayload{Matches: matches, SettlementError: settlementError})
 
 	for _, o := range orders {
 		maker := o.UserAddress
-		ws.SendOrderMessage("ORDER_ERROR", maker, o)
+		ws.SendOrderMessage("ORDER_ERROR", maker, types.OrderErrorPayload{Order: o, SettlementError: settlementError})
 	}
 
 	s.broadcastTradeUpdate(trades)
@@ -26,8 +26,13 @@ func TestCancelTrades(t *testing.T) {
 		pairDao,
 		accountDao,
 		tradeDao,
+		nil,
+		nil,
 		engine,
 		ethereum,
+		nil,
+		nil,
+		nil,
 		amqp,
 	)
 
@@ -52,3 +57,71 @@ func TestCancelTrades(t *testing.T) {
 	orderDao.AssertCalled(t, "GetByHashes", hashes)
 	engine.AssertCalled(t, "CancelTrades", orders, amounts)
 }
+
+func TestCancelOrderSignature(t *testing.T) {
+	orderDao := new(mocks.OrderDao)
+	orderService := NewOrderService(orderDao, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	o := testutils.GetTestOrder1()
+	o.UserAddress = testutils.GetTestWallet1().Address
+	orderDao.On("GetByHash", o.Hash).Return(&o, nil)
+
+	other := &types.OrderCancel{OrderHash: o.Hash}
+	other.Sign(testutils.GetTestWallet2())
+
+	// the signature of the order itself can not be used as a cancellation
+	sig, _ := testutils.GetTestWallet1().SignHash(o.Hash)
+	replayed := &types.OrderCancel{OrderHash: o.Hash, Hash: o.Hash, Signature: sig}
+
+	tests := map[string]*types.OrderCancel{
+		"signed by another account": other,
+		"order signature":           replayed,
+	}
+
+	for name, oc := range tests {
+		err := orderService.CancelOrder(oc)
+		if err == nil {
+			t.Errorf("%v: expected the cancellation to be refused", name)
+		}
+
+		oc.HardCancel = true
+		err = orderService.CancelOrder(oc)
+		if err == nil {
+			t.Errorf("%v: expected the hard cancellation to be refused", name)
+		}
+	}
+}
+
+func TestCheckAccountRestriction(t *testing.T) {
+	addr := common.HexToAddress("0x1")
+
+	tests := []struct {
+		name    string
+		level   string
+		action  string
+		allowed bool
+	}{
+		{"active account trading", types.ACCOUNT_ACTIVE, types.ACCOUNT_ACTION_TRADE, true},
+		{"cancel only account trading", types.ACCOUNT_CANCEL_ONLY, types.ACCOUNT_ACTION_TRADE, false},
+		{"cancel only account cancelling", types.ACCOUNT_CANCEL_ONLY, types.ACCOUNT_ACTION_CANCEL, true},
+		{"withdraw only account cancelling", types.ACCOUNT_WITHDRAW_ONLY, types.ACCOUNT_ACTION_CANCEL, true},
+		{"blocked account cancelling", types.ACCOUNT_BLOCKED, types.ACCOUNT_ACTION_CANCEL, false},
+	}
+
+	for _, test := range tests {
+		accountDao := new(mocks.AccountDao)
+		orderService := NewOrderService(nil, nil, accountDao, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		acc := &types.Account{Address: addr, Restriction: &types.AccountRestriction{Level: test.level, Reason: "compliance review"}}
+		accountDao.On("GetByAddress", addr).Return(acc, nil)
+
+		err := orderService.checkAccountRestriction(addr, test.action)
+		if test.allowed && err != nil {
+			t.Errorf("%v: expected the action to be allowed, got %v", test.name, err)
+		}
+
+		if !test.allowed && err == nil {
+			t.Errorf("%v: expected the action to be refused", test.name)
+		}
+	}
+}
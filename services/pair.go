@@ -14,12 +14,14 @@ import (
 // PairService struct with daos required, responsible for communicating with daos.
 // PairService functions are responsible for interacting with daos and implements business logics.
 type PairService struct {
-	pairDao  interfaces.PairDao
-	tokenDao interfaces.TokenDao
-	tradeDao interfaces.TradeDao
-	orderDao interfaces.OrderDao
-	eng      interfaces.Engine
-	provider interfaces.EthereumProvider
+	pairDao           interfaces.PairDao
+	tokenDao          interfaces.TokenDao
+	tradeDao          interfaces.TradeDao
+	orderDao          interfaces.OrderDao
+	eng               interfaces.Engine
+	provider          interfaces.EthereumProvider
+	exchange          interfaces.Exchange
+	governanceService interfaces.GovernanceService
 }
 
 // NewPairService returns a new instance of balance service
@@ -30,9 +32,11 @@ func NewPairService(
 	orderDao interfaces.OrderDao,
 	eng interfaces.Engine,
 	provider interfaces.EthereumProvider,
+	exchange interfaces.Exchange,
+	governanceService interfaces.GovernanceService,
 ) *PairService {
 
-	return &PairService{pairDao, tokenDao, tradeDao, orderDao, eng, provider}
+	return &PairService{pairDao, tokenDao, tradeDao, orderDao, eng, provider, exchange, governanceService}
 }
 
 func (s *PairService) CreatePairs(addr common.Address) ([]*types.Pair, error) {
@@ -99,12 +103,26 @@ func (s *PairService) CreatePairs(addr common.Address) ([]*types.Pair, error) {
 				TakeFee:            q.TakeFee,
 			}
 
-			err := s.pairDao.Create(&p)
+			unregistered, err := s.checkPairRegistration(&p)
 			if err != nil {
 				logger.Error(err)
 				return nil, err
 			}
 
+			err = s.pairDao.Create(&p)
+			if err != nil {
+				logger.Error(err)
+				return nil, err
+			}
+
+			if unregistered {
+				err = s.registerPair(&p, true)
+				if err != nil {
+					logger.Error(err)
+					return nil, err
+				}
+			}
+
 			pairs = append(pairs, &p)
 		}
 	}
@@ -120,6 +138,12 @@ func (s *PairService) Create(pair *types.Pair) error {
 		return err
 	}
 
+	if p != nil && !p.Active {
+		activate := pair.Active
+		*pair = *p
+		return s.retryRegistration(pair, activate)
+	}
+
 	if p != nil {
 		return ErrPairExists
 	}
@@ -152,14 +176,148 @@ func (s *PairService) Create(pair *types.Pair) error {
 	pair.BaseTokenSymbol = bt.Symbol
 	pair.BaseTokenAddress = bt.ContractAddress
 	pair.BaseTokenDecimals = bt.Decimals
+
+	active := pair.Active
+	unregistered, err := s.checkPairRegistration(pair)
+	if err != nil {
+		return err
+	}
+
 	err = s.pairDao.Create(pair)
 	if err != nil {
 		return err
 	}
 
+	if unregistered {
+		return s.registerPair(pair, active)
+	}
+
+	return nil
+}
+
+// checkPairRegistration verifies that a pair is registered on the exchange contract with
+// the pricepoint multiplier expected by the server. A pair registered with another multiplier
+// is set inactive since its trades would always revert. An unregistered pair is set inactive
+// and true is returned, so that it is registered once it is stored
+func (s *PairService) checkPairRegistration(p *types.Pair) (bool, error) {
+	registered, err := s.isPairRegistered(p)
+	if err == ErrPricepointMultiplierMismatch {
+		logger.Warningf("Pair %v is registered with another pricepoint multiplier, setting it inactive", p.Name())
+		p.Active = false
+		return false, nil
+	}
+
+	if err != nil {
+		logger.Error(err)
+		return false, err
+	}
+
+	if registered {
+		return false, nil
+	}
+
+	p.Active = false
+	return true, nil
+}
+
+// registerPair registers a stored pair on the exchange contract from the admin wallet. The pair
+// is activated once the registration transaction is mined if activate is true. If the transaction
+// can not be sent, the pair remains inactive
+func (s *PairService) registerPair(p *types.Pair, activate bool) error {
+	tx, err := s.governanceService.RegisterPair(p.BaseTokenAddress, p.QuoteTokenAddress, p.PricepointMultiplier())
+	if err != nil {
+		logger.Errorf("Pair %v could not be registered on the exchange contract: %v", p.Name(), err)
+		return err
+	}
+
+	if activate {
+		go s.activateOnRegistration(p, tx.TxHash)
+	}
+
+	return nil
+}
+
+// retryRegistration handles the creation of a stored inactive pair, whose registration failed
+// or was sent through the governance endpoints. The pair is activated if it is now registered on
+// the exchange contract, and registered again otherwise
+func (s *PairService) retryRegistration(p *types.Pair, activate bool) error {
+	registered, err := s.isPairRegistered(p)
+	if err == ErrPricepointMultiplierMismatch {
+		return ErrPairExists
+	}
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if !registered {
+		return s.registerPair(p, activate)
+	}
+
+	if !activate {
+		return nil
+	}
+
+	err = s.pairDao.SetActive(p.BaseTokenAddress, p.QuoteTokenAddress, true)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	p.Active = true
+	logger.Infof("Pair %v activated after its registration on the exchange contract", p.Name())
 	return nil
 }
 
+// isPairRegistered returns true if the pair is registered on the exchange contract. It returns
+// ErrPricepointMultiplierMismatch if the contract multiplier differs from the pair multiplier
+func (s *PairService) isPairRegistered(p *types.Pair) (bool, error) {
+	registered, err := s.exchange.PairIsRegistered(p.BaseTokenAddress, p.QuoteTokenAddress)
+	if err != nil {
+		return false, err
+	}
+
+	if !registered {
+		return false, nil
+	}
+
+	multiplier, err := s.exchange.GetPairPricepointMultiplier(p.BaseTokenAddress, p.QuoteTokenAddress)
+	if err != nil {
+		return false, err
+	}
+
+	if multiplier.Cmp(p.PricepointMultiplier()) != 0 {
+		return true, ErrPricepointMultiplierMismatch
+	}
+
+	return true, nil
+}
+
+// activateOnRegistration activates a pair once its registration transaction is mined
+// and the registration is confirmed by the exchange contract
+func (s *PairService) activateOnRegistration(p *types.Pair, h common.Hash) {
+	receipt, err := s.provider.WaitMined(h)
+	if err != nil || receipt.Status == 0 {
+		logger.Errorf("Registration of pair %v failed (tx: %v)", p.Name(), h.Hex())
+		return
+	}
+
+	registered, err := s.isPairRegistered(p)
+	if err != nil || !registered {
+		logger.Errorf("Pair %v could not be verified on the exchange contract: %v", p.Name(), err)
+		return
+	}
+
+	err = s.pairDao.SetActive(p.BaseTokenAddress, p.QuoteTokenAddress, true)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	logger.Infof("Pair %v registered on the exchange contract (tx: %v)", p.Name(), h.Hex())
+}
+
 // GetByID fetches details of a pair using its mongo ID
 func (s *PairService) GetByID(id bson.ObjectId) (*types.Pair, error) {
 	return s.pairDao.GetByID(id)
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/testutils/mocks"
+)
+
+func SetupPairServiceTest() (*PairService, *mocks.PairDao, *mocks.Exchange, *mocks.GovernanceService) {
+	pairDao := new(mocks.PairDao)
+	provider := new(mocks.EthereumProvider)
+	exchange := new(mocks.Exchange)
+	governanceService := new(mocks.GovernanceService)
+
+	// the registration transactions never succeed, so that the pairs are not activated
+	// in the background
+	provider.On("WaitMined", mock.Anything).Return(&eth.Receipt{Status: 0}, nil)
+
+	s := NewPairService(pairDao, nil, nil, nil, nil, provider, exchange, governanceService)
+	return s, pairDao, exchange, governanceService
+}
+
+func newTestStoredPair(active bool) *types.Pair {
+	return &types.Pair{
+		BaseTokenSymbol:    "ZRX",
+		BaseTokenAddress:   common.HexToAddress("0x1"),
+		BaseTokenDecimals:  18,
+		QuoteTokenSymbol:   "WETH",
+		QuoteTokenAddress:  common.HexToAddress("0x2"),
+		QuoteTokenDecimals: 18,
+		Active:             active,
+	}
+}
+
+func TestCreatePairRetriesRegistration(t *testing.T) {
+	s, pairDao, exchange, governanceService := SetupPairServiceTest()
+
+	stored := newTestStoredPair(false)
+	bt, qt := stored.BaseTokenAddress, stored.QuoteTokenAddress
+	tx := &types.AdminTransaction{TxHash: common.HexToHash("0x3")}
+
+	pairDao.On("GetByTokenAddress", bt, qt).Return(stored, nil)
+	exchange.On("PairIsRegistered", bt, qt).Return(false, nil)
+	governanceService.On("RegisterPair", bt, qt, stored.PricepointMultiplier()).Return(tx, nil)
+
+	pair := &types.Pair{BaseTokenAddress: bt, QuoteTokenAddress: qt, Active: true}
+	err := s.Create(pair)
+	if err != nil {
+		t.Fatalf("Could not retry the pair registration: %v", err)
+	}
+
+	if pair.BaseTokenSymbol != "ZRX" || pair.Active {
+		t.Errorf("Unexpected pair %v", pair)
+	}
+
+	governanceService.AssertCalled(t, "RegisterPair", bt, qt, stored.PricepointMultiplier())
+	pairDao.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestCreatePairActivatesRegisteredPair(t *testing.T) {
+	s, pairDao, exchange, governanceService := SetupPairServiceTest()
+
+	stored := newTestStoredPair(false)
+	bt, qt := stored.BaseTokenAddress, stored.QuoteTokenAddress
+
+	pairDao.On("GetByTokenAddress", bt, qt).Return(stored, nil)
+	pairDao.On("SetActive", bt, qt, true).Return(nil)
+	exchange.On("PairIsRegistered", bt, qt).Return(true, nil)
+	exchange.On("GetPairPricepointMultiplier", bt, qt).Return(stored.PricepointMultiplier(), nil)
+
+	pair := &types.Pair{BaseTokenAddress: bt, QuoteTokenAddress: qt, Active: true}
+	err := s.Create(pair)
+	if err != nil {
+		t.Fatalf("Could not activate the pair: %v", err)
+	}
+
+	if !pair.Active {
+		t.Errorf("Expected the pair to be active")
+	}
+
+	pairDao.AssertCalled(t, "SetActive", bt, qt, true)
+	governanceService.AssertNotCalled(t, "RegisterPair", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateExistingPair(t *testing.T) {
+	tests := map[string]struct {
+		stored     *types.Pair
+		multiplier *big.Int
+	}{
+		"active pair":                    {newTestStoredPair(true), nil},
+		"pricepoint multiplier mismatch": {newTestStoredPair(false), big.NewInt(1)},
+	}
+
+	for name, test := range tests {
+		s, pairDao, exchange, governanceService := SetupPairServiceTest()
+
+		bt, qt := test.stored.BaseTokenAddress, test.stored.QuoteTokenAddress
+		pairDao.On("GetByTokenAddress", bt, qt).Return(test.stored, nil)
+		exchange.On("PairIsRegistered", bt, qt).Return(true, nil)
+		exchange.On("GetPairPricepointMultiplier", bt, qt).Return(test.multiplier, nil)
+
+		err := s.Create(&types.Pair{BaseTokenAddress: bt, QuoteTokenAddress: qt, Active: true})
+		if err != ErrPairExists {
+			t.Errorf("%v: expected ErrPairExists, got %v", name, err)
+		}
+
+		pairDao.AssertNotCalled(t, "SetActive", mock.Anything, mock.Anything, mock.Anything)
+		governanceService.AssertNotCalled(t, "RegisterPair", mock.Anything, mock.Anything, mock.Anything)
+	}
+}
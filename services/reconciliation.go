@@ -0,0 +1,278 @@
+package services
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/errors"
+	"github.com/tomochain/dex-server/interfaces"
+	"github.com/tomochain/dex-server/rabbitmq"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/math"
+)
+
+// reconciliationGracePeriod leaves time to the orders and trades that were recently
+// updated to be settled before they are compared with the exchange contract
+const reconciliationGracePeriod = 10 * time.Minute
+
+// reconciliationDropPeriod is the time after which a pending trade sent in a transaction that did
+// not settle it is considered as dropped. It is longer than the time the operator awaits a trade
+// transaction, so that only the trades the operator could not resolve are failed
+const reconciliationDropPeriod = 2 * time.Hour
+
+// ReconciliationService compares the filled amounts of the open orders and the status of
+// the pending trades with the Filled and Traded mappings of the exchange contract
+type ReconciliationService struct {
+	orderDao     interfaces.OrderDao
+	tradeDao     interfaces.TradeDao
+	orderService interfaces.OrderService
+	exchange     interfaces.Exchange
+	broker       *rabbitmq.Connection
+	lastReport   *types.ReconciliationReport
+	mutex        *sync.Mutex
+}
+
+// NewReconciliationService returns a new instance of ReconciliationService
+func NewReconciliationService(
+	orderDao interfaces.OrderDao,
+	tradeDao interfaces.TradeDao,
+	orderService interfaces.OrderService,
+	exchange interfaces.Exchange,
+	broker *rabbitmq.Connection,
+) *ReconciliationService {
+	return &ReconciliationService{orderDao, tradeDao, orderService, exchange, broker, nil, &sync.Mutex{}}
+}
+
+// Reconcile reports the open orders and the pending trades that disagree with the exchange
+// contract. If autoCorrect is true, the orders filled amounts are corrected, the trades
+// settled on chain are marked as successful and the trades of dropped transactions are failed
+func (s *ReconciliationService) Reconcile(autoCorrect bool) (*types.ReconciliationReport, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	report := &types.ReconciliationReport{
+		AutoCorrect:   autoCorrect,
+		Discrepancies: []*types.Discrepancy{},
+		StartedAt:     time.Now(),
+	}
+
+	traded, err := s.reconcileTrades(report)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	err = s.reconcileOrders(report, traded)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	report.CompletedAt = time.Now()
+	s.lastReport = report
+
+	logger.Infof(
+		"Fill reconciliation: %v orders and %v trades checked, %v discrepancies found",
+		report.CheckedOrders,
+		report.CheckedTrades,
+		len(report.Discrepancies),
+	)
+
+	return report, nil
+}
+
+// GetLastReport returns the report of the last reconciliation run
+func (s *ReconciliationService) GetLastReport() *types.ReconciliationReport {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.lastReport
+}
+
+// reconcileTrades compares the pending trades with the Traded mapping and returns
+// whether each of them was settled on chain
+func (s *ReconciliationService) reconcileTrades(report *types.ReconciliationReport) (map[common.Hash]bool, error) {
+	traded := make(map[common.Hash]bool)
+
+	trades, err := s.tradeDao.GetByStatus(types.PENDING)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range trades {
+		isTraded, err := s.exchange.Traded(t.Hash)
+		if err != nil {
+			logger.Error(err)
+			continue
+		}
+
+		report.CheckedTrades++
+		traded[t.Hash] = isTraded
+
+		if time.Since(t.UpdatedAt) < reconciliationGracePeriod {
+			continue
+		}
+
+		d := &types.Discrepancy{Hash: t.Hash, Database: t.Status}
+		switch {
+		case isTraded:
+			d.Type = types.TRADE_SETTLED
+			d.Chain = "TRADED"
+		case t.TxHash != (common.Hash{}) && time.Since(t.UpdatedAt) > reconciliationDropPeriod:
+			d.Type = types.TRADE_DROPPED
+			d.Chain = "NOT_TRADED"
+		default:
+			// trades that were not settled are only reported since their transaction might still be mined
+			d.Type = types.TRADE_NOT_SETTLED
+			d.Chain = "NOT_TRADED"
+		}
+
+		logger.Warningf("Trade %v is %v in DB and %v on chain", t.Hash.Hex(), d.Database, d.Chain)
+
+		if report.AutoCorrect && d.Type != types.TRADE_NOT_SETTLED {
+			err := s.correctTrade(t, d.Type)
+			if err != nil {
+				logger.Error(err)
+			} else {
+				d.Corrected = true
+			}
+		}
+
+		report.Discrepancies = append(report.Discrepancies, d)
+	}
+
+	return traded, nil
+}
+
+// reconcileOrders compares the filled amount of the open orders with the Filled mapping. The
+// amounts of the trades which are still being settled are added to the amount filled on chain.
+// The cancellation of an order on chain sets its filled amount to the order amount, so an order
+// filled on chain beyond its settled trades is cancelled rather than marked as filled
+func (s *ReconciliationService) reconcileOrders(report *types.ReconciliationReport, traded map[common.Hash]bool) error {
+	orders, err := s.orderDao.GetOpenOrders()
+	if err != nil {
+		return err
+	}
+
+	for _, o := range orders {
+		if time.Since(o.UpdatedAt) < reconciliationGracePeriod {
+			continue
+		}
+
+		filled, err := s.exchange.Filled(o.Hash)
+		if err != nil {
+			logger.Error(err)
+			continue
+		}
+
+		settled, pending, err := s.getTradedAmounts(o, traded)
+		if err != nil {
+			logger.Error(err)
+			continue
+		}
+
+		report.CheckedOrders++
+
+		dbFilled := o.FilledAmount
+		if dbFilled == nil {
+			dbFilled = big.NewInt(0)
+		}
+
+		expected := math.Add(filled, pending)
+		if expected.Cmp(dbFilled) == 0 {
+			continue
+		}
+
+		d := &types.Discrepancy{
+			Type:     types.ORDER_FILL_MISMATCH,
+			Hash:     o.Hash,
+			Database: dbFilled.String(),
+			Chain:    filled.String(),
+		}
+
+		if math.IsEqualOrGreaterThan(filled, o.Amount) && math.IsStrictlySmallerThan(settled, filled) {
+			d.Type = types.ORDER_CANCELLED_ON_CHAIN
+			logger.Warningf("Order %v is %v in DB but was cancelled on chain", o.Hash.Hex(), o.Status)
+		} else {
+			logger.Warningf("Order %v filled amount is %v in DB and %v on chain (%v pending)", o.Hash.Hex(), dbFilled, filled, pending)
+		}
+
+		if report.AutoCorrect {
+			err := s.correctOrder(o, d.Type, expected)
+			if err != nil {
+				logger.Error(err)
+			} else {
+				d.Corrected = true
+			}
+		}
+
+		report.Discrepancies = append(report.Discrepancies, d)
+	}
+
+	return nil
+}
+
+// correctOrder routes the correction of an order through the engine: the orders cancelled on chain
+// are cancelled and the filled amount of the other orders is updated
+func (s *ReconciliationService) correctOrder(o *types.Order, discrepancy string, filledAmount *big.Int) error {
+	if discrepancy == types.ORDER_CANCELLED_ON_CHAIN {
+		return s.broker.PublishCancelOrderMessage(o)
+	}
+
+	return s.orderService.UpdateFilledAmount(o, filledAmount)
+}
+
+// getTradedAmounts returns the amount of the trades of an order that are settled on chain, and the
+// amount of its pending trades that are not settled on chain yet
+func (s *ReconciliationService) getTradedAmounts(o *types.Order, traded map[common.Hash]bool) (*big.Int, *big.Int, error) {
+	makerTrades, err := s.tradeDao.GetByMakerOrderHash(o.Hash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	takerTrades, err := s.tradeDao.GetByTakerOrderHash(o.Hash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	settled := big.NewInt(0)
+	pending := big.NewInt(0)
+	for _, t := range append(makerTrades, takerTrades...) {
+		switch {
+		case t.Status == types.SUCCESS || (t.Status == types.PENDING && traded[t.Hash]):
+			settled = math.Add(settled, t.Amount)
+		case t.Status == types.PENDING:
+			pending = math.Add(pending, t.Amount)
+		}
+	}
+
+	return settled, pending, nil
+}
+
+// correctTrade publishes a trade success message for a trade that was settled on chain, or a
+// TX_DROPPED error for a trade whose transaction was dropped. The message updates the trade status
+// and notifies the maker and the taker
+func (s *ReconciliationService) correctTrade(t *types.Trade, discrepancy string) error {
+	mo, err := s.orderDao.GetByHash(t.MakerOrderHash)
+	if err != nil {
+		return err
+	}
+
+	to, err := s.orderDao.GetByHash(t.TakerOrderHash)
+	if err != nil {
+		return err
+	}
+
+	if mo == nil || to == nil {
+		return errors.New("Could not find the orders of trade " + t.Hash.Hex())
+	}
+
+	m := types.NewMatches([]*types.Order{mo}, to, []*types.Trade{t})
+	if discrepancy == types.TRADE_DROPPED {
+		return s.broker.PublishTxErrorMessage(m, types.NewSettlementError(types.ErrCodeTxDropped))
+	}
+
+	return s.broker.PublishTradeSuccessMessage(m)
+}
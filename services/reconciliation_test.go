@@ -0,0 +1,241 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/testutils/mocks"
+)
+
+func newTestReconciliationTrade(hash string, status string, amount int64, age time.Duration) *types.Trade {
+	return &types.Trade{
+		Hash:      common.HexToHash(hash),
+		Status:    status,
+		Amount:    big.NewInt(amount),
+		UpdatedAt: time.Now().Add(-age),
+	}
+}
+
+func TestReconcileTrades(t *testing.T) {
+	dropped := newTestReconciliationTrade("0x3", types.PENDING, 100, 3*time.Hour)
+	dropped.TxHash = common.HexToHash("0x10")
+
+	sent := newTestReconciliationTrade("0x4", types.PENDING, 100, time.Hour)
+	sent.TxHash = common.HexToHash("0x11")
+
+	tests := []struct {
+		name        string
+		trade       *types.Trade
+		traded      bool
+		discrepancy string
+	}{
+		{"settled", newTestReconciliationTrade("0x1", types.PENDING, 100, time.Hour), true, types.TRADE_SETTLED},
+		{"not settled", newTestReconciliationTrade("0x2", types.PENDING, 100, time.Hour), false, types.TRADE_NOT_SETTLED},
+		{"dropped", dropped, false, types.TRADE_DROPPED},
+		{"sent and not settled yet", sent, false, types.TRADE_NOT_SETTLED},
+		{"recently updated", newTestReconciliationTrade("0x5", types.PENDING, 100, time.Minute), true, ""},
+	}
+
+	for _, test := range tests {
+		orderDao := new(mocks.OrderDao)
+		tradeDao := new(mocks.TradeDao)
+		exchange := new(mocks.Exchange)
+		s := NewReconciliationService(orderDao, tradeDao, nil, exchange, nil)
+
+		tradeDao.On("GetByStatus", types.PENDING).Return([]*types.Trade{test.trade}, nil)
+		exchange.On("Traded", test.trade.Hash).Return(test.traded, nil)
+		orderDao.On("GetOpenOrders").Return([]*types.Order{}, nil)
+
+		report, err := s.Reconcile(false)
+		if err != nil {
+			t.Fatalf("%v: could not reconcile: %v", test.name, err)
+		}
+
+		if report.CheckedTrades != 1 {
+			t.Errorf("%v: expected 1 checked trade, got %v", test.name, report.CheckedTrades)
+		}
+
+		if test.discrepancy == "" {
+			if len(report.Discrepancies) != 0 {
+				t.Errorf("%v: unexpected discrepancies %v", test.name, report.Discrepancies)
+			}
+
+			continue
+		}
+
+		if len(report.Discrepancies) != 1 || report.Discrepancies[0].Type != test.discrepancy || report.Discrepancies[0].Hash != test.trade.Hash {
+			t.Errorf("%v: expected a %v discrepancy, got %v", test.name, test.discrepancy, report.Discrepancies)
+			continue
+		}
+
+		if report.Discrepancies[0].Corrected {
+			t.Errorf("%v: the discrepancy should not be corrected", test.name)
+		}
+	}
+}
+
+func TestReconcileOrders(t *testing.T) {
+	orderHash := common.HexToHash("0x1")
+
+	tests := []struct {
+		name        string
+		filled      int64
+		dbFilled    int64
+		trades      []*types.Trade
+		traded      []*types.Trade
+		discrepancy string
+		chain       string
+	}{
+		{
+			name:     "in sync",
+			filled:   100,
+			dbFilled: 100,
+			trades:   []*types.Trade{newTestReconciliationTrade("0x2", types.SUCCESS, 100, time.Hour)},
+		},
+		{
+			name:        "fill mismatch",
+			filled:      50,
+			dbFilled:    100,
+			trades:      []*types.Trade{newTestReconciliationTrade("0x2", types.SUCCESS, 50, time.Hour)},
+			discrepancy: types.ORDER_FILL_MISMATCH,
+			chain:       "50",
+		},
+		{
+			name:        "cancelled on chain",
+			filled:      1000,
+			dbFilled:    100,
+			trades:      []*types.Trade{newTestReconciliationTrade("0x2", types.SUCCESS, 100, time.Hour)},
+			discrepancy: types.ORDER_CANCELLED_ON_CHAIN,
+			chain:       "1000",
+		},
+		{
+			// the pending trades are added to the amount filled on chain
+			name:     "pending trade",
+			filled:   50,
+			dbFilled: 100,
+			trades: []*types.Trade{
+				newTestReconciliationTrade("0x2", types.SUCCESS, 50, time.Hour),
+				newTestReconciliationTrade("0x3", types.PENDING, 50, time.Minute),
+			},
+		},
+		{
+			// a pending trade settled on chain is already included in the amount filled on chain
+			name:     "pending trade settled on chain",
+			filled:   100,
+			dbFilled: 100,
+			trades: []*types.Trade{
+				newTestReconciliationTrade("0x2", types.SUCCESS, 50, time.Hour),
+				newTestReconciliationTrade("0x3", types.PENDING, 50, time.Minute),
+			},
+			traded: []*types.Trade{newTestReconciliationTrade("0x3", types.PENDING, 50, time.Minute)},
+		},
+		{
+			name:        "pending trade settled on chain and cancelled order",
+			filled:      1000,
+			dbFilled:    100,
+			trades:      []*types.Trade{newTestReconciliationTrade("0x3", types.PENDING, 100, time.Minute)},
+			traded:      []*types.Trade{newTestReconciliationTrade("0x3", types.PENDING, 100, time.Minute)},
+			discrepancy: types.ORDER_CANCELLED_ON_CHAIN,
+			chain:       "1000",
+		},
+	}
+
+	for _, test := range tests {
+		orderDao := new(mocks.OrderDao)
+		tradeDao := new(mocks.TradeDao)
+		exchange := new(mocks.Exchange)
+		s := NewReconciliationService(orderDao, tradeDao, nil, exchange, nil)
+
+		o := &types.Order{
+			Hash:         orderHash,
+			Amount:       big.NewInt(1000),
+			FilledAmount: big.NewInt(test.dbFilled),
+			Status:       "PARTIAL_FILLED",
+			UpdatedAt:    time.Now().Add(-time.Hour),
+		}
+
+		pending := []*types.Trade{}
+		for _, tr := range test.traded {
+			pending = append(pending, tr)
+			exchange.On("Traded", tr.Hash).Return(true, nil)
+		}
+
+		tradeDao.On("GetByStatus", types.PENDING).Return(pending, nil)
+		tradeDao.On("GetByMakerOrderHash", orderHash).Return(test.trades, nil)
+		tradeDao.On("GetByTakerOrderHash", orderHash).Return([]*types.Trade{}, nil)
+		orderDao.On("GetOpenOrders").Return([]*types.Order{o}, nil)
+		exchange.On("Filled", orderHash).Return(big.NewInt(test.filled), nil)
+
+		report, err := s.Reconcile(false)
+		if err != nil {
+			t.Fatalf("%v: could not reconcile: %v", test.name, err)
+		}
+
+		if report.CheckedOrders != 1 {
+			t.Errorf("%v: expected 1 checked order, got %v", test.name, report.CheckedOrders)
+		}
+
+		if test.discrepancy == "" {
+			if len(report.Discrepancies) != 0 {
+				t.Errorf("%v: unexpected discrepancies %v", test.name, report.Discrepancies)
+			}
+
+			continue
+		}
+
+		if len(report.Discrepancies) != 1 {
+			t.Errorf("%v: expected a %v discrepancy, got %v", test.name, test.discrepancy, report.Discrepancies)
+			continue
+		}
+
+		d := report.Discrepancies[0]
+		if d.Type != test.discrepancy || d.Hash != orderHash || d.Chain != test.chain || d.Database != o.FilledAmount.String() {
+			t.Errorf("%v: unexpected discrepancy %v", test.name, d)
+		}
+	}
+}
+
+func TestReconcileOrdersAutoCorrect(t *testing.T) {
+	orderDao := new(mocks.OrderDao)
+	tradeDao := new(mocks.TradeDao)
+	orderService := new(mocks.OrderService)
+	exchange := new(mocks.Exchange)
+	s := NewReconciliationService(orderDao, tradeDao, orderService, exchange, nil)
+
+	o := &types.Order{
+		Hash:         common.HexToHash("0x1"),
+		Amount:       big.NewInt(1000),
+		FilledAmount: big.NewInt(100),
+		UpdatedAt:    time.Now().Add(-time.Hour),
+	}
+
+	pending := newTestReconciliationTrade("0x2", types.PENDING, 50, time.Minute)
+
+	tradeDao.On("GetByStatus", types.PENDING).Return([]*types.Trade{pending}, nil)
+	tradeDao.On("GetByMakerOrderHash", o.Hash).Return([]*types.Trade{pending}, nil)
+	tradeDao.On("GetByTakerOrderHash", o.Hash).Return([]*types.Trade{}, nil)
+	orderDao.On("GetOpenOrders").Return([]*types.Order{o}, nil)
+	exchange.On("Traded", pending.Hash).Return(false, nil)
+	exchange.On("Filled", o.Hash).Return(big.NewInt(200), nil)
+	orderService.On("UpdateFilledAmount", o, mock.Anything).Return(nil)
+
+	report, err := s.Reconcile(true)
+	if err != nil {
+		t.Fatalf("Could not reconcile: %v", err)
+	}
+
+	if len(report.Discrepancies) != 1 || !report.Discrepancies[0].Corrected {
+		t.Fatalf("Expected a corrected discrepancy, got %v", report.Discrepancies)
+	}
+
+	// the filled amount is corrected to the amount filled on chain and the pending trades
+	orderService.AssertCalled(t, "UpdateFilledAmount", o, big.NewInt(250))
+
+	if s.GetLastReport() != report {
+		t.Errorf("Expected the report to be the last report")
+	}
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/interfaces"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/math"
+)
+
+// ReferralService registers the referrals and accrues to the referrers a share of the taker
+// fees of the settled trades of their referees. The share is configured in basis points
+type ReferralService struct {
+	referralDao interfaces.ReferralDao
+	rebateDao   interfaces.ReferralRebateDao
+	pairDao     interfaces.PairDao
+}
+
+// NewReferralService returns a new instance of ReferralService
+func NewReferralService(
+	referralDao interfaces.ReferralDao,
+	rebateDao interfaces.ReferralRebateDao,
+	pairDao interfaces.PairDao,
+) *ReferralService {
+	return &ReferralService{referralDao, rebateDao, pairDao}
+}
+
+// Register links a referee to its referrer from a registration signed by the referee
+func (s *ReferralService) Register(r *types.ReferralRegistration) (*types.Referral, error) {
+	err := r.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := r.VerifySignature()
+	if err != nil {
+		logger.Error(err)
+	}
+
+	if !ok {
+		return nil, ErrInvalidSignature
+	}
+
+	existing, err := s.referralDao.GetByReferee(r.RefereeAddress)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if existing != nil {
+		return nil, ErrReferralExists
+	}
+
+	parent, err := s.referralDao.GetByReferee(r.ReferrerAddress)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if parent != nil && parent.ReferrerAddress == r.RefereeAddress {
+		return nil, ErrReferralCycle
+	}
+
+	referral := &types.Referral{
+		RefereeAddress:  r.RefereeAddress,
+		ReferrerAddress: r.ReferrerAddress,
+		Hash:            r.Hash,
+	}
+
+	err = s.referralDao.Create(referral)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	logger.Infof("Referral of %v by %v registered", referral.RefereeAddress.Hex(), referral.ReferrerAddress.Hex())
+	return referral, nil
+}
+
+// GetReferral returns the referral of an account, or nil if it was not referred
+func (s *ReferralService) GetReferral(addr common.Address) (*types.Referral, error) {
+	return s.referralDao.GetByReferee(addr)
+}
+
+// GetReferees returns the referrals of a referrer
+func (s *ReferralService) GetReferees(addr common.Address) ([]*types.Referral, error) {
+	return s.referralDao.GetByReferrer(addr)
+}
+
+// GetRefereeSummaries returns the volume and rebates of the referees of a referrer
+func (s *ReferralService) GetRefereeSummaries(addr common.Address) ([]*types.ReferralSummary, error) {
+	return s.rebateDao.GetRefereeSummaries(addr)
+}
+
+// GetRebates returns the rebates accrued to a referrer
+func (s *ReferralService) GetRebates(addr common.Address, limit ...int) ([]*types.ReferralRebate, error) {
+	return s.rebateDao.GetByReferrer(addr, limit...)
+}
+
+// GetBalances returns the rebates accrued to a referrer by quote token
+func (s *ReferralService) GetBalances(addr common.Address) (map[common.Address]*big.Int, error) {
+	return s.rebateDao.GetBalances(addr)
+}
+
+// HandleTradesSettled accrues the rebates of the trades of a settled match to the referrer
+// of the taker. The taker fee of each trade is the take fee signed on the taker order
+func (s *ReferralService) HandleTradesSettled(m *types.Matches) {
+	if m == nil || m.TakerOrder == nil || len(m.Trades) == 0 {
+		return
+	}
+
+	bps := math.ToBigInt(app.Config.Referrals["rebate_bps"])
+	if bps.Sign() <= 0 {
+		return
+	}
+
+	to := m.TakerOrder
+	referral, err := s.referralDao.GetByReferee(to.UserAddress)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	if referral == nil || to.TakeFee == nil {
+		return
+	}
+
+	p, err := s.pairDao.GetByTokenAddress(to.BaseToken, to.QuoteToken)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	if p == nil {
+		logger.Warningf("Pair of settled trades of %v not found", to.Hash.Hex())
+		return
+	}
+
+	amount := math.Div(math.Mul(to.TakeFee, bps), big.NewInt(10000))
+	for _, t := range m.Trades {
+		rebate := &types.ReferralRebate{
+			TradeHash:       t.Hash,
+			ReferrerAddress: referral.ReferrerAddress,
+			RefereeAddress:  referral.RefereeAddress,
+			QuoteToken:      to.QuoteToken,
+			Volume:          math.Div(math.Mul(t.Amount, t.PricePoint), p.PairMultiplier()),
+			Fee:             to.TakeFee,
+			Amount:          amount,
+		}
+
+		err := s.rebateDao.Upsert(rebate)
+		if err != nil {
+			logger.Error(err)
+		}
+	}
+}
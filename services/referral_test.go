@@ -0,0 +1,125 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/testutils"
+	"github.com/tomochain/dex-server/utils/testutils/mocks"
+)
+
+func SetupReferralServiceTest() (*mocks.ReferralDao, *mocks.ReferralRebateDao, *mocks.PairDao, *ReferralService) {
+	referralDao := new(mocks.ReferralDao)
+	rebateDao := new(mocks.ReferralRebateDao)
+	pairDao := new(mocks.PairDao)
+
+	s := NewReferralService(referralDao, rebateDao, pairDao)
+	return referralDao, rebateDao, pairDao, s
+}
+
+func TestRegisterReferral(t *testing.T) {
+	ethereumConfig := app.Config.Ethereum
+	defer func() { app.Config.Ethereum = ethereumConfig }()
+
+	exchange := common.HexToAddress("0x10")
+	app.Config.Ethereum = map[string]string{"exchange_address": exchange.Hex()}
+
+	referee := testutils.GetTestWallet1()
+	referrer := testutils.GetTestWallet2()
+
+	tests := []struct {
+		name     string
+		signer   *types.Wallet
+		existing *types.Referral
+		parent   *types.Referral
+		err      error
+	}{
+		{"new referral", referee, nil, nil, nil},
+		{"signed by the referrer", referrer, nil, nil, ErrInvalidSignature},
+		{"already referred", referee, &types.Referral{RefereeAddress: referee.Address}, nil, ErrReferralExists},
+		{"referral cycle", referee, nil, &types.Referral{RefereeAddress: referrer.Address, ReferrerAddress: referee.Address}, ErrReferralCycle},
+	}
+
+	for _, test := range tests {
+		referralDao, _, _, s := SetupReferralServiceTest()
+
+		r := &types.ReferralRegistration{
+			ExchangeAddress: exchange,
+			ReferrerAddress: referrer.Address,
+			RefereeAddress:  referee.Address,
+		}
+
+		r.Signature, _ = test.signer.SignHash(r.ComputeHash())
+
+		referralDao.On("GetByReferee", referee.Address).Return(test.existing, nil)
+		referralDao.On("GetByReferee", referrer.Address).Return(test.parent, nil)
+		referralDao.On("Create", mock.Anything).Return(nil)
+
+		referral, err := s.Register(r)
+		if err != test.err {
+			t.Errorf("%v: expected error %v, got %v", test.name, test.err, err)
+		}
+
+		if test.err != nil {
+			referralDao.AssertNotCalled(t, "Create", mock.Anything)
+			continue
+		}
+
+		if referral.RefereeAddress != referee.Address || referral.ReferrerAddress != referrer.Address {
+			t.Errorf("%v: unexpected referral %v", test.name, referral)
+		}
+
+		referralDao.AssertCalled(t, "Create", referral)
+	}
+}
+
+func TestHandleTradesSettledRebates(t *testing.T) {
+	referralsConfig := app.Config.Referrals
+	defer func() { app.Config.Referrals = referralsConfig }()
+
+	taker := common.HexToAddress("0x1")
+	referrer := common.HexToAddress("0x2")
+	baseToken := common.HexToAddress("0x3")
+	quoteToken := common.HexToAddress("0x4")
+
+	pair := &types.Pair{BaseTokenAddress: baseToken, QuoteTokenAddress: quoteToken, BaseTokenDecimals: 18}
+	to := &types.Order{UserAddress: taker, BaseToken: baseToken, QuoteToken: quoteToken, TakeFee: big.NewInt(1000)}
+	t1 := &types.Trade{Hash: common.HexToHash("0x5"), Amount: big.NewInt(2e18), PricePoint: big.NewInt(3e18)}
+	t2 := &types.Trade{Hash: common.HexToHash("0x6"), Amount: big.NewInt(1e18), PricePoint: big.NewInt(3e18)}
+	m := types.NewMatches([]*types.Order{{}, {}}, to, []*types.Trade{t1, t2})
+
+	tests := []struct {
+		name     string
+		bps      string
+		referral *types.Referral
+		rebates  int
+	}{
+		{"referred taker", "2000", &types.Referral{RefereeAddress: taker, ReferrerAddress: referrer}, 2},
+		{"taker not referred", "2000", nil, 0},
+		{"rebates disabled", "0", &types.Referral{RefereeAddress: taker, ReferrerAddress: referrer}, 0},
+	}
+
+	for _, test := range tests {
+		referralDao, rebateDao, pairDao, s := SetupReferralServiceTest()
+		app.Config.Referrals = map[string]string{"rebate_bps": test.bps}
+
+		referralDao.On("GetByReferee", taker).Return(test.referral, nil)
+		pairDao.On("GetByTokenAddress", baseToken, quoteToken).Return(pair, nil)
+		rebateDao.On("Upsert", mock.MatchedBy(func(r *types.ReferralRebate) bool {
+			return r.ReferrerAddress == referrer &&
+				r.RefereeAddress == taker &&
+				r.QuoteToken == quoteToken &&
+				r.Amount.Cmp(big.NewInt(200)) == 0 &&
+				(r.TradeHash == t1.Hash && r.Volume.Cmp(big.NewInt(6)) == 0 ||
+					r.TradeHash == t2.Hash && r.Volume.Cmp(big.NewInt(3)) == 0)
+		})).Return(nil)
+
+		s.HandleTradesSettled(m)
+
+		rebateDao.AssertNumberOfCalls(t, "Upsert", test.rebates)
+	}
+}
@@ -72,6 +72,11 @@ func (s *TradeService) GetSortedTradesByUserAddress(a common.Address, limit ...i
 	return s.tradeDao.GetSortedTradesByUserAddress(a, limit...)
 }
 
+// GetPage returns a page of trades matching a history filter and the cursor of the next page
+func (s *TradeService) GetPage(f *types.HistoryFilter) ([]*types.Trade, *types.Cursor, error) {
+	return s.tradeDao.GetPage(f)
+}
+
 func (s *TradeService) GetSortedTrades(bt, qt common.Address, n int) ([]*types.Trade, error) {
 	return s.tradeDao.GetSortedTrades(bt, qt, n)
 }
@@ -3,40 +3,46 @@ package services
 import (
 	"fmt"
 	"math/big"
+	"time"
 
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/tomochain/dex-server/app"
 	"github.com/tomochain/dex-server/interfaces"
 	"github.com/tomochain/dex-server/types"
-	"github.com/tomochain/dex-server/utils"
 	"github.com/tomochain/dex-server/utils/math"
 )
 
+// settlementCheckAttempts is the number of times a contract call of the settlement checks is
+// attempted before the check fails
+const settlementCheckAttempts = 3
+
+// settlementCheckRetryDelay is the delay between two attempts of a settlement check contract call
+var settlementCheckRetryDelay = time.Second
+
 type ValidatorService struct {
-	ethereumProvider interfaces.EthereumProvider
-	accountDao       interfaces.AccountDao
-	orderDao         interfaces.OrderDao
-	pairDao          interfaces.PairDao
+	balanceService       interfaces.BalanceService
+	lockedBalanceService interfaces.LockedBalanceService
+	accountDao           interfaces.AccountDao
+	pairDao              interfaces.PairDao
+	exchange             interfaces.Exchange
 }
 
 func NewValidatorService(
-	ethereumProvider interfaces.EthereumProvider,
+	balanceService interfaces.BalanceService,
+	lockedBalanceService interfaces.LockedBalanceService,
 	accountDao interfaces.AccountDao,
-	orderDao interfaces.OrderDao,
 	pairDao interfaces.PairDao,
+	exchange interfaces.Exchange,
 ) *ValidatorService {
 
 	return &ValidatorService{
-		ethereumProvider,
+		balanceService,
+		lockedBalanceService,
 		accountDao,
-		orderDao,
 		pairDao,
+		exchange,
 	}
 }
 
 func (s *ValidatorService) ValidateAvailableBalance(o *types.Order) error {
-	exchangeAddress := common.HexToAddress(app.Config.Ethereum["exchange_address"])
-
 	pair, err := s.pairDao.GetByTokenAddress(o.BaseToken, o.QuoteToken)
 	if err != nil {
 		logger.Error(err)
@@ -45,45 +51,19 @@ func (s *ValidatorService) ValidateAvailableBalance(o *types.Order) error {
 
 	totalRequiredAmount := o.TotalRequiredSellAmount(pair)
 
-	// balanceRecord, err := s.accountDao.GetTokenBalances(o.UserAddress)
-	// if err != nil {
-	// 	logger.Error(err)
-	// 	return err
-	// }
-
-	var sellTokenBalance *big.Int
-	var sellTokenAllowance *big.Int
-
-	// we implement retries in the case the provider connection fell asleep
-	err = utils.Retry(3, func() error {
-		sellTokenBalance, err = s.ethereumProvider.BalanceOf(o.UserAddress, o.SellToken())
-		if err != nil {
-			return err
-		}
-
-		return nil
-	})
-
+	sellTokenBalance, err := s.balanceService.GetBalance(o.UserAddress, o.SellToken())
 	if err != nil {
 		logger.Error(err)
 		return err
 	}
 
-	err = utils.Retry(3, func() error {
-		sellTokenAllowance, err = s.ethereumProvider.Allowance(o.UserAddress, exchangeAddress, o.SellToken())
-		if err != nil {
-			return err
-		}
-
-		return nil
-	})
-
+	sellTokenAllowance, err := s.balanceService.GetAllowance(o.UserAddress, o.SellToken())
 	if err != nil {
 		logger.Error(err)
 		return err
 	}
 
-	sellTokenLockedBalance, err := s.orderDao.GetUserLockedBalance(o.UserAddress, o.SellToken(), pair)
+	sellTokenLockedBalance, err := s.lockedBalanceService.GetLockedBalance(o.UserAddress, o.SellToken())
 	if err != nil {
 		logger.Error(err)
 		return err
@@ -93,14 +73,12 @@ func (s *ValidatorService) ValidateAvailableBalance(o *types.Order) error {
 	availableSellTokenAllowance := math.Sub(sellTokenAllowance, sellTokenLockedBalance)
 
 	//Sell Token Balance
-	fmt.Println(sellTokenBalance)
-	fmt.Println(totalRequiredAmount)
 	if sellTokenBalance.Cmp(totalRequiredAmount) == -1 {
 		return fmt.Errorf("Insufficient %v Balance", o.SellTokenSymbol())
 	}
 
 	if availableSellTokenBalance.Cmp(totalRequiredAmount) == -1 {
-		return fmt.Errorf("Insufficient % available", o.SellTokenSymbol())
+		return fmt.Errorf("Insufficient %v available", o.SellTokenSymbol())
 	}
 
 	if sellTokenAllowance.Cmp(totalRequiredAmount) == -1 {
@@ -111,25 +89,10 @@ func (s *ValidatorService) ValidateAvailableBalance(o *types.Order) error {
 		return fmt.Errorf("Insufficient %v allowance available", o.SellTokenSymbol())
 	}
 
-	// sellTokenBalanceRecord := balanceRecord[o.SellToken()]
-	// if sellTokenBalanceRecord == nil {
-	// 	return errors.New("Account error: Balance record not found")
-	// }
-
-	// sellTokenBalanceRecord.Balance.Set(sellTokenBalance)
-	// sellTokenBalanceRecord.Allowance.Set(sellTokenAllowance)
-	// err = s.accountDao.UpdateTokenBalance(o.UserAddress, o.SellToken(), sellTokenBalanceRecord)
-	// if err != nil {
-	// 	logger.Error(err)
-	// 	return err
-	// }
-
 	return nil
 }
 
 func (s *ValidatorService) ValidateBalance(o *types.Order) error {
-	exchangeAddress := common.HexToAddress(app.Config.Ethereum["exchange_address"])
-
 	pair, err := s.pairDao.GetByTokenAddress(o.BaseToken, o.QuoteToken)
 	if err != nil {
 		logger.Error(err)
@@ -138,65 +101,162 @@ func (s *ValidatorService) ValidateBalance(o *types.Order) error {
 
 	totalRequiredAmount := o.TotalRequiredSellAmount(pair)
 
-	// balanceRecord, err := s.accountDao.GetTokenBalances(o.UserAddress)
-	// if err != nil {
-	// 	logger.Error(err)
-	// 	return err
-	// }
+	sellTokenBalance, err := s.balanceService.GetBalance(o.UserAddress, o.SellToken())
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
 
-	var sellTokenBalance *big.Int
-	var sellTokenAllowance *big.Int
+	sellTokenAllowance, err := s.balanceService.GetAllowance(o.UserAddress, o.SellToken())
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
 
-	// we implement retries in the case the provider connection fell asleep
-	err = utils.Retry(3, func() error {
-		sellTokenBalance, err = s.ethereumProvider.BalanceOf(o.UserAddress, o.SellToken())
-		if err != nil {
-			return err
-		}
+	//Sell Token Balance
+	if sellTokenBalance.Cmp(totalRequiredAmount) == -1 {
+		return fmt.Errorf("Insufficient %v Balance", o.SellTokenSymbol())
+	}
+
+	if sellTokenAllowance.Cmp(totalRequiredAmount) == -1 {
+		return fmt.Errorf("Insufficient %v Allowance", o.SellTokenSymbol())
+	}
+
+	return nil
+}
 
-		return nil
+// ValidateTakerSettlement runs the exchange contract checks of a taker order before its matches
+// are queued for settlement: the pair must be registered, the signature valid and the order must
+// have enough unfilled amount left on chain for the total amount matched. Failing checks return a
+// *types.SettlementError. The checks fail closed: contract calls that still fail after
+// settlementCheckAttempts attempts fail the check with the SETTLEMENT_CHECK_FAILED code
+func (s *ValidatorService) ValidateTakerSettlement(to *types.Order, amount *big.Int) error {
+	var registered bool
+	err := retryContractCall(func() (err error) {
+		registered, err = s.exchange.PairIsRegistered(to.BaseToken, to.QuoteToken)
+		return err
 	})
 
+	if err != nil {
+		return err
+	}
+
+	if !registered {
+		return types.NewSettlementError(types.ErrCodePairNotRegistered)
+	}
+
+	err = s.validateSettlementSignature(to, types.ErrCodeInvalidTakerSignature)
+	if err != nil {
+		return err
+	}
+
+	return s.validateFilledAmount(to, amount)
+}
+
+// ValidateMakerSettlement runs the exchange contract checks of a maker order and its trade before
+// they are queued for settlement: the signature must be valid, the order must have enough unfilled
+// amount left on chain and the trade amount must not cause a rounding error
+func (s *ValidatorService) ValidateMakerSettlement(mo *types.Order, t *types.Trade) error {
+	err := s.validateSettlementSignature(mo, types.ErrCodeInvalidMakerSignature)
+	if err != nil {
+		return err
+	}
+
+	err = s.validateFilledAmount(mo, t.Amount)
+	if err != nil {
+		return err
+	}
+
+	pair, err := s.pairDao.GetByTokenAddress(mo.BaseToken, mo.QuoteToken)
 	if err != nil {
 		logger.Error(err)
 		return err
 	}
 
-	err = utils.Retry(3, func() error {
-		sellTokenAllowance, err = s.ethereumProvider.Allowance(o.UserAddress, exchangeAddress, o.SellToken())
-		if err != nil {
-			return err
-		}
+	if pair == nil {
+		return ErrPairNotFound
+	}
 
-		return nil
+	var isRoundingError bool
+	err = retryContractCall(func() (err error) {
+		isRoundingError, err = s.exchange.IsRoundingError(t.Amount, pair.PricepointMultiplier(), mo.PricePoint)
+		return err
 	})
 
 	if err != nil {
-		logger.Error(err)
 		return err
 	}
 
-	//Sell Token Balance
-	if sellTokenBalance.Cmp(totalRequiredAmount) == -1 {
-		return fmt.Errorf("Insufficient %v Balance", o.SellTokenSymbol())
+	if isRoundingError {
+		return types.NewSettlementError(types.ErrCodeRoundingError)
 	}
 
-	if sellTokenAllowance.Cmp(totalRequiredAmount) == -1 {
-		return fmt.Errorf("Insufficient %v Allowance", o.SellTokenSymbol())
+	return nil
+}
+
+// validateSettlementSignature checks that the order hash matches its content and that the
+// exchange contract accepts the order signature
+func (s *ValidatorService) validateSettlementSignature(o *types.Order, code string) error {
+	if o.Signature == nil || o.ComputeHash() != o.Hash {
+		return types.NewSettlementError(code)
+	}
+
+	var valid bool
+	err := retryContractCall(func() (err error) {
+		valid, err = s.exchange.IsValidSignature(o.UserAddress, o.Hash, o.Signature)
+		return err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if !valid {
+		return types.NewSettlementError(code)
+	}
+
+	return nil
+}
+
+// validateFilledAmount checks that the amount filled on chain leaves room for the given amount
+func (s *ValidatorService) validateFilledAmount(o *types.Order, amount *big.Int) error {
+	var filled *big.Int
+	err := retryContractCall(func() (err error) {
+		filled, err = s.exchange.Filled(o.Hash)
+		return err
+	})
+
+	if err != nil {
+		return err
 	}
 
-	// sellTokenBalanceRecord := balanceRecord[o.SellToken()]
-	// if sellTokenBalanceRecord == nil {
-	// 	return errors.New("Account error: Balance record not found")
-	// }
+	if math.IsEqualOrGreaterThan(filled, o.Amount) {
+		return types.NewSettlementError(types.ErrCodeTradeAlreadyCompleted)
+	}
 
-	// sellTokenBalanceRecord.Balance.Set(sellTokenBalance)
-	// sellTokenBalanceRecord.Allowance.Set(sellTokenAllowance)
-	// err = s.accountDao.UpdateTokenBalance(o.UserAddress, o.SellToken(), sellTokenBalanceRecord)
-	// if err != nil {
-	// 	logger.Error(err)
-	// 	return err
-	// }
+	if math.IsGreaterThan(math.Add(filled, amount), o.Amount) {
+		return types.NewSettlementError(types.ErrCodeTradeAmountTooBig)
+	}
 
 	return nil
 }
+
+// retryContractCall runs a contract call of the settlement checks until it succeeds, at most
+// settlementCheckAttempts times. A call that keeps failing returns the SETTLEMENT_CHECK_FAILED
+// settlement error, so that the matches are not settled without being checked
+func retryContractCall(call func() error) error {
+	var err error
+	for i := 0; i < settlementCheckAttempts; i++ {
+		if i > 0 {
+			time.Sleep(settlementCheckRetryDelay)
+		}
+
+		err = call()
+		if err == nil {
+			return nil
+		}
+	}
+
+	logger.Errorf("Settlement check failed after %v attempts: %v", settlementCheckAttempts, err)
+	return types.NewSettlementError(types.ErrCodeSettlementCheckFailed)
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tomochain/dex-server/errors"
+	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/testutils"
+	"github.com/tomochain/dex-server/utils/testutils/mocks"
+)
+
+func SetupValidatorServiceTest(t *testing.T) (*ValidatorService, *mocks.Exchange, *mocks.PairDao, *types.Order) {
+	settlementCheckRetryDelay = 0
+
+	exchange := new(mocks.Exchange)
+	pairDao := new(mocks.PairDao)
+	s := NewValidatorService(nil, nil, nil, pairDao, exchange)
+
+	o := testutils.GetTestOrder1()
+	err := o.Sign(testutils.GetTestWallet1())
+	if err != nil {
+		t.Fatalf("Could not sign order: %v", err)
+	}
+
+	o.UserAddress = testutils.GetTestWallet1().Address
+	return s, exchange, pairDao, &o
+}
+
+func TestValidateTakerSettlementFailsClosed(t *testing.T) {
+	s, exchange, _, o := SetupValidatorServiceTest(t)
+
+	exchange.On("PairIsRegistered", o.BaseToken, o.QuoteToken).Return(false, errors.New("connection refused"))
+
+	err := s.ValidateTakerSettlement(o, big.NewInt(100))
+	e, ok := err.(*types.SettlementError)
+	if !ok || e.Code != types.ErrCodeSettlementCheckFailed {
+		t.Errorf("Expected a SETTLEMENT_CHECK_FAILED settlement error, got %v", err)
+	}
+
+	exchange.AssertNumberOfCalls(t, "PairIsRegistered", settlementCheckAttempts)
+	exchange.AssertNotCalled(t, "IsValidSignature", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestValidateTakerSettlementRetries(t *testing.T) {
+	s, exchange, _, o := SetupValidatorServiceTest(t)
+
+	exchange.On("PairIsRegistered", o.BaseToken, o.QuoteToken).Return(false, errors.New("connection refused")).Once()
+	exchange.On("PairIsRegistered", o.BaseToken, o.QuoteToken).Return(true, nil)
+	exchange.On("IsValidSignature", o.UserAddress, o.Hash, o.Signature).Return(true, nil)
+	exchange.On("Filled", o.Hash).Return(big.NewInt(0), nil)
+
+	err := s.ValidateTakerSettlement(o, big.NewInt(100))
+	if err != nil {
+		t.Errorf("Expected the taker to be valid, got %v", err)
+	}
+
+	exchange.AssertNumberOfCalls(t, "PairIsRegistered", 2)
+}
+
+func TestValidateMakerSettlementFailsClosed(t *testing.T) {
+	tests := map[string]func(*mocks.Exchange, *types.Order){
+		"signature": func(exchange *mocks.Exchange, o *types.Order) {
+			exchange.On("IsValidSignature", o.UserAddress, o.Hash, o.Signature).Return(false, errors.New("timeout"))
+		},
+		"filled amount": func(exchange *mocks.Exchange, o *types.Order) {
+			exchange.On("IsValidSignature", o.UserAddress, o.Hash, o.Signature).Return(true, nil)
+			exchange.On("Filled", o.Hash).Return(nil, errors.New("timeout"))
+		},
+		"rounding error": func(exchange *mocks.Exchange, o *types.Order) {
+			exchange.On("IsValidSignature", o.UserAddress, o.Hash, o.Signature).Return(true, nil)
+			exchange.On("Filled", o.Hash).Return(big.NewInt(0), nil)
+			exchange.On("IsRoundingError", mock.Anything, mock.Anything, mock.Anything).Return(false, errors.New("timeout"))
+		},
+	}
+
+	for name, setup := range tests {
+		s, exchange, pairDao, o := SetupValidatorServiceTest(t)
+
+		pair := &types.Pair{BaseTokenDecimals: 18, QuoteTokenDecimals: 18}
+		pairDao.On("GetByTokenAddress", o.BaseToken, o.QuoteToken).Return(pair, nil)
+		setup(exchange, o)
+
+		err := s.ValidateMakerSettlement(o, &types.Trade{Amount: big.NewInt(100)})
+		e, ok := err.(*types.SettlementError)
+		if !ok || e.Code != types.ErrCodeSettlementCheckFailed {
+			t.Errorf("%v: expected a SETTLEMENT_CHECK_FAILED settlement error, got %v", name, err)
+		}
+	}
+}
@@ -17,6 +17,7 @@ type Account struct {
 	Address       common.Address                   `json:"address" bson:"address"`
 	TokenBalances map[common.Address]*TokenBalance `json:"tokenBalances" bson:"tokenBalances"`
 	IsBlocked     bool                             `json:"isBlocked" bson:"isBlocked"`
+	Restriction   *AccountRestriction              `json:"restriction" bson:"restriction"`
 	CreatedAt     time.Time                        `json:"createdAt" bson:"createdAt"`
 	UpdatedAt     time.Time                        `json:"updatedAt" bson:"updatedAt"`
 }
@@ -38,6 +39,7 @@ type AccountRecord struct {
 	Address       string                        `json:"address" bson:"address"`
 	TokenBalances map[string]TokenBalanceRecord `json:"tokenBalances" bson:"tokenBalances"`
 	IsBlocked     bool                          `json:"isBlocked" bson:"isBlocked"`
+	Restriction   *AccountRestriction           `json:"restriction" bson:"restriction,omitempty"`
 	CreatedAt     time.Time                     `json:"createdAt" bson:"createdAt"`
 	UpdatedAt     time.Time                     `json:"updatedAt" bson:"updatedAt"`
 }
@@ -55,8 +57,9 @@ type TokenBalanceRecord struct {
 // GetBSON implements bson.Getter
 func (a *Account) GetBSON() (interface{}, error) {
 	ar := AccountRecord{
-		IsBlocked: a.IsBlocked,
-		Address:   a.Address.Hex(),
+		IsBlocked:   a.IsBlocked,
+		Restriction: a.Restriction,
+		Address:     a.Address.Hex(),
 	}
 
 	tokenBalances := make(map[string]TokenBalanceRecord)
@@ -65,10 +68,10 @@ func (a *Account) GetBSON() (interface{}, error) {
 		tokenBalances[key.Hex()] = TokenBalanceRecord{
 			Address:        value.Address.Hex(),
 			Symbol:         value.Symbol,
-			Balance:        value.Balance.String(),
-			Allowance:      value.Allowance.String(),
-			LockedBalance:  value.LockedBalance.String(),
-			PendingBalance: value.PendingBalance.String(),
+			Balance:        balanceRecordValue(value.Balance),
+			Allowance:      balanceRecordValue(value.Allowance),
+			LockedBalance:  balanceRecordValue(value.LockedBalance),
+			PendingBalance: balanceRecordValue(value.PendingBalance),
 		}
 	}
 
@@ -101,6 +104,11 @@ func (a *Account) SetBSON(raw bson.Raw) error {
 		allowance, _ = allowance.SetString(value.Allowance, 10)
 		lockedBalance := new(big.Int)
 		lockedBalance, _ = lockedBalance.SetString(value.LockedBalance, 10)
+		if lockedBalance == nil {
+			// nothing is locked until the account places an order with the token
+			lockedBalance = big.NewInt(0)
+		}
+
 		pendingBalance := new(big.Int)
 		pendingBalance, _ = pendingBalance.SetString(value.PendingBalance, 10)
 
@@ -117,6 +125,7 @@ func (a *Account) SetBSON(raw bson.Raw) error {
 	a.Address = common.HexToAddress(decoded.Address)
 	a.ID = decoded.ID
 	a.IsBlocked = decoded.IsBlocked
+	a.Restriction = decoded.Restriction
 	a.CreatedAt = decoded.CreatedAt
 	a.UpdatedAt = decoded.UpdatedAt
 
@@ -128,11 +137,16 @@ func (a *Account) SetBSON(raw bson.Raw) error {
 // MarshalJSON implements the json.Marshal interface
 func (a *Account) MarshalJSON() ([]byte, error) {
 	account := map[string]interface{}{
-		"id":        a.ID,
-		"address":   a.Address,
-		"isBlocked": a.IsBlocked,
-		"createdAt": a.CreatedAt.String(),
-		"updatedAt": a.UpdatedAt.String(),
+		"id":               a.ID,
+		"address":          a.Address,
+		"isBlocked":        a.IsBlocked,
+		"restrictionLevel": a.RestrictionLevel(),
+		"createdAt":        a.CreatedAt.String(),
+		"updatedAt":        a.UpdatedAt.String(),
+	}
+
+	if a.Restriction != nil {
+		account["restriction"] = a.Restriction
 	}
 
 	tokenBalance := make(map[string]interface{})
@@ -141,8 +155,8 @@ func (a *Account) MarshalJSON() ([]byte, error) {
 		tokenBalance[address.Hex()] = map[string]interface{}{
 			"address":        balance.Address.Hex(),
 			"symbol":         balance.Symbol,
-			"balance":        balance.Balance.String(),
-			"allowance":      balance.Allowance.String(),
+			"balance":        balanceJSONValue(balance.Balance),
+			"allowance":      balanceJSONValue(balance.Allowance),
 			"lockedBalance":  balance.LockedBalance.String(),
 			"pendingBalance": balance.PendingBalance.String(),
 		}
@@ -234,10 +248,10 @@ func (a *AccountBSONUpdate) GetBSON() (interface{}, error) {
 		tokenBalances[key.Hex()] = TokenBalanceRecord{
 			Address:        value.Address.Hex(),
 			Symbol:         value.Symbol,
-			Balance:        value.Balance.String(),
-			Allowance:      value.Allowance.String(),
-			LockedBalance:  value.LockedBalance.String(),
-			PendingBalance: value.PendingBalance.String(),
+			Balance:        balanceRecordValue(value.Balance),
+			Allowance:      balanceRecordValue(value.Allowance),
+			LockedBalance:  balanceRecordValue(value.LockedBalance),
+			PendingBalance: balanceRecordValue(value.PendingBalance),
 		}
 	}
 
@@ -258,3 +272,22 @@ func (a *AccountBSONUpdate) GetBSON() (interface{}, error) {
 
 	return update, nil
 }
+
+// balanceRecordValue encodes a balance for the DB. Unknown balances are stored as empty
+// strings so that they are decoded as nil
+func balanceRecordValue(b *big.Int) string {
+	if b == nil {
+		return ""
+	}
+
+	return b.String()
+}
+
+// balanceJSONValue encodes a balance that might not be known yet
+func balanceJSONValue(b *big.Int) interface{} {
+	if b == nil {
+		return nil
+	}
+
+	return b.String()
+}
@@ -0,0 +1,204 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Account restriction levels, from the least to the most restrictive:
+// ACTIVE accounts are not restricted, CANCEL_ONLY accounts can only cancel their orders and
+// deposit, WITHDRAW_ONLY accounts can only cancel their orders and BLOCKED accounts can do nothing
+const (
+	ACCOUNT_ACTIVE        = "ACTIVE"
+	ACCOUNT_CANCEL_ONLY   = "CANCEL_ONLY"
+	ACCOUNT_WITHDRAW_ONLY = "WITHDRAW_ONLY"
+	ACCOUNT_BLOCKED       = "BLOCKED"
+)
+
+// Actions checked against the restriction level of an account
+const (
+	ACCOUNT_ACTION_TRADE   = "TRADE"
+	ACCOUNT_ACTION_CANCEL  = "CANCEL"
+	ACCOUNT_ACTION_DEPOSIT = "DEPOSIT"
+)
+
+var accountRestrictionActions = map[string][]string{
+	ACCOUNT_ACTIVE:        []string{ACCOUNT_ACTION_TRADE, ACCOUNT_ACTION_CANCEL, ACCOUNT_ACTION_DEPOSIT},
+	ACCOUNT_CANCEL_ONLY:   []string{ACCOUNT_ACTION_CANCEL, ACCOUNT_ACTION_DEPOSIT},
+	ACCOUNT_WITHDRAW_ONLY: []string{ACCOUNT_ACTION_CANCEL},
+	ACCOUNT_BLOCKED:       []string{},
+}
+
+// AccountRestriction is the restriction level of an account. The account is not restricted
+// anymore once the restriction expires. A zero expiry never expires
+type AccountRestriction struct {
+	Level     string    `json:"level" bson:"level"`
+	Reason    string    `json:"reason" bson:"reason"`
+	ExpiresAt time.Time `json:"expiresAt" bson:"expiresAt"`
+	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// Validate checks the level and expiry of a restriction
+func (r *AccountRestriction) Validate() error {
+	if _, ok := accountRestrictionActions[r.Level]; !ok {
+		return fmt.Errorf("Invalid restriction level: %v", r.Level)
+	}
+
+	if r.Level != ACCOUNT_ACTIVE && r.Reason == "" {
+		return errors.New("Restriction 'reason' parameter is required")
+	}
+
+	if !r.ExpiresAt.IsZero() && r.ExpiresAt.Before(time.Now()) {
+		return errors.New("Restriction 'expiresAt' parameter should be in the future")
+	}
+
+	return nil
+}
+
+func (r *AccountRestriction) MarshalJSON() ([]byte, error) {
+	restriction := map[string]interface{}{
+		"level":     r.Level,
+		"reason":    r.Reason,
+		"updatedAt": r.UpdatedAt.Format(time.RFC3339Nano),
+	}
+
+	if !r.ExpiresAt.IsZero() {
+		restriction["expiresAt"] = r.ExpiresAt.Format(time.RFC3339Nano)
+	}
+
+	return json.Marshal(restriction)
+}
+
+// IsExpired returns true if the restriction has an expiry that is passed
+func (r *AccountRestriction) IsExpired() bool {
+	return !r.ExpiresAt.IsZero() && r.ExpiresAt.Before(time.Now())
+}
+
+// RestrictionLevel returns the current restriction level of an account. Accounts that were
+// blocked before restriction levels were introduced are blocked
+func (a *Account) RestrictionLevel() string {
+	if a == nil {
+		return ACCOUNT_ACTIVE
+	}
+
+	if a.Restriction == nil || a.Restriction.Level == "" {
+		if a.IsBlocked {
+			return ACCOUNT_BLOCKED
+		}
+
+		return ACCOUNT_ACTIVE
+	}
+
+	if a.Restriction.IsExpired() {
+		return ACCOUNT_ACTIVE
+	}
+
+	return a.Restriction.Level
+}
+
+// CheckRestriction returns an error if the restriction level of an account does not allow an action.
+// Accounts that are not stored are not restricted
+func (a *Account) CheckRestriction(action string) error {
+	level := a.RestrictionLevel()
+	for _, allowed := range accountRestrictionActions[level] {
+		if allowed == action {
+			return nil
+		}
+	}
+
+	if level == ACCOUNT_BLOCKED {
+		return fmt.Errorf("Account is blocked (%v)", a.restrictionReason())
+	}
+
+	return fmt.Errorf("Account is restricted to %v (%v)", level, a.restrictionReason())
+}
+
+func (a *Account) restrictionReason() string {
+	if a.Restriction == nil || a.Restriction.Reason == "" {
+		return "no reason given"
+	}
+
+	return a.Restriction.Reason
+}
+
+// AccountRestrictionChange is the audit log entry of a change of the restriction level of an account
+type AccountRestrictionChange struct {
+	ID            bson.ObjectId  `json:"id" bson:"_id"`
+	Address       common.Address `json:"address" bson:"address"`
+	PreviousLevel string         `json:"previousLevel" bson:"previousLevel"`
+	Level         string         `json:"level" bson:"level"`
+	Reason        string         `json:"reason" bson:"reason"`
+	ExpiresAt     time.Time      `json:"expiresAt" bson:"expiresAt"`
+	Source        string         `json:"source" bson:"source"`
+	CreatedAt     time.Time      `json:"createdAt" bson:"createdAt"`
+}
+
+// AccountRestrictionChangeRecord is the struct which is stored in db
+type AccountRestrictionChangeRecord struct {
+	ID            bson.ObjectId `json:"id" bson:"_id"`
+	Address       string        `json:"address" bson:"address"`
+	PreviousLevel string        `json:"previousLevel" bson:"previousLevel"`
+	Level         string        `json:"level" bson:"level"`
+	Reason        string        `json:"reason" bson:"reason"`
+	ExpiresAt     time.Time     `json:"expiresAt" bson:"expiresAt"`
+	Source        string        `json:"source" bson:"source"`
+	CreatedAt     time.Time     `json:"createdAt" bson:"createdAt"`
+}
+
+func (c *AccountRestrictionChange) MarshalJSON() ([]byte, error) {
+	change := map[string]interface{}{
+		"id":            c.ID,
+		"address":       c.Address.Hex(),
+		"previousLevel": c.PreviousLevel,
+		"level":         c.Level,
+		"reason":        c.Reason,
+		"source":        c.Source,
+		"createdAt":     c.CreatedAt.Format(time.RFC3339Nano),
+	}
+
+	if !c.ExpiresAt.IsZero() {
+		change["expiresAt"] = c.ExpiresAt.Format(time.RFC3339Nano)
+	}
+
+	return json.Marshal(change)
+}
+
+// GetBSON implements bson.Getter
+func (c *AccountRestrictionChange) GetBSON() (interface{}, error) {
+	return AccountRestrictionChangeRecord{
+		ID:            c.ID,
+		Address:       c.Address.Hex(),
+		PreviousLevel: c.PreviousLevel,
+		Level:         c.Level,
+		Reason:        c.Reason,
+		ExpiresAt:     c.ExpiresAt,
+		Source:        c.Source,
+		CreatedAt:     c.CreatedAt,
+	}, nil
+}
+
+// SetBSON implements bson.Setter
+func (c *AccountRestrictionChange) SetBSON(raw bson.Raw) error {
+	decoded := &AccountRestrictionChangeRecord{}
+
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	c.ID = decoded.ID
+	c.Address = common.HexToAddress(decoded.Address)
+	c.PreviousLevel = decoded.PreviousLevel
+	c.Level = decoded.Level
+	c.Reason = decoded.Reason
+	c.ExpiresAt = decoded.ExpiresAt
+	c.Source = decoded.Source
+	c.CreatedAt = decoded.CreatedAt
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Exchange governance methods recorded in the admin transactions ledger
+const (
+	SET_FEE_ACCOUNT = "SET_FEE_ACCOUNT"
+	SET_OPERATOR    = "SET_OPERATOR"
+	SET_OWNER       = "SET_OWNER"
+	SET_WETH_TOKEN  = "SET_WETH_TOKEN"
+	REGISTER_PAIR   = "REGISTER_PAIR"
+)
+
+// AdminTransaction records a governance transaction sent to the exchange
+// smart contract from the admin wallet
+type AdminTransaction struct {
+	ID        bson.ObjectId     `json:"id" bson:"_id"`
+	Method    string            `json:"method" bson:"method"`
+	Params    map[string]string `json:"params" bson:"params"`
+	Sender    common.Address    `json:"sender" bson:"sender"`
+	TxHash    common.Hash       `json:"txHash" bson:"txHash"`
+	Status    string            `json:"status" bson:"status"`
+	CreatedAt time.Time         `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt" bson:"updatedAt"`
+}
+
+// AdminTransactionRecord is the struct which is stored in db
+type AdminTransactionRecord struct {
+	ID        bson.ObjectId     `json:"id" bson:"_id"`
+	Method    string            `json:"method" bson:"method"`
+	Params    map[string]string `json:"params" bson:"params"`
+	Sender    string            `json:"sender" bson:"sender"`
+	TxHash    string            `json:"txHash" bson:"txHash"`
+	Status    string            `json:"status" bson:"status"`
+	CreatedAt time.Time         `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt" bson:"updatedAt"`
+}
+
+func (t *AdminTransaction) MarshalJSON() ([]byte, error) {
+	tx := map[string]interface{}{
+		"id":        t.ID,
+		"method":    t.Method,
+		"params":    t.Params,
+		"sender":    t.Sender.Hex(),
+		"txHash":    t.TxHash.Hex(),
+		"status":    t.Status,
+		"createdAt": t.CreatedAt.Format(time.RFC3339Nano),
+		"updatedAt": t.UpdatedAt.Format(time.RFC3339Nano),
+	}
+
+	return json.Marshal(tx)
+}
+
+// GetBSON implements bson.Getter
+func (t *AdminTransaction) GetBSON() (interface{}, error) {
+	return AdminTransactionRecord{
+		ID:        t.ID,
+		Method:    t.Method,
+		Params:    t.Params,
+		Sender:    t.Sender.Hex(),
+		TxHash:    t.TxHash.Hex(),
+		Status:    t.Status,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}, nil
+}
+
+// SetBSON implements bson.Setter
+func (t *AdminTransaction) SetBSON(raw bson.Raw) error {
+	decoded := &AdminTransactionRecord{}
+
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	t.ID = decoded.ID
+	t.Method = decoded.Method
+	t.Params = decoded.Params
+	t.Sender = common.HexToAddress(decoded.Sender)
+	t.TxHash = common.HexToHash(decoded.TxHash)
+	t.Status = decoded.Status
+	t.CreatedAt = decoded.CreatedAt
+	t.UpdatedAt = decoded.UpdatedAt
+	return nil
+}
@@ -0,0 +1,261 @@
+package types
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Scopes of the API keys. A trade key can also cancel orders, and all the keys can read the
+// private data of the account
+const (
+	API_KEY_SCOPE_READ   = "read"
+	API_KEY_SCOPE_CANCEL = "cancel"
+	API_KEY_SCOPE_TRADE  = "trade"
+)
+
+var apiKeyScopeLevels = map[string]int{
+	API_KEY_SCOPE_READ:   1,
+	API_KEY_SCOPE_CANCEL: 2,
+	API_KEY_SCOPE_TRADE:  3,
+}
+
+// ErrAPIKeysDisabled is returned when API keys are created or verified while no API key encryption
+// key is configured
+var ErrAPIKeysDisabled = errors.New("API keys are disabled")
+
+// APIKey authenticates the requests of an account signed with its secret. The secret is the key
+// of the HMAC signatures of the requests. It is stored encrypted with the API key encryption key of
+// the server, so that reading the database is not enough to sign requests
+type APIKey struct {
+	Key             string         `json:"key" bson:"key"`
+	UserAddress     common.Address `json:"userAddress" bson:"userAddress"`
+	Label           string         `json:"label" bson:"label"`
+	Scope           string         `json:"scope" bson:"scope"`
+	EncryptedSecret string         `json:"-" bson:"encryptedSecret"`
+	AllowedIPs      []string       `json:"allowedIps" bson:"allowedIps"`
+	CreatedAt       time.Time      `json:"createdAt" bson:"createdAt"`
+}
+
+// APIKeyRecord is the struct which is stored in db
+type APIKeyRecord struct {
+	Key             string    `json:"key" bson:"key"`
+	UserAddress     string    `json:"userAddress" bson:"userAddress"`
+	Label           string    `json:"label" bson:"label"`
+	Scope           string    `json:"scope" bson:"scope"`
+	EncryptedSecret string    `json:"encryptedSecret" bson:"encryptedSecret"`
+	AllowedIPs      []string  `json:"allowedIps" bson:"allowedIps"`
+	CreatedAt       time.Time `json:"createdAt" bson:"createdAt"`
+}
+
+// APIKeyRequest is a request signed with an API key. A signature can only be used once
+type APIKeyRequest struct {
+	Key       string
+	Timestamp string
+	Signature string
+	Method    string
+	URI       string
+	Body      []byte
+	IP        net.IP
+}
+
+// ComputeAPISignature returns the hex encoded HMAC-SHA256 signature of a request, keyed with the
+// API secret, over the timestamp, the method, the request URI and the body
+func ComputeAPISignature(secret, timestamp, method, uri string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte(strings.ToUpper(method)))
+	mac.Write([]byte(uri))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Validate checks the parameters of an API key
+func (k *APIKey) Validate() error {
+	if apiKeyScopeLevels[k.Scope] == 0 {
+		return errors.New("APIKey 'scope' parameter should be read, cancel or trade")
+	}
+
+	if len(k.Label) > 64 {
+		return errors.New("APIKey 'label' parameter should not exceed 64 characters")
+	}
+
+	for _, ip := range k.AllowedIPs {
+		if parseIPNet(ip) == nil {
+			return errors.New("APIKey 'allowedIps' parameter contains an invalid IP address: " + ip)
+		}
+	}
+
+	return nil
+}
+
+// SetSecret encrypts the secret of the key with the configured API key encryption key. The public
+// key is authenticated with the secret, so that an encrypted secret can not be moved to another key
+func (k *APIKey) SetSecret(secret string) error {
+	aead, err := apiKeyCipher()
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return err
+	}
+
+	k.EncryptedSecret = hex.EncodeToString(aead.Seal(nonce, nonce, []byte(secret), []byte(k.Key)))
+	return nil
+}
+
+// Secret decrypts the secret of the key
+func (k *APIKey) Secret() (string, error) {
+	aead, err := apiKeyCipher()
+	if err != nil {
+		return "", err
+	}
+
+	b, err := hex.DecodeString(k.EncryptedSecret)
+	if err != nil || len(b) < aead.NonceSize() {
+		return "", errors.New("Invalid encrypted secret")
+	}
+
+	secret, err := aead.Open(nil, b[:aead.NonceSize()], b[aead.NonceSize():], []byte(k.Key))
+	if err != nil {
+		return "", err
+	}
+
+	return string(secret), nil
+}
+
+// VerifySignature checks the HMAC signature of a request
+func (k *APIKey) VerifySignature(signature, timestamp, method, uri string, body []byte) bool {
+	secret, err := k.Secret()
+	if err != nil {
+		return false
+	}
+
+	expected := ComputeAPISignature(secret, timestamp, method, uri, body)
+	return hmac.Equal([]byte(expected), []byte(strings.ToLower(signature)))
+}
+
+// AllowsIP returns true if the key has no IP allowlist or if the IP is one of its addresses or ranges
+func (k *APIKey) AllowsIP(ip net.IP) bool {
+	if len(k.AllowedIPs) == 0 {
+		return true
+	}
+
+	if ip == nil {
+		return false
+	}
+
+	for _, allowed := range k.AllowedIPs {
+		n := parseIPNet(allowed)
+		if n != nil && n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// apiKeyCipher returns the AES-256-GCM cipher of the API secrets, keyed with the SHA-256 hash of the
+// configured API key encryption key
+func apiKeyCipher() (cipher.AEAD, error) {
+	if app.Config.APIKeyEncryptionKey == "" {
+		return nil, ErrAPIKeysDisabled
+	}
+
+	key := sha256.Sum256([]byte(app.Config.APIKeyEncryptionKey))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// parseIPNet parses an IP address or a CIDR range, a single address being a range of one address
+func parseIPNet(s string) *net.IPNet {
+	if strings.Contains(s, "/") {
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil
+		}
+
+		return n
+	}
+
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil
+	}
+
+	if ip.To4() != nil {
+		return &net.IPNet{IP: ip.To4(), Mask: net.CIDRMask(32, 32)}
+	}
+
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}
+}
+
+func (k *APIKey) MarshalJSON() ([]byte, error) {
+	allowedIPs := k.AllowedIPs
+	if allowedIPs == nil {
+		allowedIPs = []string{}
+	}
+
+	key := map[string]interface{}{
+		"key":         k.Key,
+		"userAddress": k.UserAddress.Hex(),
+		"label":       k.Label,
+		"scope":       k.Scope,
+		"allowedIps":  allowedIPs,
+		"createdAt":   k.CreatedAt.Format(time.RFC3339Nano),
+	}
+
+	return json.Marshal(key)
+}
+
+// GetBSON implements bson.Getter
+func (k *APIKey) GetBSON() (interface{}, error) {
+	return APIKeyRecord{
+		Key:             k.Key,
+		UserAddress:     k.UserAddress.Hex(),
+		Label:           k.Label,
+		Scope:           k.Scope,
+		EncryptedSecret: k.EncryptedSecret,
+		AllowedIPs:      k.AllowedIPs,
+		CreatedAt:       k.CreatedAt,
+	}, nil
+}
+
+// SetBSON implements bson.Setter
+func (k *APIKey) SetBSON(raw bson.Raw) error {
+	decoded := &APIKeyRecord{}
+
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	k.Key = decoded.Key
+	k.UserAddress = common.HexToAddress(decoded.UserAddress)
+	k.Label = decoded.Label
+	k.Scope = decoded.Scope
+	k.EncryptedSecret = decoded.EncryptedSecret
+	k.AllowedIPs = decoded.AllowedIPs
+	k.CreatedAt = decoded.CreatedAt
+
+	return nil
+}
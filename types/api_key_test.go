@@ -0,0 +1,137 @@
+package types
+
+import (
+	"encoding/hex"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-test/deep"
+	"github.com/tomochain/dex-server/app"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestAPIKeyBSON(t *testing.T) {
+	expected := &APIKey{
+		Key:             "8f6a8c1e1d4d4a0b9b4fb3cbb5d4a2e1",
+		UserAddress:     common.HexToAddress("0x7a9f3cd060ab180f36c17fe6bdf9974f577d77aa"),
+		Label:           "market maker",
+		Scope:           API_KEY_SCOPE_CANCEL,
+		EncryptedSecret: "0102",
+		AllowedIPs:      []string{"10.0.0.0/8"},
+		CreatedAt:       time.Unix(1530000000, 0).UTC(),
+	}
+
+	encoded, err := bson.Marshal(expected)
+	if err != nil {
+		t.Errorf("Error encoding API key: %v", err)
+	}
+
+	decoded := &APIKey{}
+	err = bson.Unmarshal(encoded, decoded)
+	if err != nil {
+		t.Errorf("Error decoding API key: %v", err)
+	}
+
+	decoded.CreatedAt = decoded.CreatedAt.UTC()
+	if diff := deep.Equal(expected, decoded); diff != nil {
+		t.Errorf("Decoded API key is different: %v", diff)
+	}
+}
+
+func TestAPIKeySecret(t *testing.T) {
+	app.Config.APIKeyEncryptionKey = "test-api-key-encryption-key"
+
+	k := &APIKey{Key: "8f6a8c1e1d4d4a0b9b4fb3cbb5d4a2e1"}
+	err := k.SetSecret("secret")
+	if err != nil {
+		t.Fatalf("Error encrypting API secret: %v", err)
+	}
+
+	if strings.Contains(k.EncryptedSecret, hex.EncodeToString([]byte("secret"))) {
+		t.Errorf("Expected the secret to be encrypted")
+	}
+
+	secret, err := k.Secret()
+	if err != nil || secret != "secret" {
+		t.Errorf("Expected the decrypted secret, got %v (%v)", secret, err)
+	}
+
+	moved := &APIKey{Key: "another key", EncryptedSecret: k.EncryptedSecret}
+	_, err = moved.Secret()
+	if err == nil {
+		t.Errorf("Expected the secret of another key to be refused")
+	}
+
+	app.Config.APIKeyEncryptionKey = "another-encryption-key"
+	_, err = k.Secret()
+	if err == nil {
+		t.Errorf("Expected the secret to be refused with another encryption key")
+	}
+
+	app.Config.APIKeyEncryptionKey = ""
+	err = k.SetSecret("secret")
+	if err != ErrAPIKeysDisabled {
+		t.Errorf("Expected API keys to be disabled without encryption key, got %v", err)
+	}
+}
+
+func TestAPIKeyVerifySignature(t *testing.T) {
+	app.Config.APIKeyEncryptionKey = "test-api-key-encryption-key"
+
+	k := &APIKey{Key: "8f6a8c1e1d4d4a0b9b4fb3cbb5d4a2e1"}
+	err := k.SetSecret("secret")
+	if err != nil {
+		t.Fatalf("Error encrypting API secret: %v", err)
+	}
+
+	body := []byte(`{"orderHash":"0x1"}`)
+	sig := ComputeAPISignature("secret", "1530000000", "DELETE", "/orders/0x1", body)
+
+	if !k.VerifySignature(sig, "1530000000", "delete", "/orders/0x1", body) {
+		t.Errorf("Expected the signature to be valid")
+	}
+
+	if k.VerifySignature(sig, "1530000001", "DELETE", "/orders/0x1", body) {
+		t.Errorf("Expected the signature of another timestamp to be invalid")
+	}
+
+	if k.VerifySignature(sig, "1530000000", "DELETE", "/orders/0x1", []byte("{}")) {
+		t.Errorf("Expected the signature of another body to be invalid")
+	}
+}
+
+func TestAPIKeyAllowsIP(t *testing.T) {
+	k := &APIKey{AllowedIPs: []string{"10.0.0.0/8", "192.168.1.10"}}
+
+	tests := map[string]bool{
+		"10.1.2.3":     true,
+		"192.168.1.10": true,
+		"192.168.1.11": false,
+		"8.8.8.8":      false,
+	}
+
+	for ip, expected := range tests {
+		if k.AllowsIP(net.ParseIP(ip)) != expected {
+			t.Errorf("Expected AllowsIP(%v) to be %v", ip, expected)
+		}
+	}
+
+	if !(&APIKey{}).AllowsIP(net.ParseIP("8.8.8.8")) {
+		t.Errorf("Expected a key without allowlist to allow all the addresses")
+	}
+}
+
+func TestAuthenticationAllows(t *testing.T) {
+	cancelKey := &Authentication{APIKey: "key", Scope: API_KEY_SCOPE_CANCEL}
+	if !cancelKey.Allows(API_KEY_SCOPE_READ) || !cancelKey.Allows(API_KEY_SCOPE_CANCEL) || cancelKey.Allows(API_KEY_SCOPE_TRADE) {
+		t.Errorf("Expected a cancel key to read and cancel but not trade")
+	}
+
+	token := &Authentication{Role: AUTH_ROLE_USER}
+	if !token.Allows(API_KEY_SCOPE_TRADE) {
+		t.Errorf("Expected a token to be allowed all the scopes")
+	}
+}
@@ -0,0 +1,361 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/sha3"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Roles granted by the authentication tokens
+const (
+	AUTH_ROLE_USER  = "user"
+	AUTH_ROLE_ADMIN = "admin"
+)
+
+// ErrJWTDisabled is returned when tokens are issued or verified while no JWT key is configured,
+// or while the public sample key is configured
+var ErrJWTDisabled = errors.New("JWT authentication is disabled")
+
+// AuthChallenge is the nonce an account has to sign to log in. A challenge can only be used once
+type AuthChallenge struct {
+	Address   common.Address `json:"address" bson:"address"`
+	Nonce     common.Hash    `json:"nonce" bson:"nonce"`
+	ExpiresAt time.Time      `json:"expiresAt" bson:"expiresAt"`
+}
+
+// AuthChallengeRecord is the struct which is stored in db
+type AuthChallengeRecord struct {
+	Address   string    `json:"address" bson:"address"`
+	Nonce     string    `json:"nonce" bson:"nonce"`
+	ExpiresAt time.Time `json:"expiresAt" bson:"expiresAt"`
+}
+
+// AuthLogin is the signature of a challenge by the account logging in
+type AuthLogin struct {
+	Address   common.Address `json:"address"`
+	Nonce     common.Hash    `json:"nonce"`
+	Signature *Signature     `json:"signature"`
+}
+
+// PrivateSubscription is the payload of a websocket subscription to the private events of an
+// account. The ownership of the account is proven by a JWT or by the signature of a login challenge.
+// An empty subscription relies on the credentials of the websocket handshake
+type PrivateSubscription struct {
+	Token string
+	Login *AuthLogin
+}
+
+// AuthClaims are the claims of the JWTs issued on login. The subject is the address of the account
+type AuthClaims struct {
+	Role string `json:"role"`
+	jwt.StandardClaims
+}
+
+// ComputeAuthHash computes the hash an account signs to answer a login challenge
+func ComputeAuthHash(addr common.Address, nonce common.Hash) common.Hash {
+	sha := sha3.NewKeccak256()
+	sha.Write([]byte(LOGIN_SIGNATURE_DOMAIN))
+	sha.Write(common.HexToAddress(app.Config.Ethereum["exchange_address"]).Bytes())
+	sha.Write(addr.Bytes())
+	sha.Write(nonce.Bytes())
+	return common.BytesToHash(sha.Sum(nil))
+}
+
+// IsExpired returns true if the challenge can not be used to log in anymore
+func (c *AuthChallenge) IsExpired() bool {
+	return time.Now().After(c.ExpiresAt)
+}
+
+func (c *AuthChallenge) MarshalJSON() ([]byte, error) {
+	challenge := map[string]interface{}{
+		"address":   c.Address.Hex(),
+		"nonce":     c.Nonce.Hex(),
+		"hash":      ComputeAuthHash(c.Address, c.Nonce).Hex(),
+		"expiresAt": c.ExpiresAt.Format(time.RFC3339Nano),
+	}
+
+	return json.Marshal(challenge)
+}
+
+// GetBSON implements bson.Getter
+func (c *AuthChallenge) GetBSON() (interface{}, error) {
+	return AuthChallengeRecord{
+		Address:   c.Address.Hex(),
+		Nonce:     c.Nonce.Hex(),
+		ExpiresAt: c.ExpiresAt,
+	}, nil
+}
+
+// SetBSON implements bson.Setter
+func (c *AuthChallenge) SetBSON(raw bson.Raw) error {
+	decoded := &AuthChallengeRecord{}
+
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	c.Address = common.HexToAddress(decoded.Address)
+	c.Nonce = common.HexToHash(decoded.Nonce)
+	c.ExpiresAt = decoded.ExpiresAt
+
+	return nil
+}
+
+// Validate checks the parameters of a login
+func (l *AuthLogin) Validate() error {
+	if (l.Address == common.Address{}) {
+		return errors.New("AuthLogin 'address' parameter is required")
+	}
+
+	if (l.Nonce == common.Hash{}) {
+		return errors.New("AuthLogin 'nonce' parameter is required")
+	}
+
+	if l.Signature == nil {
+		return errors.New("AuthLogin 'signature' parameter is required")
+	}
+
+	return nil
+}
+
+// VerifySignature checks that the login signature corresponds to the address in the address field
+func (l *AuthLogin) VerifySignature() (bool, error) {
+	message := crypto.Keccak256(
+		[]byte("\x19Ethereum Signed Message:\n32"),
+		ComputeAuthHash(l.Address, l.Nonce).Bytes(),
+	)
+
+	address, err := l.Signature.Verify(common.BytesToHash(message))
+	if err != nil {
+		return false, err
+	}
+
+	if address != l.Address {
+		return false, errors.New("Recovered address is incorrect")
+	}
+
+	return true, nil
+}
+
+// UnmarshalJSON creates an AuthLogin object from a json byte string
+func (l *AuthLogin) UnmarshalJSON(b []byte) error {
+	parsed := map[string]interface{}{}
+
+	err := json.Unmarshal(b, &parsed)
+	if err != nil {
+		return err
+	}
+
+	if parsed["address"] != nil {
+		addr, ok := parsed["address"].(string)
+		if !ok {
+			return errors.New("AuthLogin 'address' parameter should be a string")
+		}
+
+		l.Address = common.HexToAddress(addr)
+	}
+
+	if parsed["nonce"] != nil {
+		nonce, ok := parsed["nonce"].(string)
+		if !ok {
+			return errors.New("AuthLogin 'nonce' parameter should be a string")
+		}
+
+		l.Nonce = common.HexToHash(nonce)
+	}
+
+	if parsed["signature"] != nil {
+		l.Signature, err = parseSignature(parsed["signature"])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsEmpty returns true if the subscription carries no proof of ownership
+func (s *PrivateSubscription) IsEmpty() bool {
+	return s.Token == "" && s.Login == nil
+}
+
+// UnmarshalJSON creates a PrivateSubscription object from a json byte string. The fields of the
+// login are at the root of the payload
+func (s *PrivateSubscription) UnmarshalJSON(b []byte) error {
+	parsed := map[string]interface{}{}
+
+	err := json.Unmarshal(b, &parsed)
+	if err != nil {
+		return err
+	}
+
+	if parsed["token"] != nil {
+		token, ok := parsed["token"].(string)
+		if !ok {
+			return errors.New("Invalid token")
+		}
+
+		s.Token = token
+	}
+
+	if parsed["signature"] != nil {
+		s.Login = &AuthLogin{}
+
+		err = s.Login.UnmarshalJSON(b)
+		if err != nil {
+			return err
+		}
+
+		return s.Login.Validate()
+	}
+
+	return nil
+}
+
+// NewAuthClaims returns the claims of a token granting a role to an account until a given time
+func NewAuthClaims(addr common.Address, role string, expiresAt time.Time) *AuthClaims {
+	return &AuthClaims{
+		Role: role,
+		StandardClaims: jwt.StandardClaims{
+			Subject:   addr.Hex(),
+			IssuedAt:  time.Now().Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+	}
+}
+
+// Address returns the address of the account authenticated by the token
+func (c *AuthClaims) Address() common.Address {
+	return common.HexToAddress(c.Subject)
+}
+
+// IsAdmin returns true if the token grants the admin role
+func (c *AuthClaims) IsAdmin() bool {
+	return c.Role == AUTH_ROLE_ADMIN
+}
+
+// Valid implements jwt.Claims
+func (c *AuthClaims) Valid() error {
+	err := c.StandardClaims.Valid()
+	if err != nil {
+		return err
+	}
+
+	if !common.IsHexAddress(c.Subject) {
+		return errors.New("Invalid token subject")
+	}
+
+	if c.Role != AUTH_ROLE_USER && c.Role != AUTH_ROLE_ADMIN {
+		return errors.New("Invalid token role")
+	}
+
+	return nil
+}
+
+// SignedString returns the JWT of the claims signed with the configured signing method and key
+func (c *AuthClaims) SignedString() (string, error) {
+	if !app.Config.JWTEnabled() {
+		return "", ErrJWTDisabled
+	}
+
+	method := jwtSigningMethod()
+
+	var key interface{}
+	var err error
+
+	switch method.(type) {
+	case *jwt.SigningMethodRSA:
+		key, err = jwt.ParseRSAPrivateKeyFromPEM([]byte(app.Config.JWTSigningKey))
+	case *jwt.SigningMethodECDSA:
+		key, err = jwt.ParseECPrivateKeyFromPEM([]byte(app.Config.JWTSigningKey))
+	default:
+		key = []byte(app.Config.JWTSigningKey)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return jwt.NewWithClaims(method, c).SignedString(key)
+}
+
+// ParseAuthToken verifies a JWT with the configured verification key and returns its claims
+func ParseAuthToken(s string) (*AuthClaims, error) {
+	if !app.Config.JWTEnabled() {
+		return nil, ErrJWTDisabled
+	}
+
+	method := jwtSigningMethod()
+	claims := &AuthClaims{}
+
+	_, err := jwt.ParseWithClaims(s, claims, func(t *jwt.Token) (interface{}, error) {
+		// tokens signed with another algorithm, including "none", are refused
+		if t.Method.Alg() != method.Alg() {
+			return nil, errors.New("Unexpected token signing method")
+		}
+
+		switch method.(type) {
+		case *jwt.SigningMethodRSA:
+			return jwt.ParseRSAPublicKeyFromPEM([]byte(app.Config.JWTVerificationKey))
+		case *jwt.SigningMethodECDSA:
+			return jwt.ParseECPublicKeyFromPEM([]byte(app.Config.JWTVerificationKey))
+		default:
+			return []byte(app.Config.JWTVerificationKey), nil
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// jwtSigningMethod returns the configured JWT signing method, HS256 by default
+func jwtSigningMethod() jwt.SigningMethod {
+	method := jwt.GetSigningMethod(strings.ToUpper(app.Config.JWTSigningMethod))
+	if method == nil {
+		return jwt.SigningMethodHS256
+	}
+
+	return method
+}
+
+// Authentication is the identity of an authenticated request: the account and its role, and
+// the scope of the API key for the requests signed with an API key
+type Authentication struct {
+	Address common.Address
+	Role    string
+	APIKey  string
+	Scope   string
+}
+
+// NewTokenAuthentication returns the authentication of a request carrying a JWT, which is
+// not restricted to a scope
+func NewTokenAuthentication(c *AuthClaims) *Authentication {
+	return &Authentication{Address: c.Address(), Role: c.Role}
+}
+
+// IsAdmin returns true if the request was authenticated with the admin role
+func (a *Authentication) IsAdmin() bool {
+	return a.Role == AUTH_ROLE_ADMIN
+}
+
+// Allows returns true if the request is allowed the actions of a scope. Requests authenticated
+// with a JWT are allowed all the scopes
+func (a *Authentication) Allows(scope string) bool {
+	if a.APIKey == "" {
+		return true
+	}
+
+	return apiKeyScopeLevels[a.Scope] >= apiKeyScopeLevels[scope]
+}
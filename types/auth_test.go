@@ -0,0 +1,50 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/app"
+)
+
+func TestComputeAuthHashDomain(t *testing.T) {
+	exchange := common.HexToAddress("0x1")
+	user := common.HexToAddress("0x2")
+	nonce := common.HexToHash("0x3")
+
+	app.Config.Ethereum = map[string]string{"exchange_address": exchange.Hex()}
+
+	m := &MinNonce{ExchangeAddress: exchange, UserAddress: user, Nonce: nonce.Big()}
+	if ComputeAuthHash(user, nonce) == m.ComputeHash() {
+		t.Errorf("Expected the login and minimum nonce hashes of the same fields to differ")
+	}
+}
+
+func TestPrivateSubscriptionUnmarshalJSON(t *testing.T) {
+	invalid := []string{
+		`{"token":1}`,
+		`{"address":2,"nonce":"0x1","signature":{"v":27,"r":"0x1","s":"0x2"}}`,
+		`{"address":"0x2","nonce":3,"signature":{"v":27,"r":"0x1","s":"0x2"}}`,
+		`{"address":"0x2","nonce":"0x1","signature":{"v":"27","r":"0x1","s":"0x2"}}`,
+		`{"address":"0x2","nonce":"0x1","signature":{"v":27,"r":1,"s":"0x2"}}`,
+		`{"address":"0x2","nonce":"0x1","signature":{"v":27}}`,
+		`{"address":"0x2","nonce":"0x1","signature":[]}`,
+	}
+
+	for _, payload := range invalid {
+		err := (&PrivateSubscription{}).UnmarshalJSON([]byte(payload))
+		if err == nil {
+			t.Errorf("Expected an error for %v", payload)
+		}
+	}
+
+	s := &PrivateSubscription{}
+	err := s.UnmarshalJSON([]byte(`{"address":"0x2","nonce":"0x1","signature":{"v":27,"r":"0x1","s":"0x2"}}`))
+	if err != nil {
+		t.Fatalf("Could not unmarshal subscription: %v", err)
+	}
+
+	if s.Login == nil || s.Login.Address != common.HexToAddress("0x2") || s.Login.Signature.V != 27 {
+		t.Errorf("Unexpected subscription login %v", s.Login)
+	}
+}
@@ -0,0 +1,50 @@
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ContractStatus describes the exchange contract deployed at the configured address and
+// whether it is compatible with the order hashing and trade encoding of the server
+type ContractStatus struct {
+	Address           common.Address `json:"address"`
+	Version           string         `json:"version"`
+	SupportedVersions []string       `json:"supportedVersions"`
+	WethToken         common.Address `json:"wethToken"`
+	RewardAccount     common.Address `json:"rewardAccount"`
+	Mismatches        []string       `json:"mismatches"`
+	ReadOnly          bool           `json:"readOnly"`
+}
+
+// IsCompatible returns true if no mismatch was found with the deployed contract
+func (s *ContractStatus) IsCompatible() bool {
+	return len(s.Mismatches) == 0
+}
+
+// IsReadOnly returns true if orders can not be placed or cancelled because the
+// deployed contract is not compatible
+func (s *ContractStatus) IsReadOnly() bool {
+	return s != nil && s.ReadOnly
+}
+
+func (s *ContractStatus) MarshalJSON() ([]byte, error) {
+	mismatches := s.Mismatches
+	if mismatches == nil {
+		mismatches = []string{}
+	}
+
+	status := map[string]interface{}{
+		"address":           s.Address.Hex(),
+		"version":           s.Version,
+		"supportedVersions": s.SupportedVersions,
+		"wethToken":         s.WethToken.Hex(),
+		"rewardAccount":     s.RewardAccount.Hex(),
+		"compatible":        s.IsCompatible(),
+		"mismatches":        mismatches,
+		"readOnly":          s.ReadOnly,
+	}
+
+	return json.Marshal(status)
+}
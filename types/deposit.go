@@ -23,8 +23,9 @@ const (
 type Chain string
 
 const (
-	ChainEthereum Chain = "ethereum"
-	ChainBitcoin  Chain = "bitcoin"
+	ChainEthereum  Chain = "ethereum"
+	ChainBitcoin   Chain = "bitcoin"
+	ChainTomochain Chain = "tomochain"
 )
 
 func NewChain(str interface{}) Chain {
@@ -150,6 +151,24 @@ type DepositTransaction struct {
 	AssociatedAddress string
 }
 
+const (
+	DEPOSIT_HELD     = "HELD"
+	DEPOSIT_RELEASED = "RELEASED"
+)
+
+// HeldDeposit is a deposit received for an account whose restriction level does not allow
+// deposits. The funds are not credited until the deposit is released by an admin
+type HeldDeposit struct {
+	ID                bson.ObjectId       `json:"id" bson:"_id"`
+	Chain             Chain               `json:"chain" bson:"chain"`
+	Address           string              `json:"address" bson:"address"`
+	AssociatedAddress string              `json:"associatedAddress" bson:"associatedAddress"`
+	Transaction       *DepositTransaction `json:"transaction" bson:"transaction"`
+	Status            string              `json:"status" bson:"status"`
+	CreatedAt         time.Time           `json:"createdAt" bson:"createdAt"`
+	UpdatedAt         time.Time           `json:"updatedAt" bson:"updatedAt"`
+}
+
 type AssociationTransaction struct {
 	Source          string   `json:"source"`
 	Signature       []byte   `json:"signature"`
@@ -0,0 +1,341 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/errors"
+	"github.com/tomochain/dex-server/utils/math"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Origin of the fees resolved for an account: the default fees of the pair, a volume tier
+// of the fee schedule of the quote token or a manual override
+const (
+	FEE_SOURCE_DEFAULT  = "DEFAULT"
+	FEE_SOURCE_TIER     = "TIER"
+	FEE_SOURCE_OVERRIDE = "OVERRIDE"
+)
+
+// FeeTier is the maker and taker fees applied to accounts with a trailing traded volume
+// of at least MinVolume. Volumes and fees are denominated in quote token units
+type FeeTier struct {
+	MinVolume *big.Int `json:"minVolume" bson:"minVolume"`
+	MakeFee   *big.Int `json:"makeFee" bson:"makeFee"`
+	TakeFee   *big.Int `json:"takeFee" bson:"takeFee"`
+}
+
+// FeeTierRecord is the struct which is stored in db
+type FeeTierRecord struct {
+	MinVolume string `json:"minVolume" bson:"minVolume"`
+	MakeFee   string `json:"makeFee" bson:"makeFee"`
+	TakeFee   string `json:"takeFee" bson:"takeFee"`
+}
+
+// FeeSchedule is the list of volume tiers of a quote token, sorted by increasing volume
+type FeeSchedule struct {
+	QuoteToken common.Address `json:"quoteToken" bson:"quoteToken"`
+	Tiers      []*FeeTier     `json:"tiers" bson:"tiers"`
+	UpdatedAt  time.Time      `json:"updatedAt" bson:"updatedAt"`
+}
+
+// FeeScheduleRecord is the struct which is stored in db
+type FeeScheduleRecord struct {
+	QuoteToken string           `json:"quoteToken" bson:"quoteToken"`
+	Tiers      []*FeeTierRecord `json:"tiers" bson:"tiers"`
+	UpdatedAt  time.Time        `json:"updatedAt" bson:"updatedAt"`
+}
+
+// FeeOverride is a fee manually set for an account and a quote token. It takes precedence
+// over the fee schedule until it expires. A zero expiry never expires
+type FeeOverride struct {
+	UserAddress common.Address `json:"userAddress" bson:"userAddress"`
+	QuoteToken  common.Address `json:"quoteToken" bson:"quoteToken"`
+	MakeFee     *big.Int       `json:"makeFee" bson:"makeFee"`
+	TakeFee     *big.Int       `json:"takeFee" bson:"takeFee"`
+	Reason      string         `json:"reason" bson:"reason"`
+	ExpiresAt   time.Time      `json:"expiresAt" bson:"expiresAt"`
+	UpdatedAt   time.Time      `json:"updatedAt" bson:"updatedAt"`
+}
+
+// FeeOverrideRecord is the struct which is stored in db
+type FeeOverrideRecord struct {
+	UserAddress string    `json:"userAddress" bson:"userAddress"`
+	QuoteToken  string    `json:"quoteToken" bson:"quoteToken"`
+	MakeFee     string    `json:"makeFee" bson:"makeFee"`
+	TakeFee     string    `json:"takeFee" bson:"takeFee"`
+	Reason      string    `json:"reason" bson:"reason"`
+	ExpiresAt   time.Time `json:"expiresAt" bson:"expiresAt"`
+	UpdatedAt   time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// ResolvedFee is the minimum maker and taker fees an account has to sign on the orders
+// of pairs quoted in a given token
+type ResolvedFee struct {
+	UserAddress common.Address
+	QuoteToken  common.Address
+	Volume      *big.Int
+	MakeFee     *big.Int
+	TakeFee     *big.Int
+	Source      string
+}
+
+// Validate checks that the tiers of a fee schedule are complete and sorted by strictly increasing volume
+func (s *FeeSchedule) Validate() error {
+	if (s.QuoteToken == common.Address{}) {
+		return errors.New("FeeSchedule 'quoteToken' parameter is required")
+	}
+
+	if len(s.Tiers) == 0 {
+		return errors.New("FeeSchedule 'tiers' parameter is required")
+	}
+
+	for i, t := range s.Tiers {
+		if t == nil || t.MinVolume == nil || t.MakeFee == nil || t.TakeFee == nil {
+			return fmt.Errorf("FeeSchedule tier %v is incomplete", i)
+		}
+
+		if t.MinVolume.Sign() < 0 || t.MakeFee.Sign() < 0 || t.TakeFee.Sign() < 0 {
+			return fmt.Errorf("FeeSchedule tier %v should be positive", i)
+		}
+
+		if i > 0 && math.IsEqualOrSmallerThan(t.MinVolume, s.Tiers[i-1].MinVolume) {
+			return fmt.Errorf("FeeSchedule tier %v should have a higher 'minVolume' than the previous tier", i)
+		}
+	}
+
+	return nil
+}
+
+// Tier returns the tier with the highest minimum volume reached by a traded volume,
+// or nil if the volume is lower than the minimum volume of all the tiers
+func (s *FeeSchedule) Tier(volume *big.Int) *FeeTier {
+	var tier *FeeTier
+	for _, t := range s.Tiers {
+		if math.IsEqualOrGreaterThan(volume, t.MinVolume) {
+			tier = t
+		}
+	}
+
+	return tier
+}
+
+// Validate checks the parameters of a fee override
+func (o *FeeOverride) Validate() error {
+	if (o.UserAddress == common.Address{}) {
+		return errors.New("FeeOverride 'userAddress' parameter is required")
+	}
+
+	if (o.QuoteToken == common.Address{}) {
+		return errors.New("FeeOverride 'quoteToken' parameter is required")
+	}
+
+	if o.MakeFee == nil || o.TakeFee == nil {
+		return errors.New("FeeOverride 'makeFee' and 'takeFee' parameters are required")
+	}
+
+	if o.MakeFee.Sign() < 0 || o.TakeFee.Sign() < 0 {
+		return errors.New("FeeOverride fees should be positive")
+	}
+
+	if o.Reason == "" {
+		return errors.New("FeeOverride 'reason' parameter is required")
+	}
+
+	if o.IsExpired() {
+		return errors.New("FeeOverride 'expiresAt' parameter should be in the future")
+	}
+
+	return nil
+}
+
+// IsExpired returns true if the override has an expiry that is passed
+func (o *FeeOverride) IsExpired() bool {
+	return !o.ExpiresAt.IsZero() && o.ExpiresAt.Before(time.Now())
+}
+
+// Check returns an error if the fees signed on an order are lower than the resolved fees
+func (f *ResolvedFee) Check(o *Order) error {
+	if o.MakeFee == nil || math.IsStrictlySmallerThan(o.MakeFee, f.MakeFee) {
+		return fmt.Errorf("Invalid MakeFee: the minimum make fee is %v", f.MakeFee)
+	}
+
+	if o.TakeFee == nil || math.IsStrictlySmallerThan(o.TakeFee, f.TakeFee) {
+		return fmt.Errorf("Invalid TakeFee: the minimum take fee is %v", f.TakeFee)
+	}
+
+	return nil
+}
+
+func (f *ResolvedFee) MarshalJSON() ([]byte, error) {
+	fee := map[string]interface{}{
+		"userAddress": f.UserAddress.Hex(),
+		"quoteToken":  f.QuoteToken.Hex(),
+		"makeFee":     f.MakeFee.String(),
+		"takeFee":     f.TakeFee.String(),
+		"source":      f.Source,
+	}
+
+	if f.Volume != nil {
+		fee["volume"] = f.Volume.String()
+	}
+
+	return json.Marshal(fee)
+}
+
+func (t *FeeTier) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.record())
+}
+
+func (t *FeeTier) UnmarshalJSON(b []byte) error {
+	decoded := &FeeTierRecord{}
+
+	err := json.Unmarshal(b, decoded)
+	if err != nil {
+		return err
+	}
+
+	if decoded.MinVolume != "" {
+		t.MinVolume = math.ToBigInt(decoded.MinVolume)
+	}
+
+	if decoded.MakeFee != "" {
+		t.MakeFee = math.ToBigInt(decoded.MakeFee)
+	}
+
+	if decoded.TakeFee != "" {
+		t.TakeFee = math.ToBigInt(decoded.TakeFee)
+	}
+
+	return nil
+}
+
+func (t *FeeTier) record() *FeeTierRecord {
+	tr := &FeeTierRecord{}
+
+	if t.MinVolume != nil {
+		tr.MinVolume = t.MinVolume.String()
+	}
+
+	if t.MakeFee != nil {
+		tr.MakeFee = t.MakeFee.String()
+	}
+
+	if t.TakeFee != nil {
+		tr.TakeFee = t.TakeFee.String()
+	}
+
+	return tr
+}
+
+// GetBSON implements bson.Getter
+func (s *FeeSchedule) GetBSON() (interface{}, error) {
+	sr := FeeScheduleRecord{
+		QuoteToken: s.QuoteToken.Hex(),
+		Tiers:      []*FeeTierRecord{},
+		UpdatedAt:  s.UpdatedAt,
+	}
+
+	for _, t := range s.Tiers {
+		sr.Tiers = append(sr.Tiers, t.record())
+	}
+
+	return sr, nil
+}
+
+// SetBSON implements bson.Setter
+func (s *FeeSchedule) SetBSON(raw bson.Raw) error {
+	decoded := &FeeScheduleRecord{}
+
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	s.QuoteToken = common.HexToAddress(decoded.QuoteToken)
+	s.UpdatedAt = decoded.UpdatedAt
+	s.Tiers = []*FeeTier{}
+
+	for _, t := range decoded.Tiers {
+		s.Tiers = append(s.Tiers, &FeeTier{
+			MinVolume: math.ToBigInt(t.MinVolume),
+			MakeFee:   math.ToBigInt(t.MakeFee),
+			TakeFee:   math.ToBigInt(t.TakeFee),
+		})
+	}
+
+	return nil
+}
+
+func (o *FeeOverride) MarshalJSON() ([]byte, error) {
+	override := map[string]interface{}{
+		"userAddress": o.UserAddress.Hex(),
+		"quoteToken":  o.QuoteToken.Hex(),
+		"reason":      o.Reason,
+		"updatedAt":   o.UpdatedAt.Format(time.RFC3339Nano),
+	}
+
+	if o.MakeFee != nil {
+		override["makeFee"] = o.MakeFee.String()
+	}
+
+	if o.TakeFee != nil {
+		override["takeFee"] = o.TakeFee.String()
+	}
+
+	if !o.ExpiresAt.IsZero() {
+		override["expiresAt"] = o.ExpiresAt.Format(time.RFC3339Nano)
+	}
+
+	return json.Marshal(override)
+}
+
+// GetBSON implements bson.Getter
+func (o *FeeOverride) GetBSON() (interface{}, error) {
+	or := FeeOverrideRecord{
+		UserAddress: o.UserAddress.Hex(),
+		QuoteToken:  o.QuoteToken.Hex(),
+		Reason:      o.Reason,
+		ExpiresAt:   o.ExpiresAt,
+		UpdatedAt:   o.UpdatedAt,
+	}
+
+	if o.MakeFee != nil {
+		or.MakeFee = o.MakeFee.String()
+	}
+
+	if o.TakeFee != nil {
+		or.TakeFee = o.TakeFee.String()
+	}
+
+	return or, nil
+}
+
+// SetBSON implements bson.Setter
+func (o *FeeOverride) SetBSON(raw bson.Raw) error {
+	decoded := &FeeOverrideRecord{}
+
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	o.UserAddress = common.HexToAddress(decoded.UserAddress)
+	o.QuoteToken = common.HexToAddress(decoded.QuoteToken)
+	o.Reason = decoded.Reason
+	o.ExpiresAt = decoded.ExpiresAt
+	o.UpdatedAt = decoded.UpdatedAt
+
+	if decoded.MakeFee != "" {
+		o.MakeFee = math.ToBigInt(decoded.MakeFee)
+	}
+
+	if decoded.TakeFee != "" {
+		o.TakeFee = math.ToBigInt(decoded.TakeFee)
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/utils/math"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// FeeLedgerEntry is the maker and taker fees earned by the exchange on a settled trade. The fees
+// are the make fee signed on the maker order and the take fee signed on the taker order, and are
+// paid in the quote token of the pair
+type FeeLedgerEntry struct {
+	TradeHash  common.Hash    `json:"tradeHash" bson:"tradeHash"`
+	PairName   string         `json:"pairName" bson:"pairName"`
+	BaseToken  common.Address `json:"baseToken" bson:"baseToken"`
+	QuoteToken common.Address `json:"quoteToken" bson:"quoteToken"`
+	FeeToken   common.Address `json:"feeToken" bson:"feeToken"`
+	Maker      common.Address `json:"maker" bson:"maker"`
+	Taker      common.Address `json:"taker" bson:"taker"`
+	MakeFee    *big.Int       `json:"makeFee" bson:"makeFee"`
+	TakeFee    *big.Int       `json:"takeFee" bson:"takeFee"`
+	SettledAt  time.Time      `json:"settledAt" bson:"settledAt"`
+}
+
+// FeeLedgerEntryRecord is the struct which is stored in db
+type FeeLedgerEntryRecord struct {
+	TradeHash  string    `json:"tradeHash" bson:"tradeHash"`
+	PairName   string    `json:"pairName" bson:"pairName"`
+	BaseToken  string    `json:"baseToken" bson:"baseToken"`
+	QuoteToken string    `json:"quoteToken" bson:"quoteToken"`
+	FeeToken   string    `json:"feeToken" bson:"feeToken"`
+	Maker      string    `json:"maker" bson:"maker"`
+	Taker      string    `json:"taker" bson:"taker"`
+	MakeFee    string    `json:"makeFee" bson:"makeFee"`
+	TakeFee    string    `json:"takeFee" bson:"takeFee"`
+	SettledAt  time.Time `json:"settledAt" bson:"settledAt"`
+}
+
+// FeeRevenue is the sum of the fees earned on a pair in a fee token during a day (UTC)
+type FeeRevenue struct {
+	Date       string
+	PairName   string
+	FeeToken   common.Address
+	MakeFees   *big.Int
+	TakeFees   *big.Int
+	TradeCount int
+}
+
+// Total returns the sum of the maker and taker fees
+func (r *FeeRevenue) Total() *big.Int {
+	return math.Add(r.MakeFees, r.TakeFees)
+}
+
+// FeeRevenueCSVHeader returns the column names of the CSV fee revenue report
+func FeeRevenueCSVHeader() []string {
+	return []string{"date", "pair", "feeToken", "makeFees", "takeFees", "totalFees", "tradeCount"}
+}
+
+// CSVRecord returns the fee revenue as a row of the CSV fee revenue report
+func (r *FeeRevenue) CSVRecord() []string {
+	return []string{
+		r.Date,
+		r.PairName,
+		r.FeeToken.Hex(),
+		r.MakeFees.String(),
+		r.TakeFees.String(),
+		r.Total().String(),
+		strconv.Itoa(r.TradeCount),
+	}
+}
+
+func (r *FeeRevenue) MarshalJSON() ([]byte, error) {
+	revenue := map[string]interface{}{
+		"date":       r.Date,
+		"pairName":   r.PairName,
+		"feeToken":   r.FeeToken.Hex(),
+		"makeFees":   r.MakeFees.String(),
+		"takeFees":   r.TakeFees.String(),
+		"totalFees":  r.Total().String(),
+		"tradeCount": r.TradeCount,
+	}
+
+	return json.Marshal(revenue)
+}
+
+// GetBSON implements bson.Getter
+func (e *FeeLedgerEntry) GetBSON() (interface{}, error) {
+	er := FeeLedgerEntryRecord{
+		TradeHash:  e.TradeHash.Hex(),
+		PairName:   e.PairName,
+		BaseToken:  e.BaseToken.Hex(),
+		QuoteToken: e.QuoteToken.Hex(),
+		FeeToken:   e.FeeToken.Hex(),
+		Maker:      e.Maker.Hex(),
+		Taker:      e.Taker.Hex(),
+		SettledAt:  e.SettledAt,
+	}
+
+	if e.MakeFee != nil {
+		er.MakeFee = e.MakeFee.String()
+	}
+
+	if e.TakeFee != nil {
+		er.TakeFee = e.TakeFee.String()
+	}
+
+	return er, nil
+}
+
+// SetBSON implements bson.Setter
+func (e *FeeLedgerEntry) SetBSON(raw bson.Raw) error {
+	decoded := &FeeLedgerEntryRecord{}
+
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	e.TradeHash = common.HexToHash(decoded.TradeHash)
+	e.PairName = decoded.PairName
+	e.BaseToken = common.HexToAddress(decoded.BaseToken)
+	e.QuoteToken = common.HexToAddress(decoded.QuoteToken)
+	e.FeeToken = common.HexToAddress(decoded.FeeToken)
+	e.Maker = common.HexToAddress(decoded.Maker)
+	e.Taker = common.HexToAddress(decoded.Taker)
+	e.SettledAt = decoded.SettledAt
+
+	if decoded.MakeFee != "" {
+		e.MakeFee = math.ToBigInt(decoded.MakeFee)
+	}
+
+	if decoded.TakeFee != "" {
+		e.TakeFee = math.ToBigInt(decoded.TakeFee)
+	}
+
+	return nil
+}
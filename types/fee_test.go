@@ -0,0 +1,56 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestResolvedFeeCheck(t *testing.T) {
+	f := &ResolvedFee{MakeFee: big.NewInt(10), TakeFee: big.NewInt(20)}
+
+	tests := []struct {
+		name    string
+		makeFee *big.Int
+		takeFee *big.Int
+		valid   bool
+	}{
+		{"exact fees", big.NewInt(10), big.NewInt(20), true},
+		{"higher fees", big.NewInt(11), big.NewInt(25), true},
+		{"under-signed make fee", big.NewInt(9), big.NewInt(20), false},
+		{"under-signed take fee", big.NewInt(10), big.NewInt(19), false},
+		{"missing make fee", nil, big.NewInt(20), false},
+		{"missing take fee", big.NewInt(10), nil, false},
+	}
+
+	for _, test := range tests {
+		o := &Order{MakeFee: test.makeFee, TakeFee: test.takeFee}
+
+		err := f.Check(o)
+		if test.valid && err != nil {
+			t.Errorf("%v: expected the fees to be accepted, got %v", test.name, err)
+		}
+
+		if !test.valid && err == nil {
+			t.Errorf("%v: expected the fees to be rejected", test.name)
+		}
+	}
+}
+
+func TestFeeOverrideIsExpired(t *testing.T) {
+	tests := map[string]struct {
+		expiresAt time.Time
+		expired   bool
+	}{
+		"no expiry":     {time.Time{}, false},
+		"future expiry": {time.Now().Add(time.Hour), false},
+		"past expiry":   {time.Now().Add(-time.Hour), true},
+	}
+
+	for name, test := range tests {
+		o := &FeeOverride{ExpiresAt: test.expiresAt}
+		if o.IsExpired() != test.expired {
+			t.Errorf("%v: expected expired to be %v", name, test.expired)
+		}
+	}
+}
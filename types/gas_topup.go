@@ -0,0 +1,119 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/utils/math"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// GAS_TOP_UP_CREATED is the status of a top-up recorded before its transaction is sent
+const GAS_TOP_UP_CREATED = "CREATED"
+
+// GasTopUp is a ledger entry recording a native token transfer sent from the
+// admin wallet to an operator wallet in order to keep it funded for gas
+type GasTopUp struct {
+	ID            bson.ObjectId  `json:"id" bson:"_id"`
+	Wallet        common.Address `json:"wallet" bson:"wallet"`
+	Funder        common.Address `json:"funder" bson:"funder"`
+	Amount        *big.Int       `json:"amount" bson:"amount"`
+	BalanceBefore *big.Int       `json:"balanceBefore" bson:"balanceBefore"`
+	TxHash        common.Hash    `json:"txHash" bson:"txHash"`
+	Status        string         `json:"status" bson:"status"`
+	CreatedAt     time.Time      `json:"createdAt" bson:"createdAt"`
+	UpdatedAt     time.Time      `json:"updatedAt" bson:"updatedAt"`
+}
+
+// GasTopUpRecord is the struct which is stored in db
+type GasTopUpRecord struct {
+	ID            bson.ObjectId `json:"id" bson:"_id"`
+	Wallet        string        `json:"wallet" bson:"wallet"`
+	Funder        string        `json:"funder" bson:"funder"`
+	Amount        string        `json:"amount" bson:"amount"`
+	BalanceBefore string        `json:"balanceBefore" bson:"balanceBefore"`
+	TxHash        string        `json:"txHash,omitempty" bson:"txHash,omitempty"`
+	Status        string        `json:"status" bson:"status"`
+	CreatedAt     time.Time     `json:"createdAt" bson:"createdAt"`
+	UpdatedAt     time.Time     `json:"updatedAt" bson:"updatedAt"`
+}
+
+func (t *GasTopUp) MarshalJSON() ([]byte, error) {
+	topUp := map[string]interface{}{
+		"id":        t.ID,
+		"wallet":    t.Wallet.Hex(),
+		"funder":    t.Funder.Hex(),
+		"txHash":    t.TxHash.Hex(),
+		"status":    t.Status,
+		"createdAt": t.CreatedAt.Format(time.RFC3339Nano),
+		"updatedAt": t.UpdatedAt.Format(time.RFC3339Nano),
+	}
+
+	if t.Amount != nil {
+		topUp["amount"] = t.Amount.String()
+	}
+
+	if t.BalanceBefore != nil {
+		topUp["balanceBefore"] = t.BalanceBefore.String()
+	}
+
+	return json.Marshal(topUp)
+}
+
+// GetBSON implements bson.Getter
+func (t *GasTopUp) GetBSON() (interface{}, error) {
+	tr := GasTopUpRecord{
+		ID:        t.ID,
+		Wallet:    t.Wallet.Hex(),
+		Funder:    t.Funder.Hex(),
+		Status:    t.Status,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+
+	// the hash is omitted until the transfer is sent so that the sparse txHash index
+	// only holds the sent transfers
+	if (t.TxHash != common.Hash{}) {
+		tr.TxHash = t.TxHash.Hex()
+	}
+
+	if t.Amount != nil {
+		tr.Amount = t.Amount.String()
+	}
+
+	if t.BalanceBefore != nil {
+		tr.BalanceBefore = t.BalanceBefore.String()
+	}
+
+	return tr, nil
+}
+
+// SetBSON implements bson.Setter
+func (t *GasTopUp) SetBSON(raw bson.Raw) error {
+	decoded := &GasTopUpRecord{}
+
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	t.ID = decoded.ID
+	t.Wallet = common.HexToAddress(decoded.Wallet)
+	t.Funder = common.HexToAddress(decoded.Funder)
+	t.TxHash = common.HexToHash(decoded.TxHash)
+	t.Status = decoded.Status
+	t.CreatedAt = decoded.CreatedAt
+	t.UpdatedAt = decoded.UpdatedAt
+
+	if decoded.Amount != "" {
+		t.Amount = math.ToBigInt(decoded.Amount)
+	}
+
+	if decoded.BalanceBefore != "" {
+		t.BalanceBefore = math.ToBigInt(decoded.BalanceBefore)
+	}
+
+	return nil
+}
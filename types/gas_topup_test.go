@@ -0,0 +1,51 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestGasTopUpBSON(t *testing.T) {
+	assert := assert.New(t)
+
+	topUp := &GasTopUp{
+		ID:            bson.NewObjectId(),
+		Wallet:        common.HexToAddress("0x1"),
+		Funder:        common.HexToAddress("0x2"),
+		Amount:        big.NewInt(1e18),
+		BalanceBefore: big.NewInt(1e16),
+		Status:        GAS_TOP_UP_CREATED,
+	}
+
+	data, err := bson.Marshal(topUp)
+	if err != nil {
+		t.Fatalf("Could not marshal top-up: %v", err)
+	}
+
+	raw := bson.M{}
+	bson.Unmarshal(data, &raw)
+	_, ok := raw["txHash"]
+	assert.False(ok, "the hash of an unsent top-up should not be stored")
+
+	topUp.TxHash = common.HexToHash("0x3")
+	topUp.Status = PENDING
+
+	data, err = bson.Marshal(topUp)
+	if err != nil {
+		t.Fatalf("Could not marshal top-up: %v", err)
+	}
+
+	decoded := &GasTopUp{}
+	err = bson.Unmarshal(data, decoded)
+	if err != nil {
+		t.Fatalf("Could not unmarshal top-up: %v", err)
+	}
+
+	assert.Equal(topUp.TxHash, decoded.TxHash)
+	assert.Equal(topUp.Wallet, decoded.Wallet)
+	assert.Equal(topUp.Amount, decoded.Amount)
+}
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	HARD_CANCEL_QUEUED = "QUEUED"
+	HARD_CANCEL_SENT   = "SENT"
+)
+
+// HardCancel records the on-chain cancellation of an order. The record is created
+// when the order is queued and is updated when the cancel transaction is sent and
+// mined, so that the queue survives a restart and an order is cancelled only once
+type HardCancel struct {
+	ID        bson.ObjectId `json:"id" bson:"_id"`
+	OrderHash common.Hash   `json:"orderHash" bson:"orderHash"`
+	Order     *Order        `json:"order" bson:"order"`
+	Status    string        `json:"status" bson:"status"`
+	TxHash    common.Hash   `json:"txHash" bson:"txHash"`
+	CreatedAt time.Time     `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time     `json:"updatedAt" bson:"updatedAt"`
+}
+
+// HardCancelRecord is the struct which is stored in db
+type HardCancelRecord struct {
+	ID        bson.ObjectId `json:"id" bson:"_id"`
+	OrderHash string        `json:"orderHash" bson:"orderHash"`
+	Order     *Order        `json:"order" bson:"order"`
+	Status    string        `json:"status" bson:"status"`
+	TxHash    string        `json:"txHash" bson:"txHash"`
+	CreatedAt time.Time     `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time     `json:"updatedAt" bson:"updatedAt"`
+}
+
+// IsActive returns true if the order is waiting to be cancelled or was already
+// cancelled on the exchange contract
+func (c *HardCancel) IsActive() bool {
+	return c.Status != FAILED
+}
+
+// GetBSON implements bson.Getter
+func (c *HardCancel) GetBSON() (interface{}, error) {
+	return HardCancelRecord{
+		ID:        c.ID,
+		OrderHash: c.OrderHash.Hex(),
+		Order:     c.Order,
+		Status:    c.Status,
+		TxHash:    c.TxHash.Hex(),
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}, nil
+}
+
+// SetBSON implements bson.Setter
+func (c *HardCancel) SetBSON(raw bson.Raw) error {
+	decoded := &HardCancelRecord{}
+
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	c.ID = decoded.ID
+	c.OrderHash = common.HexToHash(decoded.OrderHash)
+	c.Order = decoded.Order
+	c.Status = decoded.Status
+	c.TxHash = common.HexToHash(decoded.TxHash)
+	c.CreatedAt = decoded.CreatedAt
+	c.UpdatedAt = decoded.UpdatedAt
+	return nil
+}
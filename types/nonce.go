@@ -0,0 +1,210 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/sha3"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/errors"
+	"github.com/tomochain/dex-server/utils/math"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// MinNonce is a message signed by an account to set its minimum valid nonce. The open orders
+// of the account with a lower nonce are cancelled and orders with a lower nonce are not
+// accepted anymore
+type MinNonce struct {
+	ExchangeAddress common.Address `json:"exchangeAddress"`
+	UserAddress     common.Address `json:"userAddress"`
+	Nonce           *big.Int       `json:"nonce"`
+	Hash            common.Hash    `json:"hash"`
+	Signature       *Signature     `json:"signature"`
+}
+
+// AccountNonce is the entry of the nonce registry of an account
+type AccountNonce struct {
+	UserAddress common.Address `json:"userAddress" bson:"userAddress"`
+	MinNonce    *big.Int       `json:"minNonce" bson:"minNonce"`
+	UpdatedAt   time.Time      `json:"updatedAt" bson:"updatedAt"`
+}
+
+// AccountNonceRecord is the struct which is stored in db
+type AccountNonceRecord struct {
+	UserAddress string    `json:"userAddress" bson:"userAddress"`
+	MinNonce    string    `json:"minNonce" bson:"minNonce"`
+	UpdatedAt   time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// Validate checks the parameters of a minimum valid nonce message
+func (m *MinNonce) Validate() error {
+	if m.ExchangeAddress != common.HexToAddress(app.Config.Ethereum["exchange_address"]) {
+		return errors.New("MinNonce 'exchangeAddress' parameter is incorrect")
+	}
+
+	if (m.UserAddress == common.Address{}) {
+		return errors.New("MinNonce 'userAddress' parameter is required")
+	}
+
+	if m.Nonce == nil {
+		return errors.New("MinNonce 'nonce' parameter is required")
+	}
+
+	if m.Signature == nil {
+		return errors.New("MinNonce 'signature' parameter is required")
+	}
+
+	if math.IsSmallerThan(m.Nonce, big.NewInt(0)) {
+		return errors.New("MinNonce 'nonce' parameter should be positive")
+	}
+
+	return nil
+}
+
+// ComputeHash computes the hash of a minimum valid nonce message
+func (m *MinNonce) ComputeHash() common.Hash {
+	sha := sha3.NewKeccak256()
+	sha.Write([]byte(MIN_NONCE_SIGNATURE_DOMAIN))
+	sha.Write(m.ExchangeAddress.Bytes())
+	sha.Write(m.UserAddress.Bytes())
+	sha.Write(common.BigToHash(m.Nonce).Bytes())
+	return common.BytesToHash(sha.Sum(nil))
+}
+
+// VerifySignature checks that the message signature corresponds to the address in the userAddress field
+func (m *MinNonce) VerifySignature() (bool, error) {
+	m.Hash = m.ComputeHash()
+
+	message := crypto.Keccak256(
+		[]byte("\x19Ethereum Signed Message:\n32"),
+		m.Hash.Bytes(),
+	)
+
+	address, err := m.Signature.Verify(common.BytesToHash(message))
+	if err != nil {
+		return false, err
+	}
+
+	if address != m.UserAddress {
+		return false, errors.New("Recovered address is incorrect")
+	}
+
+	return true, nil
+}
+
+// MarshalJSON returns the json encoded byte array representing the MinNonce struct
+func (m *MinNonce) MarshalJSON() ([]byte, error) {
+	minNonce := map[string]interface{}{
+		"exchangeAddress": m.ExchangeAddress,
+		"userAddress":     m.UserAddress,
+		"hash":            m.Hash,
+	}
+
+	if m.Nonce != nil {
+		minNonce["nonce"] = m.Nonce.String()
+	}
+
+	if m.Signature != nil {
+		minNonce["signature"] = map[string]interface{}{
+			"v": m.Signature.V,
+			"r": m.Signature.R,
+			"s": m.Signature.S,
+		}
+	}
+
+	return json.Marshal(minNonce)
+}
+
+// UnmarshalJSON creates a MinNonce object from a json byte string
+func (m *MinNonce) UnmarshalJSON(b []byte) error {
+	parsed := map[string]interface{}{}
+
+	err := json.Unmarshal(b, &parsed)
+	if err != nil {
+		return err
+	}
+
+	if parsed["exchangeAddress"] != nil {
+		addr, ok := parsed["exchangeAddress"].(string)
+		if !ok {
+			return errors.New("MinNonce 'exchangeAddress' parameter should be a string")
+		}
+
+		m.ExchangeAddress = common.HexToAddress(addr)
+	}
+
+	if parsed["userAddress"] != nil {
+		addr, ok := parsed["userAddress"].(string)
+		if !ok {
+			return errors.New("MinNonce 'userAddress' parameter should be a string")
+		}
+
+		m.UserAddress = common.HexToAddress(addr)
+	}
+
+	if parsed["nonce"] != nil {
+		nonce, ok := parsed["nonce"].(string)
+		if !ok {
+			return errors.New("MinNonce 'nonce' parameter should be a string")
+		}
+
+		m.Nonce, ok = big.NewInt(0).SetString(nonce, 10)
+		if !ok {
+			return errors.New("MinNonce 'nonce' parameter should be an integer")
+		}
+	}
+
+	if parsed["hash"] != nil {
+		h, ok := parsed["hash"].(string)
+		if !ok {
+			return errors.New("MinNonce 'hash' parameter should be a string")
+		}
+
+		m.Hash = common.HexToHash(h)
+	}
+
+	if parsed["signature"] != nil {
+		m.Signature, err = parseSignature(parsed["signature"])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetBSON implements bson.Getter
+func (n *AccountNonce) GetBSON() (interface{}, error) {
+	nr := AccountNonceRecord{
+		UserAddress: n.UserAddress.Hex(),
+		UpdatedAt:   n.UpdatedAt,
+	}
+
+	if n.MinNonce != nil {
+		nr.MinNonce = n.MinNonce.String()
+	}
+
+	return nr, nil
+}
+
+// SetBSON implements bson.Setter
+func (n *AccountNonce) SetBSON(raw bson.Raw) error {
+	decoded := &AccountNonceRecord{}
+
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	n.UserAddress = common.HexToAddress(decoded.UserAddress)
+	n.UpdatedAt = decoded.UpdatedAt
+
+	if decoded.MinNonce != "" {
+		n.MinNonce = math.ToBigInt(decoded.MinNonce)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestMinNonceUnmarshalJSON(t *testing.T) {
+	valid := `{"userAddress":"0x2","nonce":"10","signature":{"v":27,"r":"0x1","s":"0x2"}}`
+
+	m := &MinNonce{}
+	err := m.UnmarshalJSON([]byte(valid))
+	if err != nil {
+		t.Fatalf("Could not unmarshal min nonce: %v", err)
+	}
+
+	if m.Nonce.Int64() != 10 || m.Signature == nil || m.Signature.V != 27 {
+		t.Errorf("Unexpected min nonce %v", m)
+	}
+
+	invalid := []string{
+		`{"userAddress":2}`,
+		`{"nonce":10}`,
+		`{"nonce":"ten"}`,
+		`{"hash":true}`,
+		`{"signature":"0x1"}`,
+		`{"signature":{"v":"27","r":"0x1","s":"0x2"}}`,
+		`{"signature":{"v":27,"r":"0x1"}}`,
+	}
+
+	for _, payload := range invalid {
+		err := (&MinNonce{}).UnmarshalJSON([]byte(payload))
+		if err == nil {
+			t.Errorf("Expected an error for %v", payload)
+		}
+	}
+}
@@ -1,16 +1,28 @@
 package types
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
 
 type OperatorMessage struct {
 	MessageType string
 	Matches     *Matches
-	ErrorType   string
+	// SettlementError is set on TRADE_ERROR, TRADE_TX_ERROR, TRADE_TX_TIMEOUT and TRADE_INVALID messages
+	SettlementError *SettlementError
+	// Orders and TxHash are set on the HARD_CANCEL_TX_* messages. TxHash is also set on TRADE_TX_TIMEOUT messages
+	Orders []*Order
+	TxHash common.Hash
 }
 
 func (m *OperatorMessage) String() string {
-	if m.ErrorType != "" {
-		return fmt.Sprintf("%v: %v (%v)", m.MessageType, m.Matches.String(), m.ErrorType)
+	if m.Matches == nil {
+		return fmt.Sprintf("%v: %v orders (tx: %v)", m.MessageType, len(m.Orders), m.TxHash.Hex())
+	}
+
+	if m.SettlementError != nil {
+		return fmt.Sprintf("%v: %v (%v)", m.MessageType, m.Matches.String(), m.SettlementError.String())
 	}
 
 	return fmt.Sprintf("%v: %v", m.MessageType, m.Matches.String())
@@ -169,19 +169,13 @@ func (o *Order) Sign(w *Wallet) error {
 	return nil
 }
 
+// Process fills the pair data of an order. The signed fees are checked against the fees
+// of the order maker by the order service
 func (o *Order) Process(p *Pair) error {
 	if o.FilledAmount == nil {
 		o.FilledAmount = big.NewInt(0)
 	}
 
-	if !math.IsEqual(o.MakeFee, p.MakeFee) {
-		return errors.New("Invalid MakeFee")
-	}
-
-	if !math.IsEqual(o.TakeFee, p.TakeFee) {
-		return errors.New("Invalid TakeFee")
-	}
-
 	o.PairName = p.Name()
 	o.CreatedAt = time.Now()
 	o.UpdatedAt = time.Now()
@@ -285,7 +279,7 @@ func (o *Order) TotalRequiredSellAmount(p *Pair) *big.Int {
 
 	if o.Side == BUY {
 		sellAmount := math.Div(math.Mul(o.Amount, o.PricePoint), pairMultiplier)
-		fee := math.Max(p.MakeFee, p.TakeFee)
+		fee := math.Max(o.MakeFee, o.TakeFee)
 		requiredSellTokenAmount = math.Add(sellAmount, fee)
 	} else {
 		requiredSellTokenAmount = o.Amount
@@ -16,10 +16,14 @@ import (
 // same order. To be valid and be able to be processed by the matching engine,
 // the OrderCancel must include a signature by the Maker of the order corresponding
 // to the OrderHash.
+//
+// A hard cancel additionally cancels the order on the exchange smart contract so that the
+// signed order can not be settled anymore.
 type OrderCancel struct {
-	OrderHash common.Hash `json:"orderHash"`
-	Hash      common.Hash `json:"hash"`
-	Signature *Signature  `json:"signature"`
+	OrderHash  common.Hash `json:"orderHash"`
+	Hash       common.Hash `json:"hash"`
+	Signature  *Signature  `json:"signature"`
+	HardCancel bool        `json:"hardCancel,omitempty"`
 }
 
 // NewOrderCancel returns a new empty OrderCancel object
@@ -43,6 +47,10 @@ func (oc *OrderCancel) MarshalJSON() ([]byte, error) {
 		},
 	}
 
+	if oc.HardCancel {
+		orderCancel["hardCancel"] = true
+	}
+
 	return json.Marshal(orderCancel)
 }
 
@@ -70,7 +78,15 @@ func (oc *OrderCancel) UnmarshalJSON(b []byte) error {
 	}
 	oc.Hash = common.HexToHash(parsed["hash"].(string))
 
-	sig := parsed["signature"].(map[string]interface{})
+	if parsed["hardCancel"] != nil {
+		oc.HardCancel, _ = parsed["hardCancel"].(bool)
+	}
+
+	sig, ok := parsed["signature"].(map[string]interface{})
+	if !ok {
+		return errors.New("Signature is missing")
+	}
+
 	oc.Signature = &Signature{
 		V: byte(sig["v"].(float64)),
 		R: common.HexToHash(sig["r"].(string)),
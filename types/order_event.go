@@ -0,0 +1,189 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/utils/math"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Types of the events of the order timeline
+const (
+	ORDER_EVENT_ADDED               = "ADDED"
+	ORDER_EVENT_RE_ADDED            = "RE_ADDED"
+	ORDER_EVENT_PARTIALLY_FILLED    = "PARTIALLY_FILLED"
+	ORDER_EVENT_FILLED              = "FILLED"
+	ORDER_EVENT_CANCELLED           = "CANCELLED"
+	ORDER_EVENT_CORRECTED           = "CORRECTED"
+	ORDER_EVENT_INVALIDATED         = "INVALIDATED"
+	ORDER_EVENT_REJECTED            = "REJECTED"
+	ORDER_EVENT_TRADE_PENDING       = "TRADE_PENDING"
+	ORDER_EVENT_TRADE_SUCCESS       = "TRADE_SUCCESS"
+	ORDER_EVENT_TRADE_ERROR         = "TRADE_ERROR"
+	ORDER_EVENT_TRADE_CANCELLED     = "TRADE_CANCELLED"
+	ORDER_EVENT_TRADE_TIMEOUT       = "TRADE_TIMEOUT"
+	ORDER_EVENT_HARD_CANCEL_PENDING = "HARD_CANCEL_PENDING"
+	ORDER_EVENT_HARD_CANCELLED      = "HARD_CANCELLED"
+	ORDER_EVENT_HARD_CANCEL_ERROR   = "HARD_CANCEL_ERROR"
+)
+
+// OrderEvent is a step of the lifecycle of an order: a response of the matching engine or the
+// settlement status of one of its trades. The status and filled amount of the order are only
+// set when they are known at the time of the event
+type OrderEvent struct {
+	OrderHash    common.Hash `json:"orderHash" bson:"orderHash"`
+	Type         string      `json:"type" bson:"type"`
+	Status       string      `json:"status" bson:"status"`
+	FilledAmount *big.Int    `json:"filledAmount" bson:"filledAmount"`
+	TradeHash    common.Hash `json:"tradeHash" bson:"tradeHash"`
+	TxHash       common.Hash `json:"txHash" bson:"txHash"`
+	Error        string      `json:"error" bson:"error"`
+	CreatedAt    time.Time   `json:"createdAt" bson:"createdAt"`
+}
+
+// OrderEventRecord is the struct which is stored in db
+type OrderEventRecord struct {
+	OrderHash    string    `json:"orderHash" bson:"orderHash"`
+	Type         string    `json:"type" bson:"type"`
+	Status       string    `json:"status,omitempty" bson:"status,omitempty"`
+	FilledAmount string    `json:"filledAmount,omitempty" bson:"filledAmount,omitempty"`
+	TradeHash    string    `json:"tradeHash,omitempty" bson:"tradeHash,omitempty"`
+	TxHash       string    `json:"txHash,omitempty" bson:"txHash,omitempty"`
+	Error        string    `json:"error,omitempty" bson:"error,omitempty"`
+	CreatedAt    time.Time `json:"createdAt" bson:"createdAt"`
+}
+
+// NewOrderEvent returns an event of an order with its current status and filled amount
+func NewOrderEvent(o *Order, eventType string) *OrderEvent {
+	return &OrderEvent{
+		OrderHash:    o.Hash,
+		Type:         eventType,
+		Status:       o.Status,
+		FilledAmount: o.FilledAmount,
+		CreatedAt:    time.Now(),
+	}
+}
+
+// AddedEventType returns the type of the event of an order added to the orderbook given its
+// previous events. An order that was already added, or whose trades were invalidated or
+// cancelled, is put back in the orderbook
+func AddedEventType(previous []*OrderEvent) string {
+	for _, e := range previous {
+		if e.Type == ORDER_EVENT_ADDED || e.Type == ORDER_EVENT_RE_ADDED {
+			return ORDER_EVENT_RE_ADDED
+		}
+	}
+
+	if len(previous) > 0 {
+		switch previous[len(previous)-1].Type {
+		case ORDER_EVENT_INVALIDATED, ORDER_EVENT_TRADE_CANCELLED:
+			return ORDER_EVENT_RE_ADDED
+		}
+	}
+
+	return ORDER_EVENT_ADDED
+}
+
+// NewTradeOrderEvents returns the events of the maker and taker orders of a trade
+func NewTradeOrderEvents(t *Trade, eventType string) []*OrderEvent {
+	events := []*OrderEvent{}
+	for _, h := range []common.Hash{t.MakerOrderHash, t.TakerOrderHash} {
+		events = append(events, &OrderEvent{
+			OrderHash: h,
+			Type:      eventType,
+			TradeHash: t.Hash,
+			TxHash:    t.TxHash,
+			CreatedAt: time.Now(),
+		})
+	}
+
+	return events
+}
+
+func (e *OrderEvent) MarshalJSON() ([]byte, error) {
+	event := map[string]interface{}{
+		"orderHash": e.OrderHash.Hex(),
+		"type":      e.Type,
+		"createdAt": e.CreatedAt.Format(time.RFC3339Nano),
+	}
+
+	if e.Status != "" {
+		event["status"] = e.Status
+	}
+
+	if e.FilledAmount != nil {
+		event["filledAmount"] = e.FilledAmount.String()
+	}
+
+	if (e.TradeHash != common.Hash{}) {
+		event["tradeHash"] = e.TradeHash.Hex()
+	}
+
+	if (e.TxHash != common.Hash{}) {
+		event["txHash"] = e.TxHash.Hex()
+	}
+
+	if e.Error != "" {
+		event["error"] = e.Error
+	}
+
+	return json.Marshal(event)
+}
+
+// GetBSON implements bson.Getter
+func (e *OrderEvent) GetBSON() (interface{}, error) {
+	r := OrderEventRecord{
+		OrderHash: e.OrderHash.Hex(),
+		Type:      e.Type,
+		Status:    e.Status,
+		Error:     e.Error,
+		CreatedAt: e.CreatedAt,
+	}
+
+	if e.FilledAmount != nil {
+		r.FilledAmount = e.FilledAmount.String()
+	}
+
+	if (e.TradeHash != common.Hash{}) {
+		r.TradeHash = e.TradeHash.Hex()
+	}
+
+	if (e.TxHash != common.Hash{}) {
+		r.TxHash = e.TxHash.Hex()
+	}
+
+	return r, nil
+}
+
+// SetBSON implements bson.Setter
+func (e *OrderEvent) SetBSON(raw bson.Raw) error {
+	decoded := &OrderEventRecord{}
+
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	e.OrderHash = common.HexToHash(decoded.OrderHash)
+	e.Type = decoded.Type
+	e.Status = decoded.Status
+	e.Error = decoded.Error
+	e.CreatedAt = decoded.CreatedAt
+
+	if decoded.FilledAmount != "" {
+		e.FilledAmount = math.ToBigInt(decoded.FilledAmount)
+	}
+
+	if decoded.TradeHash != "" {
+		e.TradeHash = common.HexToHash(decoded.TradeHash)
+	}
+
+	if decoded.TxHash != "" {
+		e.TxHash = common.HexToHash(decoded.TxHash)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-test/deep"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestOrderEventBSON(t *testing.T) {
+	expected := &OrderEvent{
+		OrderHash:    common.HexToHash("0x6d9ad89548c9e3ce4c97825d027291477f2c44a8caef792095f2cabc978493ff"),
+		Type:         ORDER_EVENT_TRADE_ERROR,
+		Status:       "PARTIAL_FILLED",
+		FilledAmount: big.NewInt(100),
+		TradeHash:    common.HexToHash("0xb9070a2d333403c255ce71ddf6e795053599b2e885321de40353832b96d8880a"),
+		Error:        ErrCodeTxReverted,
+		CreatedAt:    time.Unix(1530000000, 0).UTC(),
+	}
+
+	encoded, err := bson.Marshal(expected)
+	if err != nil {
+		t.Errorf("Error encoding order event: %v", err)
+	}
+
+	decoded := &OrderEvent{}
+	err = bson.Unmarshal(encoded, decoded)
+	if err != nil {
+		t.Errorf("Could not decode order event: %v", err)
+	}
+
+	decoded.CreatedAt = decoded.CreatedAt.UTC()
+	if diff := deep.Equal(expected, decoded); diff != nil {
+		t.Errorf("Expected: \n%+v\nGot: \n%+v\n\n", expected, decoded)
+	}
+}
+
+func TestNewTradeOrderEvents(t *testing.T) {
+	tr := &Trade{
+		Hash:           common.HexToHash("0x1"),
+		MakerOrderHash: common.HexToHash("0x2"),
+		TakerOrderHash: common.HexToHash("0x3"),
+		TxHash:         common.HexToHash("0x4"),
+	}
+
+	events := NewTradeOrderEvents(tr, ORDER_EVENT_TRADE_PENDING)
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %v", len(events))
+	}
+
+	if events[0].OrderHash != tr.MakerOrderHash || events[1].OrderHash != tr.TakerOrderHash {
+		t.Errorf("Events should be recorded for the maker and taker orders")
+	}
+
+	for _, e := range events {
+		if e.TradeHash != tr.Hash || e.TxHash != tr.TxHash || e.Type != ORDER_EVENT_TRADE_PENDING {
+			t.Errorf("Unexpected event %+v", e)
+		}
+	}
+}
+
+func TestAddedEventType(t *testing.T) {
+	events := func(eventTypes ...string) []*OrderEvent {
+		res := []*OrderEvent{}
+		for _, e := range eventTypes {
+			res = append(res, &OrderEvent{Type: e})
+		}
+
+		return res
+	}
+
+	cases := []struct {
+		previous []*OrderEvent
+		expected string
+	}{
+		{events(), ORDER_EVENT_ADDED},
+		{events(ORDER_EVENT_PARTIALLY_FILLED), ORDER_EVENT_ADDED},
+		{events(ORDER_EVENT_ADDED, ORDER_EVENT_PARTIALLY_FILLED), ORDER_EVENT_RE_ADDED},
+		{events(ORDER_EVENT_PARTIALLY_FILLED, ORDER_EVENT_TRADE_CANCELLED), ORDER_EVENT_RE_ADDED},
+		{events(ORDER_EVENT_FILLED, ORDER_EVENT_INVALIDATED), ORDER_EVENT_RE_ADDED},
+	}
+
+	for _, c := range cases {
+		if res := AddedEventType(c.previous); res != c.expected {
+			t.Errorf("Expected %v, got %v", c.expected, res)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/utils/math"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// OrderLock is the amount of sell token locked by an open order. It is the part of the
+// locked balance of the order maker that is released once the order is filled, cancelled
+// or invalidated
+type OrderLock struct {
+	OrderHash   common.Hash    `json:"orderHash" bson:"orderHash"`
+	UserAddress common.Address `json:"userAddress" bson:"userAddress"`
+	Token       common.Address `json:"token" bson:"token"`
+	Amount      *big.Int       `json:"amount" bson:"amount"`
+	UpdatedAt   time.Time      `json:"updatedAt" bson:"updatedAt"`
+}
+
+// OrderLockRecord is the struct which is stored in db
+type OrderLockRecord struct {
+	OrderHash   string    `json:"orderHash" bson:"orderHash"`
+	UserAddress string    `json:"userAddress" bson:"userAddress"`
+	Token       string    `json:"token" bson:"token"`
+	Amount      string    `json:"amount" bson:"amount"`
+	UpdatedAt   time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// GetBSON implements bson.Getter
+func (l *OrderLock) GetBSON() (interface{}, error) {
+	lr := OrderLockRecord{
+		OrderHash:   l.OrderHash.Hex(),
+		UserAddress: l.UserAddress.Hex(),
+		Token:       l.Token.Hex(),
+		UpdatedAt:   l.UpdatedAt,
+	}
+
+	if l.Amount != nil {
+		lr.Amount = l.Amount.String()
+	}
+
+	return lr, nil
+}
+
+// SetBSON implements bson.Setter
+func (l *OrderLock) SetBSON(raw bson.Raw) error {
+	decoded := &OrderLockRecord{}
+
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	l.OrderHash = common.HexToHash(decoded.OrderHash)
+	l.UserAddress = common.HexToAddress(decoded.UserAddress)
+	l.Token = common.HexToAddress(decoded.Token)
+	l.UpdatedAt = decoded.UpdatedAt
+
+	if decoded.Amount != "" {
+		l.Amount = math.ToBigInt(decoded.Amount)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package types
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Default and maximum number of orders or trades returned in a page
+const (
+	DefaultPageLimit = 100
+	MaxPageLimit     = 1000
+)
+
+// Cursor is the position of the last document of a page. Pages are sorted by decreasing
+// creation date and decreasing id, the id breaking the ties between documents created
+// at the same time
+type Cursor struct {
+	CreatedAt time.Time
+	ID        bson.ObjectId
+}
+
+// HistoryFilter selects a page of orders or trades. Zero fields are not filtered on
+type HistoryFilter struct {
+	UserAddress common.Address
+	BaseToken   common.Address
+	QuoteToken  common.Address
+	Side        string
+	Status      string
+	From        time.Time
+	To          time.Time
+	Cursor      *Cursor
+	Limit       int
+}
+
+// NewCursor returns the cursor pointing after a document
+func NewCursor(createdAt time.Time, id bson.ObjectId) *Cursor {
+	return &Cursor{createdAt, id}
+}
+
+// Encode returns the opaque string representation of a cursor
+func (c *Cursor) Encode() string {
+	s := fmt.Sprintf("%d:%s", c.CreatedAt.UnixNano(), c.ID.Hex())
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+// DecodeCursor parses a cursor encoded with Encode
+func DecodeCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("Invalid cursor")
+	}
+
+	parts := strings.Split(string(b), ":")
+	if len(parts) != 2 || !bson.IsObjectIdHex(parts[1]) {
+		return nil, errors.New("Invalid cursor")
+	}
+
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, errors.New("Invalid cursor")
+	}
+
+	return &Cursor{time.Unix(0, nanos), bson.ObjectIdHex(parts[1])}, nil
+}
+
+// Validate checks the parameters of a history filter
+func (f *HistoryFilter) Validate() error {
+	if (f.BaseToken == common.Address{}) != (f.QuoteToken == common.Address{}) {
+		return errors.New("baseToken and quoteToken parameters should be set together")
+	}
+
+	if f.Side != "" && f.Side != BUY && f.Side != SELL {
+		return errors.New("side parameter should be BUY or SELL")
+	}
+
+	if !f.From.IsZero() && !f.To.IsZero() && f.To.Before(f.From) {
+		return errors.New("to parameter should be after the from parameter")
+	}
+
+	if f.Limit < 0 {
+		return errors.New("limit parameter should be positive")
+	}
+
+	return nil
+}
+
+// PageLimit returns the number of documents of a page, bounded by MaxPageLimit
+func (f *HistoryFilter) PageLimit() int {
+	if f.Limit == 0 {
+		return DefaultPageLimit
+	}
+
+	if f.Limit > MaxPageLimit {
+		return MaxPageLimit
+	}
+
+	return f.Limit
+}
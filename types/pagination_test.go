@@ -0,0 +1,55 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestCursorEncoding(t *testing.T) {
+	c := NewCursor(time.Unix(1530000000, 123456789), bson.NewObjectId())
+
+	decoded, err := DecodeCursor(c.Encode())
+	if err != nil {
+		t.Fatalf("Could not decode cursor: %v", err)
+	}
+
+	if !decoded.CreatedAt.Equal(c.CreatedAt) || decoded.ID != c.ID {
+		t.Errorf("Expected %v, got %v", c, decoded)
+	}
+
+	for _, s := range []string{"", "not a cursor", "MTIzOmFiYw"} {
+		_, err := DecodeCursor(s)
+		if err == nil {
+			t.Errorf("Expected an error decoding %q", s)
+		}
+	}
+}
+
+func TestHistoryFilterValidate(t *testing.T) {
+	f := &HistoryFilter{BaseToken: common.HexToAddress("0x1")}
+	if f.Validate() == nil {
+		t.Error("Expected an error for a base token without quote token")
+	}
+
+	f = &HistoryFilter{Side: "HOLD"}
+	if f.Validate() == nil {
+		t.Error("Expected an error for an invalid side")
+	}
+
+	f = &HistoryFilter{From: time.Unix(200, 0), To: time.Unix(100, 0)}
+	if f.Validate() == nil {
+		t.Error("Expected an error for an inverted time range")
+	}
+
+	f = &HistoryFilter{Side: BUY, Limit: 5000}
+	if err := f.Validate(); err != nil {
+		t.Errorf("Expected a valid filter, got %v", err)
+	}
+
+	if f.PageLimit() != MaxPageLimit {
+		t.Errorf("Expected page limit %v, got %v", MaxPageLimit, f.PageLimit())
+	}
+}
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Discrepancy types reported by the fill reconciliation
+const (
+	ORDER_FILL_MISMATCH      = "ORDER_FILL_MISMATCH"
+	ORDER_CANCELLED_ON_CHAIN = "ORDER_CANCELLED_ON_CHAIN"
+	TRADE_SETTLED            = "TRADE_SETTLED"
+	TRADE_NOT_SETTLED        = "TRADE_NOT_SETTLED"
+	TRADE_DROPPED            = "TRADE_DROPPED"
+)
+
+// Discrepancy describes an order or a trade whose state in the database
+// disagrees with the Filled and Traded mappings of the exchange contract
+type Discrepancy struct {
+	Type      string      `json:"type"`
+	Hash      common.Hash `json:"hash"`
+	Database  string      `json:"database"`
+	Chain     string      `json:"chain"`
+	Corrected bool        `json:"corrected"`
+}
+
+// ReconciliationReport is the result of a fill reconciliation run
+type ReconciliationReport struct {
+	AutoCorrect   bool           `json:"autoCorrect"`
+	CheckedOrders int            `json:"checkedOrders"`
+	CheckedTrades int            `json:"checkedTrades"`
+	Discrepancies []*Discrepancy `json:"discrepancies"`
+	StartedAt     time.Time      `json:"startedAt"`
+	CompletedAt   time.Time      `json:"completedAt"`
+}
+
+func (d *Discrepancy) MarshalJSON() ([]byte, error) {
+	discrepancy := map[string]interface{}{
+		"type":      d.Type,
+		"hash":      d.Hash.Hex(),
+		"database":  d.Database,
+		"chain":     d.Chain,
+		"corrected": d.Corrected,
+	}
+
+	return json.Marshal(discrepancy)
+}
+
+func (r *ReconciliationReport) MarshalJSON() ([]byte, error) {
+	discrepancies := r.Discrepancies
+	if discrepancies == nil {
+		discrepancies = []*Discrepancy{}
+	}
+
+	report := map[string]interface{}{
+		"autoCorrect":   r.AutoCorrect,
+		"checkedOrders": r.CheckedOrders,
+		"checkedTrades": r.CheckedTrades,
+		"discrepancies": discrepancies,
+		"startedAt":     r.StartedAt.Format(time.RFC3339Nano),
+		"completedAt":   r.CompletedAt.Format(time.RFC3339Nano),
+	}
+
+	return json.Marshal(report)
+}
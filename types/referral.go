@@ -0,0 +1,297 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/sha3"
+	"github.com/tomochain/dex-server/app"
+	"github.com/tomochain/dex-server/errors"
+	"github.com/tomochain/dex-server/utils/math"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ReferralRegistration is a message signed by a referee to register the account that referred it
+type ReferralRegistration struct {
+	ExchangeAddress common.Address `json:"exchangeAddress"`
+	ReferrerAddress common.Address `json:"referrerAddress"`
+	RefereeAddress  common.Address `json:"refereeAddress"`
+	Hash            common.Hash    `json:"hash"`
+	Signature       *Signature     `json:"signature"`
+}
+
+// Referral links a referee to its referrer. An account can only be referred once
+type Referral struct {
+	RefereeAddress  common.Address `json:"refereeAddress" bson:"refereeAddress"`
+	ReferrerAddress common.Address `json:"referrerAddress" bson:"referrerAddress"`
+	Hash            common.Hash    `json:"hash" bson:"hash"`
+	CreatedAt       time.Time      `json:"createdAt" bson:"createdAt"`
+}
+
+// ReferralRecord is the struct which is stored in db
+type ReferralRecord struct {
+	RefereeAddress  string    `json:"refereeAddress" bson:"refereeAddress"`
+	ReferrerAddress string    `json:"referrerAddress" bson:"referrerAddress"`
+	Hash            string    `json:"hash" bson:"hash"`
+	CreatedAt       time.Time `json:"createdAt" bson:"createdAt"`
+}
+
+// ReferralRebate is the share of the taker fee of a settled trade accrued to the referrer of the taker.
+// The volume of the trade and the fees are denominated in quote token units
+type ReferralRebate struct {
+	TradeHash       common.Hash    `json:"tradeHash" bson:"tradeHash"`
+	ReferrerAddress common.Address `json:"referrerAddress" bson:"referrerAddress"`
+	RefereeAddress  common.Address `json:"refereeAddress" bson:"refereeAddress"`
+	QuoteToken      common.Address `json:"quoteToken" bson:"quoteToken"`
+	Volume          *big.Int       `json:"volume" bson:"volume"`
+	Fee             *big.Int       `json:"fee" bson:"fee"`
+	Amount          *big.Int       `json:"amount" bson:"amount"`
+	CreatedAt       time.Time      `json:"createdAt" bson:"createdAt"`
+}
+
+// ReferralRebateRecord is the struct which is stored in db
+type ReferralRebateRecord struct {
+	TradeHash       string    `json:"tradeHash" bson:"tradeHash"`
+	ReferrerAddress string    `json:"referrerAddress" bson:"referrerAddress"`
+	RefereeAddress  string    `json:"refereeAddress" bson:"refereeAddress"`
+	QuoteToken      string    `json:"quoteToken" bson:"quoteToken"`
+	Volume          string    `json:"volume" bson:"volume"`
+	Fee             string    `json:"fee" bson:"fee"`
+	Amount          string    `json:"amount" bson:"amount"`
+	CreatedAt       time.Time `json:"createdAt" bson:"createdAt"`
+}
+
+// ReferralSummary is the traded volume of a referee in a quote token and the rebates
+// its trades earned to its referrer
+type ReferralSummary struct {
+	RefereeAddress common.Address
+	QuoteToken     common.Address
+	Volume         *big.Int
+	Rebates        *big.Int
+	TradeCount     int
+}
+
+// Validate checks the parameters of a referral registration
+func (r *ReferralRegistration) Validate() error {
+	if r.ExchangeAddress != common.HexToAddress(app.Config.Ethereum["exchange_address"]) {
+		return errors.New("ReferralRegistration 'exchangeAddress' parameter is incorrect")
+	}
+
+	if (r.ReferrerAddress == common.Address{}) {
+		return errors.New("ReferralRegistration 'referrerAddress' parameter is required")
+	}
+
+	if (r.RefereeAddress == common.Address{}) {
+		return errors.New("ReferralRegistration 'refereeAddress' parameter is required")
+	}
+
+	if r.ReferrerAddress == r.RefereeAddress {
+		return errors.New("An account can not refer itself")
+	}
+
+	if r.Signature == nil {
+		return errors.New("ReferralRegistration 'signature' parameter is required")
+	}
+
+	return nil
+}
+
+// ComputeHash computes the hash of a referral registration
+func (r *ReferralRegistration) ComputeHash() common.Hash {
+	sha := sha3.NewKeccak256()
+	sha.Write([]byte(REFERRAL_SIGNATURE_DOMAIN))
+	sha.Write(r.ExchangeAddress.Bytes())
+	sha.Write(r.ReferrerAddress.Bytes())
+	sha.Write(r.RefereeAddress.Bytes())
+	return common.BytesToHash(sha.Sum(nil))
+}
+
+// VerifySignature checks that the registration signature corresponds to the address in the refereeAddress field
+func (r *ReferralRegistration) VerifySignature() (bool, error) {
+	r.Hash = r.ComputeHash()
+
+	message := crypto.Keccak256(
+		[]byte("\x19Ethereum Signed Message:\n32"),
+		r.Hash.Bytes(),
+	)
+
+	address, err := r.Signature.Verify(common.BytesToHash(message))
+	if err != nil {
+		return false, err
+	}
+
+	if address != r.RefereeAddress {
+		return false, errors.New("Recovered address is incorrect")
+	}
+
+	return true, nil
+}
+
+// UnmarshalJSON creates a ReferralRegistration object from a json byte string
+func (r *ReferralRegistration) UnmarshalJSON(b []byte) error {
+	parsed := map[string]interface{}{}
+
+	err := json.Unmarshal(b, &parsed)
+	if err != nil {
+		return err
+	}
+
+	if parsed["exchangeAddress"] != nil {
+		r.ExchangeAddress = common.HexToAddress(parsed["exchangeAddress"].(string))
+	}
+
+	if parsed["referrerAddress"] != nil {
+		r.ReferrerAddress = common.HexToAddress(parsed["referrerAddress"].(string))
+	}
+
+	if parsed["refereeAddress"] != nil {
+		r.RefereeAddress = common.HexToAddress(parsed["refereeAddress"].(string))
+	}
+
+	if parsed["hash"] != nil {
+		r.Hash = common.HexToHash(parsed["hash"].(string))
+	}
+
+	if parsed["signature"] != nil {
+		sig := parsed["signature"].(map[string]interface{})
+		r.Signature = &Signature{
+			V: byte(sig["v"].(float64)),
+			R: common.HexToHash(sig["r"].(string)),
+			S: common.HexToHash(sig["s"].(string)),
+		}
+	}
+
+	return nil
+}
+
+func (r *Referral) MarshalJSON() ([]byte, error) {
+	referral := map[string]interface{}{
+		"refereeAddress":  r.RefereeAddress.Hex(),
+		"referrerAddress": r.ReferrerAddress.Hex(),
+		"hash":            r.Hash.Hex(),
+		"createdAt":       r.CreatedAt.Format(time.RFC3339Nano),
+	}
+
+	return json.Marshal(referral)
+}
+
+// GetBSON implements bson.Getter
+func (r *Referral) GetBSON() (interface{}, error) {
+	return ReferralRecord{
+		RefereeAddress:  r.RefereeAddress.Hex(),
+		ReferrerAddress: r.ReferrerAddress.Hex(),
+		Hash:            r.Hash.Hex(),
+		CreatedAt:       r.CreatedAt,
+	}, nil
+}
+
+// SetBSON implements bson.Setter
+func (r *Referral) SetBSON(raw bson.Raw) error {
+	decoded := &ReferralRecord{}
+
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	r.RefereeAddress = common.HexToAddress(decoded.RefereeAddress)
+	r.ReferrerAddress = common.HexToAddress(decoded.ReferrerAddress)
+	r.Hash = common.HexToHash(decoded.Hash)
+	r.CreatedAt = decoded.CreatedAt
+
+	return nil
+}
+
+func (r *ReferralRebate) MarshalJSON() ([]byte, error) {
+	rebate := map[string]interface{}{
+		"tradeHash":       r.TradeHash.Hex(),
+		"referrerAddress": r.ReferrerAddress.Hex(),
+		"refereeAddress":  r.RefereeAddress.Hex(),
+		"quoteToken":      r.QuoteToken.Hex(),
+		"createdAt":       r.CreatedAt.Format(time.RFC3339Nano),
+	}
+
+	if r.Volume != nil {
+		rebate["volume"] = r.Volume.String()
+	}
+
+	if r.Fee != nil {
+		rebate["fee"] = r.Fee.String()
+	}
+
+	if r.Amount != nil {
+		rebate["amount"] = r.Amount.String()
+	}
+
+	return json.Marshal(rebate)
+}
+
+// GetBSON implements bson.Getter
+func (r *ReferralRebate) GetBSON() (interface{}, error) {
+	rr := ReferralRebateRecord{
+		TradeHash:       r.TradeHash.Hex(),
+		ReferrerAddress: r.ReferrerAddress.Hex(),
+		RefereeAddress:  r.RefereeAddress.Hex(),
+		QuoteToken:      r.QuoteToken.Hex(),
+		CreatedAt:       r.CreatedAt,
+	}
+
+	if r.Volume != nil {
+		rr.Volume = r.Volume.String()
+	}
+
+	if r.Fee != nil {
+		rr.Fee = r.Fee.String()
+	}
+
+	if r.Amount != nil {
+		rr.Amount = r.Amount.String()
+	}
+
+	return rr, nil
+}
+
+// SetBSON implements bson.Setter
+func (r *ReferralRebate) SetBSON(raw bson.Raw) error {
+	decoded := &ReferralRebateRecord{}
+
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	r.TradeHash = common.HexToHash(decoded.TradeHash)
+	r.ReferrerAddress = common.HexToAddress(decoded.ReferrerAddress)
+	r.RefereeAddress = common.HexToAddress(decoded.RefereeAddress)
+	r.QuoteToken = common.HexToAddress(decoded.QuoteToken)
+	r.CreatedAt = decoded.CreatedAt
+
+	if decoded.Volume != "" {
+		r.Volume = math.ToBigInt(decoded.Volume)
+	}
+
+	if decoded.Fee != "" {
+		r.Fee = math.ToBigInt(decoded.Fee)
+	}
+
+	if decoded.Amount != "" {
+		r.Amount = math.ToBigInt(decoded.Amount)
+	}
+
+	return nil
+}
+
+func (s *ReferralSummary) MarshalJSON() ([]byte, error) {
+	summary := map[string]interface{}{
+		"refereeAddress": s.RefereeAddress.Hex(),
+		"quoteToken":     s.QuoteToken.Hex(),
+		"volume":         s.Volume.String(),
+		"rebates":        s.Rebates.String(),
+		"tradeCount":     s.TradeCount,
+	}
+
+	return json.Marshal(summary)
+}
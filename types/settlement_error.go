@@ -0,0 +1,101 @@
+package types
+
+import "fmt"
+
+// Settlement error codes. The contract error codes correspond to the error ids
+// emitted by the exchange smart contract in LogError events
+const (
+	ErrCodeInvalidSignature      = "INVALID_SIGNATURE"
+	ErrCodeInvalidMakerSignature = "INVALID_MAKER_SIGNATURE"
+	ErrCodeInvalidTakerSignature = "INVALID_TAKER_SIGNATURE"
+	ErrCodeInvalidOrderSides     = "INVALID_ORDER_SIDES"
+	ErrCodePricepointMismatch    = "PRICEPOINT_MISMATCH"
+	ErrCodeTradeAlreadyCompleted = "TRADE_ALREADY_COMPLETED"
+	ErrCodeTradeAmountTooBig     = "TRADE_AMOUNT_TOO_BIG"
+	ErrCodeRoundingError         = "ROUNDING_ERROR"
+	ErrCodePairNotRegistered     = "PAIR_NOT_REGISTERED"
+	ErrCodeUnknownContractError  = "UNKNOWN_CONTRACT_ERROR"
+	ErrCodeTxReverted            = "TX_REVERTED"
+	ErrCodeTxTimeout             = "TX_TIMEOUT"
+	ErrCodeTxDropped             = "TX_DROPPED"
+	ErrCodeTxSendFailed          = "TX_SEND_FAILED"
+	ErrCodeGasEstimationFailed   = "GAS_ESTIMATION_FAILED"
+	ErrCodeInvalidTrade          = "INVALID_TRADE"
+	ErrCodeSettlementCheckFailed = "SETTLEMENT_CHECK_FAILED"
+	ErrCodeServerError           = "SERVER_ERROR"
+)
+
+var settlementErrorMessages = map[string]string{
+	ErrCodeInvalidSignature:      "Signature invalid",
+	ErrCodeInvalidMakerSignature: "Maker signature invalid",
+	ErrCodeInvalidTakerSignature: "Taker signature invalid",
+	ErrCodeInvalidOrderSides:     "Orders should have opposite side",
+	ErrCodePricepointMismatch:    "Pricepoints do no match",
+	ErrCodeTradeAlreadyCompleted: "Trades already completed or cancelled",
+	ErrCodeTradeAmountTooBig:     "Trade amount is too large",
+	ErrCodeRoundingError:         "Rounding error is too large",
+	ErrCodePairNotRegistered:     "Pair is not registered on the exchange contract",
+	ErrCodeUnknownContractError:  "Unknown error",
+	ErrCodeTxReverted:            "Transaction reverted",
+	ErrCodeTxTimeout:             "Transaction was not mined in time, its outcome is unknown",
+	ErrCodeTxDropped:             "Transaction was never mined",
+	ErrCodeTxSendFailed:          "Transaction could not be sent",
+	ErrCodeGasEstimationFailed:   "Transaction gas estimation failed",
+	ErrCodeInvalidTrade:          "Trade is invalid",
+	ErrCodeSettlementCheckFailed: "Settlement checks could not be run on the exchange contract",
+	ErrCodeServerError:           "Server error",
+}
+
+var contractErrorCodes = map[int]string{
+	1: ErrCodeInvalidSignature,
+	2: ErrCodeInvalidMakerSignature,
+	3: ErrCodeInvalidTakerSignature,
+	4: ErrCodeInvalidOrderSides,
+	5: ErrCodePricepointMismatch,
+	6: ErrCodeTradeAlreadyCompleted,
+	7: ErrCodeTradeAmountTooBig,
+	8: ErrCodeRoundingError,
+}
+
+// SettlementError describes why the on-chain settlement of a trade failed
+type SettlementError struct {
+	Code            string `json:"code" bson:"code"`
+	Message         string `json:"message" bson:"message"`
+	ContractErrorID int    `json:"contractErrorId,omitempty" bson:"contractErrorId,omitempty"`
+}
+
+// NewSettlementError returns the settlement error corresponding to a given code
+func NewSettlementError(code string) *SettlementError {
+	msg, ok := settlementErrorMessages[code]
+	if !ok {
+		code = ErrCodeServerError
+		msg = settlementErrorMessages[ErrCodeServerError]
+	}
+
+	return &SettlementError{Code: code, Message: msg}
+}
+
+// NewContractSettlementError returns the settlement error corresponding to
+// an error id emitted by the exchange smart contract
+func NewContractSettlementError(id int) *SettlementError {
+	code, ok := contractErrorCodes[id]
+	if !ok {
+		code = ErrCodeUnknownContractError
+	}
+
+	e := NewSettlementError(code)
+	e.ContractErrorID = id
+	return e
+}
+
+func (e *SettlementError) Error() string {
+	return e.Message
+}
+
+func (e *SettlementError) String() string {
+	if e.ContractErrorID != 0 {
+		return fmt.Sprintf("%v (contract error %v): %v", e.Code, e.ContractErrorID, e.Message)
+	}
+
+	return fmt.Sprintf("%v: %v", e.Code, e.Message)
+}
@@ -9,6 +9,14 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
+// Domains of the messages signed by the accounts. The domain is the first field hashed in a message,
+// so that the signature of a message of one type can not be replayed as a message of another type
+const (
+	LOGIN_SIGNATURE_DOMAIN     = "TomoDEX Login"
+	MIN_NONCE_SIGNATURE_DOMAIN = "TomoDEX Min Nonce"
+	REFERRAL_SIGNATURE_DOMAIN  = "TomoDEX Referral"
+)
+
 // Signature struct
 type Signature struct {
 	V byte
@@ -35,6 +43,28 @@ func NewSignature(b []byte) (*Signature, error) {
 	}, nil
 }
 
+// parseSignature returns the signature of a decoded json payload, with its v, r and s fields.
+// It returns an error instead of panicking if a field is missing or of an unexpected type
+func parseSignature(v interface{}) (*Signature, error) {
+	sig, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("Invalid signature")
+	}
+
+	sigV, okV := sig["v"].(float64)
+	sigR, okR := sig["r"].(string)
+	sigS, okS := sig["s"].(string)
+	if !okV || !okR || !okS {
+		return nil, errors.New("Invalid signature")
+	}
+
+	return &Signature{
+		V: byte(sigV),
+		R: common.HexToHash(sigR),
+		S: common.HexToHash(sigS),
+	}, nil
+}
+
 func (s *Signature) GetRecord() *SignatureRecord {
 	return &SignatureRecord{
 		V: s.V,
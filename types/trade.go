@@ -37,24 +37,27 @@ type Trade struct {
 	PricePoint *big.Int    `json:"pricepoint" bson:"pricepoint"`
 	Status     string      `json:"status" bson:"status"`
 	Amount     *big.Int    `json:"amount" bson:"amount"`
+	// SettlementError is set when the on-chain settlement of the trade failed
+	SettlementError *SettlementError `json:"settlementError,omitempty" bson:"settlementError,omitempty"`
 }
 
 type TradeRecord struct {
-	ID             bson.ObjectId `json:"id" bson:"_id"`
-	Taker          string        `json:"taker" bson:"taker"`
-	Maker          string        `json:"maker" bson:"maker"`
-	BaseToken      string        `json:"baseToken" bson:"baseToken"`
-	QuoteToken     string        `json:"quoteToken" bson:"quoteToken"`
-	MakerOrderHash string        `json:"makerOrderHash" bson:"makerOrderHash"`
-	TakerOrderHash string        `json:"takerOrderHash" bson:"takerOrderHash"`
-	Hash           string        `json:"hash" bson:"hash"`
-	TxHash         string        `json:"txHash" bson:"txHash"`
-	PairName       string        `json:"pairName" bson:"pairName"`
-	CreatedAt      time.Time     `json:"createdAt" bson:"createdAt"`
-	UpdatedAt      time.Time     `json:"updatedAt" bson:"updatedAt"`
-	PricePoint     string        `json:"pricepoint" bson:"pricepoint"`
-	Amount         string        `json:"amount" bson:"amount"`
-	Status         string        `json:"status" bson:"status"`
+	ID              bson.ObjectId    `json:"id" bson:"_id"`
+	Taker           string           `json:"taker" bson:"taker"`
+	Maker           string           `json:"maker" bson:"maker"`
+	BaseToken       string           `json:"baseToken" bson:"baseToken"`
+	QuoteToken      string           `json:"quoteToken" bson:"quoteToken"`
+	MakerOrderHash  string           `json:"makerOrderHash" bson:"makerOrderHash"`
+	TakerOrderHash  string           `json:"takerOrderHash" bson:"takerOrderHash"`
+	Hash            string           `json:"hash" bson:"hash"`
+	TxHash          string           `json:"txHash" bson:"txHash"`
+	PairName        string           `json:"pairName" bson:"pairName"`
+	CreatedAt       time.Time        `json:"createdAt" bson:"createdAt"`
+	UpdatedAt       time.Time        `json:"updatedAt" bson:"updatedAt"`
+	PricePoint      string           `json:"pricepoint" bson:"pricepoint"`
+	Amount          string           `json:"amount" bson:"amount"`
+	Status          string           `json:"status" bson:"status"`
+	SettlementError *SettlementError `json:"settlementError,omitempty" bson:"settlementError,omitempty"`
 }
 
 // NewTrade returns a new unsigned trade corresponding to an Order, amount and taker address
@@ -158,6 +161,10 @@ func (t *Trade) MarshalJSON() ([]byte, error) {
 		trade["makerOrderHash"] = t.MakerOrderHash.Hex()
 	}
 
+	if t.SettlementError != nil {
+		trade["settlementError"] = t.SettlementError
+	}
+
 	return json.Marshal(trade)
 }
 
@@ -240,26 +247,42 @@ func (t *Trade) UnmarshalJSON(b []byte) error {
 		t.CreatedAt = tm
 	}
 
+	if e, ok := trade["settlementError"].(map[string]interface{}); ok {
+		t.SettlementError = &SettlementError{}
+		if e["code"] != nil {
+			t.SettlementError.Code = e["code"].(string)
+		}
+
+		if e["message"] != nil {
+			t.SettlementError.Message = e["message"].(string)
+		}
+
+		if e["contractErrorId"] != nil {
+			t.SettlementError.ContractErrorID = int(e["contractErrorId"].(float64))
+		}
+	}
+
 	return nil
 }
 
 func (t *Trade) GetBSON() (interface{}, error) {
 	tr := TradeRecord{
-		ID:             t.ID,
-		PairName:       t.PairName,
-		Maker:          t.Maker.Hex(),
-		Taker:          t.Taker.Hex(),
-		BaseToken:      t.BaseToken.Hex(),
-		QuoteToken:     t.QuoteToken.Hex(),
-		MakerOrderHash: t.MakerOrderHash.Hex(),
-		Hash:           t.Hash.Hex(),
-		TxHash:         t.TxHash.Hex(),
-		TakerOrderHash: t.TakerOrderHash.Hex(),
-		CreatedAt:      t.CreatedAt,
-		UpdatedAt:      t.UpdatedAt,
-		PricePoint:     t.PricePoint.String(),
-		Status:         t.Status,
-		Amount:         t.Amount.String(),
+		ID:              t.ID,
+		PairName:        t.PairName,
+		Maker:           t.Maker.Hex(),
+		Taker:           t.Taker.Hex(),
+		BaseToken:       t.BaseToken.Hex(),
+		QuoteToken:      t.QuoteToken.Hex(),
+		MakerOrderHash:  t.MakerOrderHash.Hex(),
+		Hash:            t.Hash.Hex(),
+		TxHash:          t.TxHash.Hex(),
+		TakerOrderHash:  t.TakerOrderHash.Hex(),
+		CreatedAt:       t.CreatedAt,
+		UpdatedAt:       t.UpdatedAt,
+		PricePoint:      t.PricePoint.String(),
+		Status:          t.Status,
+		Amount:          t.Amount.String(),
+		SettlementError: t.SettlementError,
 	}
 
 	return tr, nil
@@ -267,21 +290,22 @@ func (t *Trade) GetBSON() (interface{}, error) {
 
 func (t *Trade) SetBSON(raw bson.Raw) error {
 	decoded := new(struct {
-		ID             bson.ObjectId `json:"id,omitempty" bson:"_id"`
-		PairName       string        `json:"pairName" bson:"pairName"`
-		Taker          string        `json:"taker" bson:"taker"`
-		Maker          string        `json:"maker" bson:"maker"`
-		BaseToken      string        `json:"baseToken" bson:"baseToken"`
-		QuoteToken     string        `json:"quoteToken" bson:"quoteToken"`
-		MakerOrderHash string        `json:"makerOrderHash" bson:"makerOrderHash"`
-		TakerOrderHash string        `json:"takerOrderHash" bson:"takerOrderHash"`
-		Hash           string        `json:"hash" bson:"hash"`
-		TxHash         string        `json:"txHash" bson:"txHash"`
-		CreatedAt      time.Time     `json:"createdAt" bson:"createdAt"`
-		UpdatedAt      time.Time     `json:"updatedAt" bson:"updatedAt"`
-		PricePoint     string        `json:"pricepoint" bson:"pricepoint"`
-		Status         string        `json:"status" bson:"status"`
-		Amount         string        `json:"amount" bson:"amount"`
+		ID              bson.ObjectId    `json:"id,omitempty" bson:"_id"`
+		PairName        string           `json:"pairName" bson:"pairName"`
+		Taker           string           `json:"taker" bson:"taker"`
+		Maker           string           `json:"maker" bson:"maker"`
+		BaseToken       string           `json:"baseToken" bson:"baseToken"`
+		QuoteToken      string           `json:"quoteToken" bson:"quoteToken"`
+		MakerOrderHash  string           `json:"makerOrderHash" bson:"makerOrderHash"`
+		TakerOrderHash  string           `json:"takerOrderHash" bson:"takerOrderHash"`
+		Hash            string           `json:"hash" bson:"hash"`
+		TxHash          string           `json:"txHash" bson:"txHash"`
+		CreatedAt       time.Time        `json:"createdAt" bson:"createdAt"`
+		UpdatedAt       time.Time        `json:"updatedAt" bson:"updatedAt"`
+		PricePoint      string           `json:"pricepoint" bson:"pricepoint"`
+		Status          string           `json:"status" bson:"status"`
+		Amount          string           `json:"amount" bson:"amount"`
+		SettlementError *SettlementError `json:"settlementError,omitempty" bson:"settlementError,omitempty"`
 	})
 
 	err := raw.Unmarshal(decoded)
@@ -302,6 +326,7 @@ func (t *Trade) SetBSON(raw bson.Raw) error {
 	t.Status = decoded.Status
 	t.Amount = math.ToBigInt(decoded.Amount)
 	t.PricePoint = math.ToBigInt(decoded.PricePoint)
+	t.SettlementError = decoded.SettlementError
 
 	t.CreatedAt = decoded.CreatedAt
 	t.UpdatedAt = decoded.UpdatedAt
@@ -372,6 +397,10 @@ func (t TradeBSONUpdate) GetBSON() (interface{}, error) {
 		set["amount"] = t.Amount.String()
 	}
 
+	if t.SettlementError != nil {
+		set["settlementError"] = t.SettlementError
+	}
+
 	setOnInsert := bson.M{
 		"_id":       bson.NewObjectId(),
 		"hash":      t.Hash.Hex(),
@@ -71,6 +71,7 @@ func (w *Wallet) GetBSON() (interface{}, error) {
 		Address:    w.Address.Hex(),
 		PrivateKey: hex.EncodeToString(w.PrivateKey.D.Bytes()),
 		Admin:      w.Admin,
+		Operator:   w.Operator,
 	}, nil
 }
 
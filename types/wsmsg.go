@@ -28,6 +28,7 @@ const (
 	ORDER_FILLED           = "ORDER_FILLED"
 	ORDER_PARTIALLY_FILLED = "ORDER_PARTIALLY_FILLED"
 	ORDER_CANCELLED        = "ORDER_CANCELLED"
+	ORDER_CORRECTED        = "ORDER_CORRECTED"
 
 	UPDATE_STATUS = "UPDATE"
 	ERROR_STATUS  = "ERROR"
@@ -39,8 +40,13 @@ const (
 	TRADE_TX_PENDING = "TRADE_TX_PENDING"
 	TRADE_TX_SUCCESS = "TRADE_TX_SUCCESS"
 	TRADE_TX_ERROR   = "TRADE_TX_ERROR"
+	TRADE_TX_TIMEOUT = "TRADE_TX_TIMEOUT"
 	TRADE_INVALID    = "TRADE_INVALID"
 
+	HARD_CANCEL_TX_PENDING = "HARD_CANCEL_TX_PENDING"
+	HARD_CANCEL_TX_SUCCESS = "HARD_CANCEL_TX_SUCCESS"
+	HARD_CANCEL_TX_ERROR   = "HARD_CANCEL_TX_ERROR"
+
 	// channel
 	TradeChannel     = "trades"
 	OrderbookChannel = "orderbook"
@@ -84,6 +90,21 @@ type OrderSuccessPayload struct {
 	Matches *Matches `json:"matches"`
 }
 
+// OrderErrorPayload is sent to the taker (with the matches) and to the
+// makers (with their order) when the settlement of a trade fails
+type OrderErrorPayload struct {
+	Matches         *Matches         `json:"matches,omitempty"`
+	Order           *Order           `json:"order,omitempty"`
+	SettlementError *SettlementError `json:"settlementError"`
+}
+
+// OrderHardCancelPayload is sent to the maker of an order whose on-chain
+// cancellation transaction is pending, confirmed or failed
+type OrderHardCancelPayload struct {
+	Order  *Order      `json:"order"`
+	TxHash common.Hash `json:"txHash"`
+}
+
 type OrderMatchedPayload struct {
 	Matches *Matches `json:"matches"`
 }
@@ -0,0 +1,25 @@
+package httputils
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/tomochain/dex-server/types"
+)
+
+type contextKey string
+
+// authenticationKey is the request context key of the authentication of the sender of a request
+const authenticationKey contextKey = "authentication"
+
+// WithAuthentication returns a copy of a request carrying the authentication of its sender
+func WithAuthentication(r *http.Request, a *types.Authentication) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), authenticationKey, a))
+}
+
+// GetAuthentication returns the authentication of the sender of a request, or nil if the
+// request is anonymous
+func GetAuthentication(r *http.Request) *types.Authentication {
+	a, _ := r.Context().Value(authenticationKey).(*types.Authentication)
+	return a
+}
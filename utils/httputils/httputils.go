@@ -1,6 +1,7 @@
 package httputils
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"net/http"
 )
@@ -18,3 +19,13 @@ func Write(w http.ResponseWriter, code int, payload interface{}) {
 	w.WriteHeader(code)
 	w.Write(response)
 }
+
+// WriteCSV writes the records as a CSV attachment with the given file name
+func WriteCSV(w http.ResponseWriter, code int, filename string, records [][]string) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	w.WriteHeader(code)
+
+	writer := csv.NewWriter(w)
+	writer.WriteAll(records)
+}
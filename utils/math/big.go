@@ -63,6 +63,14 @@ func Max(a, b *big.Int) *big.Int {
 	}
 }
 
+func Min(a, b *big.Int) *big.Int {
+	if a.Cmp(b) == -1 {
+		return a
+	} else {
+		return b
+	}
+}
+
 func IsZero(x *big.Int) bool {
 	if x.Cmp(big.NewInt(0)) == 0 {
 		return true
@@ -78,7 +78,7 @@ func NewClient(w *types.Wallet, s Server) *Client {
 	source := rand.NewSource(time.Now().UnixNano())
 	ng := rand.New(source)
 
-	return &Client{
+	client := &Client{
 		connection:     ws.NewClient(c),
 		Wallet:         w,
 		Requests:       reqs,
@@ -88,6 +88,30 @@ func NewClient(w *types.Wallet, s Server) *Client {
 		Logs:           logs,
 		NonceGenerator: ng,
 	}
+
+	client.subscribeOrders()
+	return client
+}
+
+// subscribeOrders subscribes the client to the private order events of its wallet with a JWT
+func (c *Client) subscribeOrders() {
+	token, err := types.NewAuthClaims(c.Wallet.Address, types.AUTH_ROLE_USER, time.Now().Add(time.Hour)).SignedString()
+	if err != nil {
+		panic(err)
+	}
+
+	msg := types.WebsocketMessage{
+		Channel: types.OrderChannel,
+		Event: types.WebsocketEvent{
+			Type:    types.SUBSCRIBE,
+			Payload: map[string]string{"token": token},
+		},
+	}
+
+	err = c.send(msg)
+	if err != nil {
+		panic(err)
+	}
 }
 
 // send is used to prevent concurrent writes on the websocket connection
@@ -309,7 +333,8 @@ func (c *Client) handleOrderSuccess(e types.WebsocketEvent) {
 func (c *Client) handleOrderError(e types.WebsocketEvent) {
 	o := &types.Order{}
 
-	bytes, err := json.Marshal(e.Payload)
+	payload := e.Payload.(map[string]interface{})
+	bytes, err := json.Marshal(payload["order"])
 	if err != nil {
 		log.Print(err)
 	}
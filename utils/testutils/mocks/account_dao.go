@@ -189,3 +189,74 @@ func (_m *AccountDao) UpdateTokenBalance(owner common.Address, token common.Addr
 
 	return r0
 }
+
+// ClearBalanceCache provides a mock function with given fields: tokens
+func (_m *AccountDao) ClearBalanceCache(tokens ...common.Address) error {
+	_va := make([]interface{}, len(tokens))
+	for _i := range tokens {
+		_va[_i] = tokens[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(...common.Address) error); ok {
+		r0 = rf(tokens...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateLockedBalance provides a mock function with given fields: owner, token, lockedBalance
+func (_m *AccountDao) UpdateLockedBalance(owner common.Address, token common.Address, lockedBalance *big.Int) error {
+	ret := _m.Called(owner, token, lockedBalance)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Address, common.Address, *big.Int) error); ok {
+		r0 = rf(owner, token, lockedBalance)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateRestriction provides a mock function with given fields: owner, r
+func (_m *AccountDao) UpdateRestriction(owner common.Address, r *types.AccountRestriction) error {
+	ret := _m.Called(owner, r)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Address, *types.AccountRestriction) error); ok {
+		r0 = rf(owner, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindOrCreate provides a mock function with given fields: addr
+func (_m *AccountDao) FindOrCreate(addr common.Address) (*types.Account, error) {
+	ret := _m.Called(addr)
+
+	var r0 *types.Account
+	if rf, ok := ret.Get(0).(func(common.Address) *types.Account); ok {
+		r0 = rf(addr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address) error); ok {
+		r1 = rf(addr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
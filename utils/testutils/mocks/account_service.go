@@ -141,3 +141,79 @@ func (_m *AccountService) GetTokenBalances(owner common.Address) (map[common.Add
 
 	return r0, r1
 }
+
+// SetRestriction provides a mock function with given fields: a, r, source
+func (_m *AccountService) SetRestriction(a common.Address, r *types.AccountRestriction, source string) (*types.Account, error) {
+	ret := _m.Called(a, r, source)
+
+	var r0 *types.Account
+	if rf, ok := ret.Get(0).(func(common.Address, *types.AccountRestriction, string) *types.Account); ok {
+		r0 = rf(a, r, source)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, *types.AccountRestriction, string) error); ok {
+		r1 = rf(a, r, source)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRestrictionHistory provides a mock function with given fields: a, limit
+func (_m *AccountService) GetRestrictionHistory(a common.Address, limit ...int) ([]*types.AccountRestrictionChange, error) {
+	_va := make([]interface{}, len(limit))
+	for _i := range limit {
+		_va[_i] = limit[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, a)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []*types.AccountRestrictionChange
+	if rf, ok := ret.Get(0).(func(common.Address, ...int) []*types.AccountRestrictionChange); ok {
+		r0 = rf(a, limit...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.AccountRestrictionChange)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, ...int) error); ok {
+		r1 = rf(a, limit...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindOrCreate provides a mock function with given fields: a
+func (_m *AccountService) FindOrCreate(a common.Address) (*types.Account, error) {
+	ret := _m.Called(a)
+
+	var r0 *types.Account
+	if rf, ok := ret.Get(0).(func(common.Address) *types.Account); ok {
+		r0 = rf(a)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address) error); ok {
+		r1 = rf(a)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -2,6 +2,7 @@
 
 package mocks
 
+import big "math/big"
 import bind "github.com/ethereum/go-ethereum/accounts/abi/bind"
 import common "github.com/ethereum/go-ethereum/common"
 import contractsinterfaces "github.com/tomochain/dex-server/contracts/contractsinterfaces"
@@ -16,20 +17,20 @@ type Exchange struct {
 	mock.Mock
 }
 
-// CallTrade provides a mock function with given fields: o, t, call
-func (_m *Exchange) CallTrade(o *types.Order, t *types.Trade, call *ethereum.CallMsg) (uint64, error) {
-	ret := _m.Called(o, t, call)
+// CallTrade provides a mock function with given fields: m, call
+func (_m *Exchange) CallTrade(m *types.Matches, call *ethereum.CallMsg) (uint64, error) {
+	ret := _m.Called(m, call)
 
 	var r0 uint64
-	if rf, ok := ret.Get(0).(func(*types.Order, *types.Trade, *ethereum.CallMsg) uint64); ok {
-		r0 = rf(o, t, call)
+	if rf, ok := ret.Get(0).(func(*types.Matches, *ethereum.CallMsg) uint64); ok {
+		r0 = rf(m, call)
 	} else {
 		r0 = ret.Get(0).(uint64)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*types.Order, *types.Trade, *ethereum.CallMsg) error); ok {
-		r1 = rf(o, t, call)
+	if rf, ok := ret.Get(1).(func(*types.Matches, *ethereum.CallMsg) error); ok {
+		r1 = rf(m, call)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -76,6 +77,75 @@ func (_m *Exchange) GetAddress() common.Address {
 	return r0
 }
 
+// FilterBatchTrades provides a mock function with given fields: start
+func (_m *Exchange) FilterBatchTrades(start uint64) ([]*contractsinterfaces.ExchangeLogBatchTrades, error) {
+	ret := _m.Called(start)
+
+	var r0 []*contractsinterfaces.ExchangeLogBatchTrades
+	if rf, ok := ret.Get(0).(func(uint64) []*contractsinterfaces.ExchangeLogBatchTrades); ok {
+		r0 = rf(start)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*contractsinterfaces.ExchangeLogBatchTrades)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(start)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FilterErrors provides a mock function with given fields: start
+func (_m *Exchange) FilterErrors(start uint64) ([]*contractsinterfaces.ExchangeLogError, error) {
+	ret := _m.Called(start)
+
+	var r0 []*contractsinterfaces.ExchangeLogError
+	if rf, ok := ret.Get(0).(func(uint64) []*contractsinterfaces.ExchangeLogError); ok {
+		r0 = rf(start)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*contractsinterfaces.ExchangeLogError)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(start)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FilterTrades provides a mock function with given fields: start
+func (_m *Exchange) FilterTrades(start uint64) ([]*contractsinterfaces.ExchangeLogTrade, error) {
+	ret := _m.Called(start)
+
+	var r0 []*contractsinterfaces.ExchangeLogTrade
+	if rf, ok := ret.Get(0).(func(uint64) []*contractsinterfaces.ExchangeLogTrade); ok {
+		r0 = rf(start)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*contractsinterfaces.ExchangeLogTrade)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(start)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetErrorEvents provides a mock function with given fields: logs
 func (_m *Exchange) GetErrorEvents(logs chan *contractsinterfaces.ExchangeLogError) error {
 	ret := _m.Called(logs)
@@ -261,13 +331,237 @@ func (_m *Exchange) SetOperator(a common.Address, isOperator bool, txOpts *bind.
 	return r0, r1
 }
 
-// Trade provides a mock function with given fields: o, t, txOpts
-func (_m *Exchange) Trade(o *types.Order, t *types.Trade, txOpts *bind.TransactOpts) (*coretypes.Transaction, error) {
-	ret := _m.Called(o, t, txOpts)
+// Trade provides a mock function with given fields: m, txOpts
+func (_m *Exchange) Trade(m *types.Matches, txOpts *bind.TransactOpts) (*coretypes.Transaction, error) {
+	ret := _m.Called(m, txOpts)
+
+	var r0 *coretypes.Transaction
+	if rf, ok := ret.Get(0).(func(*types.Matches, *bind.TransactOpts) *coretypes.Transaction); ok {
+		r0 = rf(m, txOpts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.Matches, *bind.TransactOpts) error); ok {
+		r1 = rf(m, txOpts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListenToOperatorUpdates provides a mock function with given fields:
+func (_m *Exchange) ListenToOperatorUpdates() (chan *contractsinterfaces.ExchangeLogOperatorUpdate, error) {
+	ret := _m.Called()
+
+	var r0 chan *contractsinterfaces.ExchangeLogOperatorUpdate
+	if rf, ok := ret.Get(0).(func() chan *contractsinterfaces.ExchangeLogOperatorUpdate); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(chan *contractsinterfaces.ExchangeLogOperatorUpdate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Owner provides a mock function with given fields:
+func (_m *Exchange) Owner() (common.Address, error) {
+	ret := _m.Called()
+
+	var r0 common.Address
+	if rf, ok := ret.Get(0).(func() common.Address); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(common.Address)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RegisterPair provides a mock function with given fields: bt, qt, multiplier, txOpts
+func (_m *Exchange) RegisterPair(bt common.Address, qt common.Address, multiplier *big.Int, txOpts *bind.TransactOpts) (*coretypes.Transaction, error) {
+	ret := _m.Called(bt, qt, multiplier, txOpts)
+
+	var r0 *coretypes.Transaction
+	if rf, ok := ret.Get(0).(func(common.Address, common.Address, *big.Int, *bind.TransactOpts) *coretypes.Transaction); ok {
+		r0 = rf(bt, qt, multiplier, txOpts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, common.Address, *big.Int, *bind.TransactOpts) error); ok {
+		r1 = rf(bt, qt, multiplier, txOpts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetOwner provides a mock function with given fields: a, txOpts
+func (_m *Exchange) SetOwner(a common.Address, txOpts *bind.TransactOpts) (*coretypes.Transaction, error) {
+	ret := _m.Called(a, txOpts)
+
+	var r0 *coretypes.Transaction
+	if rf, ok := ret.Get(0).(func(common.Address, *bind.TransactOpts) *coretypes.Transaction); ok {
+		r0 = rf(a, txOpts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, *bind.TransactOpts) error); ok {
+		r1 = rf(a, txOpts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetWethToken provides a mock function with given fields: a, txOpts
+func (_m *Exchange) SetWethToken(a common.Address, txOpts *bind.TransactOpts) (*coretypes.Transaction, error) {
+	ret := _m.Called(a, txOpts)
+
+	var r0 *coretypes.Transaction
+	if rf, ok := ret.Get(0).(func(common.Address, *bind.TransactOpts) *coretypes.Transaction); ok {
+		r0 = rf(a, txOpts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, *bind.TransactOpts) error); ok {
+		r1 = rf(a, txOpts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WethToken provides a mock function with given fields:
+func (_m *Exchange) WethToken() (common.Address, error) {
+	ret := _m.Called()
+
+	var r0 common.Address
+	if rf, ok := ret.Get(0).(func() common.Address); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(common.Address)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPairPricepointMultiplier provides a mock function with given fields: bt, qt
+func (_m *Exchange) GetPairPricepointMultiplier(bt common.Address, qt common.Address) (*big.Int, error) {
+	ret := _m.Called(bt, qt)
+
+	var r0 *big.Int
+	if rf, ok := ret.Get(0).(func(common.Address, common.Address) *big.Int); ok {
+		r0 = rf(bt, qt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, common.Address) error); ok {
+		r1 = rf(bt, qt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PairIsRegistered provides a mock function with given fields: bt, qt
+func (_m *Exchange) PairIsRegistered(bt common.Address, qt common.Address) (bool, error) {
+	ret := _m.Called(bt, qt)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(common.Address, common.Address) bool); ok {
+		r0 = rf(bt, qt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, common.Address) error); ok {
+		r1 = rf(bt, qt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BatchCancelOrders provides a mock function with given fields: orders, txOpts
+func (_m *Exchange) BatchCancelOrders(orders []*types.Order, txOpts *bind.TransactOpts) (*coretypes.Transaction, error) {
+	ret := _m.Called(orders, txOpts)
+
+	var r0 *coretypes.Transaction
+	if rf, ok := ret.Get(0).(func([]*types.Order, *bind.TransactOpts) *coretypes.Transaction); ok {
+		r0 = rf(orders, txOpts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]*types.Order, *bind.TransactOpts) error); ok {
+		r1 = rf(orders, txOpts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CancelOrder provides a mock function with given fields: o, txOpts
+func (_m *Exchange) CancelOrder(o *types.Order, txOpts *bind.TransactOpts) (*coretypes.Transaction, error) {
+	ret := _m.Called(o, txOpts)
 
 	var r0 *coretypes.Transaction
-	if rf, ok := ret.Get(0).(func(*types.Order, *types.Trade, *bind.TransactOpts) *coretypes.Transaction); ok {
-		r0 = rf(o, t, txOpts)
+	if rf, ok := ret.Get(0).(func(*types.Order, *bind.TransactOpts) *coretypes.Transaction); ok {
+		r0 = rf(o, txOpts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*coretypes.Transaction)
@@ -275,8 +569,272 @@ func (_m *Exchange) Trade(o *types.Order, t *types.Trade, txOpts *bind.TransactO
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*types.Order, *types.Trade, *bind.TransactOpts) error); ok {
-		r1 = rf(o, t, txOpts)
+	if rf, ok := ret.Get(1).(func(*types.Order, *bind.TransactOpts) error); ok {
+		r1 = rf(o, txOpts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FilterCancelOrders provides a mock function with given fields: start
+func (_m *Exchange) FilterCancelOrders(start uint64) ([]*contractsinterfaces.ExchangeLogCancelOrder, error) {
+	ret := _m.Called(start)
+
+	var r0 []*contractsinterfaces.ExchangeLogCancelOrder
+	if rf, ok := ret.Get(0).(func(uint64) []*contractsinterfaces.ExchangeLogCancelOrder); ok {
+		r0 = rf(start)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*contractsinterfaces.ExchangeLogCancelOrder)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(start)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListenToCancelOrders provides a mock function with given fields:
+func (_m *Exchange) ListenToCancelOrders() (chan *contractsinterfaces.ExchangeLogCancelOrder, error) {
+	ret := _m.Called()
+
+	var r0 chan *contractsinterfaces.ExchangeLogCancelOrder
+	if rf, ok := ret.Get(0).(func() chan *contractsinterfaces.ExchangeLogCancelOrder); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(chan *contractsinterfaces.ExchangeLogCancelOrder)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Filled provides a mock function with given fields: h
+func (_m *Exchange) Filled(h common.Hash) (*big.Int, error) {
+	ret := _m.Called(h)
+
+	var r0 *big.Int
+	if rf, ok := ret.Get(0).(func(common.Hash) *big.Int); ok {
+		r0 = rf(h)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
+		r1 = rf(h)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Traded provides a mock function with given fields: h
+func (_m *Exchange) Traded(h common.Hash) (bool, error) {
+	ret := _m.Called(h)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(common.Hash) bool); ok {
+		r0 = rf(h)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
+		r1 = rf(h)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsValidSignature provides a mock function with given fields: signer, h, sig
+func (_m *Exchange) IsValidSignature(signer common.Address, h common.Hash, sig *types.Signature) (bool, error) {
+	ret := _m.Called(signer, h, sig)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(common.Address, common.Hash, *types.Signature) bool); ok {
+		r0 = rf(signer, h, sig)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, common.Hash, *types.Signature) error); ok {
+		r1 = rf(signer, h, sig)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsRoundingError provides a mock function with given fields: numerator, denominator, target
+func (_m *Exchange) IsRoundingError(numerator *big.Int, denominator *big.Int, target *big.Int) (bool, error) {
+	ret := _m.Called(numerator, denominator, target)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*big.Int, *big.Int, *big.Int) bool); ok {
+		r0 = rf(numerator, denominator, target)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*big.Int, *big.Int, *big.Int) error); ok {
+		r1 = rf(numerator, denominator, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Version provides a mock function with given fields:
+func (_m *Exchange) Version() (string, error) {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RewardAccount provides a mock function with given fields:
+func (_m *Exchange) RewardAccount() (common.Address, error) {
+	ret := _m.Called()
+
+	var r0 common.Address
+	if rf, ok := ret.Get(0).(func() common.Address); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(common.Address)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckCompatibility provides a mock function with given fields: weth, rewardAccount
+func (_m *Exchange) CheckCompatibility(weth common.Address, rewardAccount common.Address) (*types.ContractStatus, error) {
+	ret := _m.Called(weth, rewardAccount)
+
+	var r0 *types.ContractStatus
+	if rf, ok := ret.Get(0).(func(common.Address, common.Address) *types.ContractStatus); ok {
+		r0 = rf(weth, rewardAccount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ContractStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, common.Address) error); ok {
+		r1 = rf(weth, rewardAccount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CallBatchTrades provides a mock function with given fields: m, txOpts
+func (_m *Exchange) CallBatchTrades(m *types.Matches, txOpts *ethereum.CallMsg) (uint64, error) {
+	ret := _m.Called(m, txOpts)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(*types.Matches, *ethereum.CallMsg) uint64); ok {
+		r0 = rf(m, txOpts)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.Matches, *ethereum.CallMsg) error); ok {
+		r1 = rf(m, txOpts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExecuteBatchTrades provides a mock function with given fields: m, txOpts
+func (_m *Exchange) ExecuteBatchTrades(m *types.Matches, txOpts *bind.TransactOpts) (*coretypes.Transaction, error) {
+	ret := _m.Called(m, txOpts)
+
+	var r0 *coretypes.Transaction
+	if rf, ok := ret.Get(0).(func(*types.Matches, *bind.TransactOpts) *coretypes.Transaction); ok {
+		r0 = rf(m, txOpts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.Matches, *bind.TransactOpts) error); ok {
+		r1 = rf(m, txOpts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListenToBatchTrades provides a mock function with given fields:
+func (_m *Exchange) ListenToBatchTrades() (chan *contractsinterfaces.ExchangeLogBatchTrades, error) {
+	ret := _m.Called()
+
+	var r0 chan *contractsinterfaces.ExchangeLogBatchTrades
+	if rf, ok := ret.Get(0).(func() chan *contractsinterfaces.ExchangeLogBatchTrades); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(chan *contractsinterfaces.ExchangeLogBatchTrades)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
 		r1 = ret.Error(1)
 	}
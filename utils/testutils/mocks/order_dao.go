@@ -111,13 +111,20 @@ func (_m *OrderDao) GetByID(id bson.ObjectId) (*types.Order, error) {
 	return r0, r1
 }
 
-// GetByUserAddress provides a mock function with given fields: addr
-func (_m *OrderDao) GetByUserAddress(addr common.Address) ([]*types.Order, error) {
-	ret := _m.Called(addr)
+// GetByUserAddress provides a mock function with given fields: addr, limit
+func (_m *OrderDao) GetByUserAddress(addr common.Address, limit ...int) ([]*types.Order, error) {
+	_va := make([]interface{}, len(limit))
+	for _i := range limit {
+		_va[_i] = limit[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, addr)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 []*types.Order
-	if rf, ok := ret.Get(0).(func(common.Address) []*types.Order); ok {
-		r0 = rf(addr)
+	if rf, ok := ret.Get(0).(func(common.Address, ...int) []*types.Order); ok {
+		r0 = rf(addr, limit...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*types.Order)
@@ -125,8 +132,8 @@ func (_m *OrderDao) GetByUserAddress(addr common.Address) ([]*types.Order, error
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(common.Address) error); ok {
-		r1 = rf(addr)
+	if rf, ok := ret.Get(1).(func(common.Address, ...int) error); ok {
+		r1 = rf(addr, limit...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -134,13 +141,20 @@ func (_m *OrderDao) GetByUserAddress(addr common.Address) ([]*types.Order, error
 	return r0, r1
 }
 
-// GetCurrentByUserAddress provides a mock function with given fields: addr
-func (_m *OrderDao) GetCurrentByUserAddress(addr common.Address) ([]*types.Order, error) {
-	ret := _m.Called(addr)
+// GetCurrentByUserAddress provides a mock function with given fields: a, limit
+func (_m *OrderDao) GetCurrentByUserAddress(a common.Address, limit ...int) ([]*types.Order, error) {
+	_va := make([]interface{}, len(limit))
+	for _i := range limit {
+		_va[_i] = limit[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, a)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 []*types.Order
-	if rf, ok := ret.Get(0).(func(common.Address) []*types.Order); ok {
-		r0 = rf(addr)
+	if rf, ok := ret.Get(0).(func(common.Address, ...int) []*types.Order); ok {
+		r0 = rf(a, limit...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*types.Order)
@@ -148,8 +162,8 @@ func (_m *OrderDao) GetCurrentByUserAddress(addr common.Address) ([]*types.Order
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(common.Address) error); ok {
-		r1 = rf(addr)
+	if rf, ok := ret.Get(1).(func(common.Address, ...int) error); ok {
+		r1 = rf(a, limit...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -157,13 +171,20 @@ func (_m *OrderDao) GetCurrentByUserAddress(addr common.Address) ([]*types.Order
 	return r0, r1
 }
 
-// GetHistoryByUserAddress provides a mock function with given fields: addr
-func (_m *OrderDao) GetHistoryByUserAddress(addr common.Address) ([]*types.Order, error) {
-	ret := _m.Called(addr)
+// GetHistoryByUserAddress provides a mock function with given fields: a, limit
+func (_m *OrderDao) GetHistoryByUserAddress(a common.Address, limit ...int) ([]*types.Order, error) {
+	_va := make([]interface{}, len(limit))
+	for _i := range limit {
+		_va[_i] = limit[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, a)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 []*types.Order
-	if rf, ok := ret.Get(0).(func(common.Address) []*types.Order); ok {
-		r0 = rf(addr)
+	if rf, ok := ret.Get(0).(func(common.Address, ...int) []*types.Order); ok {
+		r0 = rf(a, limit...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*types.Order)
@@ -171,8 +192,8 @@ func (_m *OrderDao) GetHistoryByUserAddress(addr common.Address) ([]*types.Order
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(common.Address) error); ok {
-		r1 = rf(addr)
+	if rf, ok := ret.Get(1).(func(common.Address, ...int) error); ok {
+		r1 = rf(a, limit...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -180,13 +201,13 @@ func (_m *OrderDao) GetHistoryByUserAddress(addr common.Address) ([]*types.Order
 	return r0, r1
 }
 
-// GetUserLockedBalance provides a mock function with given fields: account, token
-func (_m *OrderDao) GetUserLockedBalance(account common.Address, token common.Address) (*big.Int, error) {
-	ret := _m.Called(account, token)
+// GetUserLockedBalance provides a mock function with given fields: account, token, p
+func (_m *OrderDao) GetUserLockedBalance(account common.Address, token common.Address, p *types.Pair) (*big.Int, error) {
+	ret := _m.Called(account, token, p)
 
 	var r0 *big.Int
-	if rf, ok := ret.Get(0).(func(common.Address, common.Address) *big.Int); ok {
-		r0 = rf(account, token)
+	if rf, ok := ret.Get(0).(func(common.Address, common.Address, *types.Pair) *big.Int); ok {
+		r0 = rf(account, token, p)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*big.Int)
@@ -194,8 +215,8 @@ func (_m *OrderDao) GetUserLockedBalance(account common.Address, token common.Ad
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(common.Address, common.Address) error); ok {
-		r1 = rf(account, token)
+	if rf, ok := ret.Get(1).(func(common.Address, common.Address, *types.Pair) error); ok {
+		r1 = rf(account, token, p)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -272,3 +293,411 @@ func (_m *OrderDao) UpdateOrderStatus(hash common.Hash, status string) error {
 
 	return r0
 }
+
+// GetOpenOrders provides a mock function with given fields:
+func (_m *OrderDao) GetOpenOrders() ([]*types.Order, error) {
+	ret := _m.Called()
+
+	var r0 []*types.Order
+	if rf, ok := ret.Get(0).(func() []*types.Order); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPage provides a mock function with given fields: f
+func (_m *OrderDao) GetPage(f *types.HistoryFilter) ([]*types.Order, *types.Cursor, error) {
+	ret := _m.Called(f)
+
+	var r0 []*types.Order
+	if rf, ok := ret.Get(0).(func(*types.HistoryFilter) []*types.Order); ok {
+		r0 = rf(f)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Order)
+		}
+	}
+
+	var r1 *types.Cursor
+	if rf, ok := ret.Get(1).(func(*types.HistoryFilter) *types.Cursor); ok {
+		r1 = rf(f)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*types.Cursor)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*types.HistoryFilter) error); ok {
+		r2 = rf(f)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetHistoryPage provides a mock function with given fields: f
+func (_m *OrderDao) GetHistoryPage(f *types.HistoryFilter) ([]*types.Order, *types.Cursor, error) {
+	ret := _m.Called(f)
+
+	var r0 []*types.Order
+	if rf, ok := ret.Get(0).(func(*types.HistoryFilter) []*types.Order); ok {
+		r0 = rf(f)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Order)
+		}
+	}
+
+	var r1 *types.Cursor
+	if rf, ok := ret.Get(1).(func(*types.HistoryFilter) *types.Cursor); ok {
+		r1 = rf(f)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*types.Cursor)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*types.HistoryFilter) error); ok {
+		r2 = rf(f)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Upsert provides a mock function with given fields: id, o
+func (_m *OrderDao) Upsert(id bson.ObjectId, o *types.Order) error {
+	ret := _m.Called(id, o)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(bson.ObjectId, *types.Order) error); ok {
+		r0 = rf(id, o)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: orders
+func (_m *OrderDao) Delete(orders ...*types.Order) error {
+	_va := make([]interface{}, len(orders))
+	for _i := range orders {
+		_va[_i] = orders[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(...*types.Order) error); ok {
+		r0 = rf(orders...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteByHashes provides a mock function with given fields: hashes
+func (_m *OrderDao) DeleteByHashes(hashes ...common.Hash) error {
+	_va := make([]interface{}, len(hashes))
+	for _i := range hashes {
+		_va[_i] = hashes[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(...common.Hash) error); ok {
+		r0 = rf(hashes...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpsertByHash provides a mock function with given fields: h, o
+func (_m *OrderDao) UpsertByHash(h common.Hash, o *types.Order) error {
+	ret := _m.Called(h, o)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Hash, *types.Order) error); ok {
+		r0 = rf(h, o)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetMatchingBuyOrders provides a mock function with given fields: o
+func (_m *OrderDao) GetMatchingBuyOrders(o *types.Order) ([]*types.Order, error) {
+	ret := _m.Called(o)
+
+	var r0 []*types.Order
+	if rf, ok := ret.Get(0).(func(*types.Order) []*types.Order); ok {
+		r0 = rf(o)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.Order) error); ok {
+		r1 = rf(o)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMatchingSellOrders provides a mock function with given fields: o
+func (_m *OrderDao) GetMatchingSellOrders(o *types.Order) ([]*types.Order, error) {
+	ret := _m.Called(o)
+
+	var r0 []*types.Order
+	if rf, ok := ret.Get(0).(func(*types.Order) []*types.Order); ok {
+		r0 = rf(o)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.Order) error); ok {
+		r1 = rf(o)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateOrderFilledAmounts provides a mock function with given fields: h, values
+func (_m *OrderDao) UpdateOrderFilledAmounts(h []common.Hash, values []*big.Int) ([]*types.Order, error) {
+	ret := _m.Called(h, values)
+
+	var r0 []*types.Order
+	if rf, ok := ret.Get(0).(func([]common.Hash, []*big.Int) []*types.Order); ok {
+		r0 = rf(h, values)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]common.Hash, []*big.Int) error); ok {
+		r1 = rf(h, values)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateOrderStatusesByHashes provides a mock function with given fields: status, hashes
+func (_m *OrderDao) UpdateOrderStatusesByHashes(status string, hashes ...common.Hash) ([]*types.Order, error) {
+	_va := make([]interface{}, len(hashes))
+	for _i := range hashes {
+		_va[_i] = hashes[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, status)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []*types.Order
+	if rf, ok := ret.Get(0).(func(string, ...common.Hash) []*types.Order); ok {
+		r0 = rf(status, hashes...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, ...common.Hash) error); ok {
+		r1 = rf(status, hashes...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRawOrderBook provides a mock function with given fields: _a0
+func (_m *OrderDao) GetRawOrderBook(_a0 *types.Pair) ([]*types.Order, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []*types.Order
+	if rf, ok := ret.Get(0).(func(*types.Pair) []*types.Order); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.Pair) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrderBook provides a mock function with given fields: _a0
+func (_m *OrderDao) GetOrderBook(_a0 *types.Pair) ([]map[string]string, []map[string]string, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []map[string]string
+	if rf, ok := ret.Get(0).(func(*types.Pair) []map[string]string); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]map[string]string)
+		}
+	}
+
+	var r1 []map[string]string
+	if rf, ok := ret.Get(1).(func(*types.Pair) []map[string]string); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]map[string]string)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*types.Pair) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetSideOrderBook provides a mock function with given fields: p, side, sort, limit
+func (_m *OrderDao) GetSideOrderBook(p *types.Pair, side string, sort int, limit ...int) ([]map[string]string, error) {
+	_va := make([]interface{}, len(limit))
+	for _i := range limit {
+		_va[_i] = limit[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, p, side, sort)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []map[string]string
+	if rf, ok := ret.Get(0).(func(*types.Pair, string, int, ...int) []map[string]string); ok {
+		r0 = rf(p, side, sort, limit...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]map[string]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.Pair, string, int, ...int) error); ok {
+		r1 = rf(p, side, sort, limit...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrderBookPricePoint provides a mock function with given fields: p, pp, side
+func (_m *OrderDao) GetOrderBookPricePoint(p *types.Pair, pp *big.Int, side string) (*big.Int, error) {
+	ret := _m.Called(p, pp, side)
+
+	var r0 *big.Int
+	if rf, ok := ret.Get(0).(func(*types.Pair, *big.Int, string) *big.Int); ok {
+		r0 = rf(p, pp, side)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.Pair, *big.Int, string) error); ok {
+		r1 = rf(p, pp, side)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindAndModify provides a mock function with given fields: h, o
+func (_m *OrderDao) FindAndModify(h common.Hash, o *types.Order) (*types.Order, error) {
+	ret := _m.Called(h, o)
+
+	var r0 *types.Order
+	if rf, ok := ret.Get(0).(func(common.Hash, *types.Order) *types.Order); ok {
+		r0 = rf(h, o)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash, *types.Order) error); ok {
+		r1 = rf(h, o)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Aggregate provides a mock function with given fields: q
+func (_m *OrderDao) Aggregate(q []bson.M) ([]*types.OrderData, error) {
+	ret := _m.Called(q)
+
+	var r0 []*types.OrderData
+	if rf, ok := ret.Get(0).(func([]bson.M) []*types.OrderData); ok {
+		r0 = rf(q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.OrderData)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]bson.M) error); ok {
+		r1 = rf(q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -2,10 +2,12 @@
 
 package mocks
 
+import big "math/big"
 import bson "gopkg.in/mgo.v2/bson"
 import common "github.com/ethereum/go-ethereum/common"
 
 import mock "github.com/stretchr/testify/mock"
+import time "time"
 import types "github.com/tomochain/dex-server/types"
 
 // OrderService is an autogenerated mock type for the OrderService type
@@ -87,13 +89,20 @@ func (_m *OrderService) GetByID(id bson.ObjectId) (*types.Order, error) {
 	return r0, r1
 }
 
-// GetByUserAddress provides a mock function with given fields: addr
-func (_m *OrderService) GetByUserAddress(addr common.Address) ([]*types.Order, error) {
-	ret := _m.Called(addr)
+// GetByUserAddress provides a mock function with given fields: a, limit
+func (_m *OrderService) GetByUserAddress(a common.Address, limit ...int) ([]*types.Order, error) {
+	_va := make([]interface{}, len(limit))
+	for _i := range limit {
+		_va[_i] = limit[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, a)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 []*types.Order
-	if rf, ok := ret.Get(0).(func(common.Address) []*types.Order); ok {
-		r0 = rf(addr)
+	if rf, ok := ret.Get(0).(func(common.Address, ...int) []*types.Order); ok {
+		r0 = rf(a, limit...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*types.Order)
@@ -101,8 +110,8 @@ func (_m *OrderService) GetByUserAddress(addr common.Address) ([]*types.Order, e
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(common.Address) error); ok {
-		r1 = rf(addr)
+	if rf, ok := ret.Get(1).(func(common.Address, ...int) error); ok {
+		r1 = rf(a, limit...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -110,13 +119,20 @@ func (_m *OrderService) GetByUserAddress(addr common.Address) ([]*types.Order, e
 	return r0, r1
 }
 
-// GetCurrentByUserAddress provides a mock function with given fields: addr
-func (_m *OrderService) GetCurrentByUserAddress(addr common.Address) ([]*types.Order, error) {
-	ret := _m.Called(addr)
+// GetCurrentByUserAddress provides a mock function with given fields: a, limit
+func (_m *OrderService) GetCurrentByUserAddress(a common.Address, limit ...int) ([]*types.Order, error) {
+	_va := make([]interface{}, len(limit))
+	for _i := range limit {
+		_va[_i] = limit[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, a)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 []*types.Order
-	if rf, ok := ret.Get(0).(func(common.Address) []*types.Order); ok {
-		r0 = rf(addr)
+	if rf, ok := ret.Get(0).(func(common.Address, ...int) []*types.Order); ok {
+		r0 = rf(a, limit...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*types.Order)
@@ -124,8 +140,8 @@ func (_m *OrderService) GetCurrentByUserAddress(addr common.Address) ([]*types.O
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(common.Address) error); ok {
-		r1 = rf(addr)
+	if rf, ok := ret.Get(1).(func(common.Address, ...int) error); ok {
+		r1 = rf(a, limit...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -133,13 +149,20 @@ func (_m *OrderService) GetCurrentByUserAddress(addr common.Address) ([]*types.O
 	return r0, r1
 }
 
-// GetHistoryByUserAddress provides a mock function with given fields: addr
-func (_m *OrderService) GetHistoryByUserAddress(addr common.Address) ([]*types.Order, error) {
-	ret := _m.Called(addr)
+// GetHistoryByUserAddress provides a mock function with given fields: a, limit
+func (_m *OrderService) GetHistoryByUserAddress(a common.Address, limit ...int) ([]*types.Order, error) {
+	_va := make([]interface{}, len(limit))
+	for _i := range limit {
+		_va[_i] = limit[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, a)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 []*types.Order
-	if rf, ok := ret.Get(0).(func(common.Address) []*types.Order); ok {
-		r0 = rf(addr)
+	if rf, ok := ret.Get(0).(func(common.Address, ...int) []*types.Order); ok {
+		r0 = rf(a, limit...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*types.Order)
@@ -147,8 +170,8 @@ func (_m *OrderService) GetHistoryByUserAddress(addr common.Address) ([]*types.O
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(common.Address) error); ok {
-		r1 = rf(addr)
+	if rf, ok := ret.Get(1).(func(common.Address, ...int) error); ok {
+		r1 = rf(a, limit...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -242,3 +265,242 @@ func (_m *OrderService) RollbackTrade(o *types.Order, t *types.Trade) error {
 
 	return r0
 }
+
+// UpdateFilledAmount provides a mock function with given fields: o, filledAmount
+func (_m *OrderService) UpdateFilledAmount(o *types.Order, filledAmount *big.Int) error {
+	ret := _m.Called(o, filledAmount)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*types.Order, *big.Int) error); ok {
+		r0 = rf(o, filledAmount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetMinNonce provides a mock function with given fields: addr
+func (_m *OrderService) GetMinNonce(addr common.Address) (*big.Int, error) {
+	ret := _m.Called(addr)
+
+	var r0 *big.Int
+	if rf, ok := ret.Get(0).(func(common.Address) *big.Int); ok {
+		r0 = rf(addr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address) error); ok {
+		r1 = rf(addr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetMinNonce provides a mock function with given fields: m
+func (_m *OrderService) SetMinNonce(m *types.MinNonce) ([]*types.Order, error) {
+	ret := _m.Called(m)
+
+	var r0 []*types.Order
+	if rf, ok := ret.Get(0).(func(*types.MinNonce) []*types.Order); ok {
+		r0 = rf(m)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.MinNonce) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPage provides a mock function with given fields: f
+func (_m *OrderService) GetPage(f *types.HistoryFilter) ([]*types.Order, *types.Cursor, error) {
+	ret := _m.Called(f)
+
+	var r0 []*types.Order
+	if rf, ok := ret.Get(0).(func(*types.HistoryFilter) []*types.Order); ok {
+		r0 = rf(f)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Order)
+		}
+	}
+
+	var r1 *types.Cursor
+	if rf, ok := ret.Get(1).(func(*types.HistoryFilter) *types.Cursor); ok {
+		r1 = rf(f)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*types.Cursor)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*types.HistoryFilter) error); ok {
+		r2 = rf(f)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetHistoryPage provides a mock function with given fields: f
+func (_m *OrderService) GetHistoryPage(f *types.HistoryFilter) ([]*types.Order, *types.Cursor, error) {
+	ret := _m.Called(f)
+
+	var r0 []*types.Order
+	if rf, ok := ret.Get(0).(func(*types.HistoryFilter) []*types.Order); ok {
+		r0 = rf(f)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Order)
+		}
+	}
+
+	var r1 *types.Cursor
+	if rf, ok := ret.Get(1).(func(*types.HistoryFilter) *types.Cursor); ok {
+		r1 = rf(f)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*types.Cursor)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*types.HistoryFilter) error); ok {
+		r2 = rf(f)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewOrderAndWait provides a mock function with given fields: o, timeout
+func (_m *OrderService) NewOrderAndWait(o *types.Order, timeout time.Duration) (*types.EngineResponse, error) {
+	ret := _m.Called(o, timeout)
+
+	var r0 *types.EngineResponse
+	if rf, ok := ret.Get(0).(func(*types.Order, time.Duration) *types.EngineResponse); ok {
+		r0 = rf(o, timeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.EngineResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.Order, time.Duration) error); ok {
+		r1 = rf(o, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CancelOrderAndWait provides a mock function with given fields: oc, timeout
+func (_m *OrderService) CancelOrderAndWait(oc *types.OrderCancel, timeout time.Duration) (*types.EngineResponse, error) {
+	ret := _m.Called(oc, timeout)
+
+	var r0 *types.EngineResponse
+	if rf, ok := ret.Get(0).(func(*types.OrderCancel, time.Duration) *types.EngineResponse); ok {
+		r0 = rf(oc, timeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.EngineResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.OrderCancel, time.Duration) error); ok {
+		r1 = rf(oc, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEvents provides a mock function with given fields: h
+func (_m *OrderService) GetEvents(h common.Hash) ([]*types.OrderEvent, error) {
+	ret := _m.Called(h)
+
+	var r0 []*types.OrderEvent
+	if rf, ok := ret.Get(0).(func(common.Hash) []*types.OrderEvent); ok {
+		r0 = rf(h)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.OrderEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
+		r1 = rf(h)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByTopic provides a mock function with given fields: userAddress, tokenAddress
+func (_m *OrderService) GetByTopic(userAddress common.Address, tokenAddress common.Address) ([]*types.OrderRecord, error) {
+	ret := _m.Called(userAddress, tokenAddress)
+
+	var r0 []*types.OrderRecord
+	if rf, ok := ret.Get(0).(func(common.Address, common.Address) []*types.OrderRecord); ok {
+		r0 = rf(userAddress, tokenAddress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.OrderRecord)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, common.Address) error); ok {
+		r1 = rf(userAddress, tokenAddress)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByHashes provides a mock function with given fields: hashes
+func (_m *OrderService) GetByHashes(hashes []common.Hash) ([]*types.Order, error) {
+	ret := _m.Called(hashes)
+
+	var r0 []*types.Order
+	if rf, ok := ret.Get(0).(func([]common.Hash) []*types.Order); ok {
+		r0 = rf(hashes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]common.Hash) error); ok {
+		r1 = rf(hashes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
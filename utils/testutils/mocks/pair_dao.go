@@ -164,3 +164,63 @@ func (_m *PairDao) GetByTokenSymbols(baseTokenSymbol string, quoteTokenSymbol st
 
 	return r0, r1
 }
+
+// SetActive provides a mock function with given fields: bt, qt, active
+func (_m *PairDao) SetActive(bt common.Address, qt common.Address, active bool) error {
+	ret := _m.Called(bt, qt, active)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Address, common.Address, bool) error); ok {
+		r0 = rf(bt, qt, active)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetListedPairs provides a mock function with given fields:
+func (_m *PairDao) GetListedPairs() ([]types.Pair, error) {
+	ret := _m.Called()
+
+	var r0 []types.Pair
+	if rf, ok := ret.Get(0).(func() []types.Pair); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Pair)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUnlistedPairs provides a mock function with given fields:
+func (_m *PairDao) GetUnlistedPairs() ([]types.Pair, error) {
+	ret := _m.Called()
+
+	var r0 []types.Pair
+	if rf, ok := ret.Get(0).(func() []types.Pair); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Pair)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -3,9 +3,12 @@
 package mocks
 
 import big "math/big"
+import bind "github.com/ethereum/go-ethereum/accounts/abi/bind"
+import ecdsa "crypto/ecdsa"
 import common "github.com/ethereum/go-ethereum/common"
 
 import mock "github.com/stretchr/testify/mock"
+import time "time"
 import types "github.com/ethereum/go-ethereum/core/types"
 
 // EthereumProvider is an autogenerated mock type for the EthereumProvider type
@@ -59,6 +62,29 @@ func (_m *EthereumProvider) BalanceOf(owner common.Address, token common.Address
 	return r0, r1
 }
 
+// ChainID provides a mock function with given fields:
+func (_m *EthereumProvider) ChainID() (*big.Int, error) {
+	ret := _m.Called()
+
+	var r0 *big.Int
+	if rf, ok := ret.Get(0).(func() *big.Int); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExchangeAllowance provides a mock function with given fields: owner, token
 func (_m *EthereumProvider) ExchangeAllowance(owner common.Address, token common.Address) (*big.Int, error) {
 	ret := _m.Called(owner, token)
@@ -126,6 +152,52 @@ func (_m *EthereumProvider) GetPendingNonceAt(a common.Address) (uint64, error)
 	return r0, r1
 }
 
+// NewTransactor provides a mock function with given fields: key
+func (_m *EthereumProvider) NewTransactor(key *ecdsa.PrivateKey) (*bind.TransactOpts, error) {
+	ret := _m.Called(key)
+
+	var r0 *bind.TransactOpts
+	if rf, ok := ret.Get(0).(func(*ecdsa.PrivateKey) *bind.TransactOpts); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bind.TransactOpts)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ecdsa.PrivateKey) error); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SendEther provides a mock function with given fields: key, to, amount
+func (_m *EthereumProvider) SendEther(key *ecdsa.PrivateKey, to common.Address, amount *big.Int) (common.Hash, error) {
+	ret := _m.Called(key, to, amount)
+
+	var r0 common.Hash
+	if rf, ok := ret.Get(0).(func(*ecdsa.PrivateKey, common.Address, *big.Int) common.Hash); ok {
+		r0 = rf(key, to, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Hash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ecdsa.PrivateKey, common.Address, *big.Int) error); ok {
+		r1 = rf(key, to, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // WaitMined provides a mock function with given fields: hash
 func (_m *EthereumProvider) WaitMined(hash common.Hash) (*types.Receipt, error) {
 	ret := _m.Called(hash)
@@ -148,3 +220,68 @@ func (_m *EthereumProvider) WaitMined(hash common.Hash) (*types.Receipt, error)
 
 	return r0, r1
 }
+
+// WaitMinedWithTimeout provides a mock function with given fields: hash, timeout
+func (_m *EthereumProvider) WaitMinedWithTimeout(hash common.Hash, timeout time.Duration) (*types.Receipt, error) {
+	ret := _m.Called(hash, timeout)
+
+	var r0 *types.Receipt
+	if rf, ok := ret.Get(0).(func(common.Hash, time.Duration) *types.Receipt); ok {
+		r0 = rf(hash, timeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Receipt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash, time.Duration) error); ok {
+		r1 = rf(hash, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Decimals provides a mock function with given fields: token
+func (_m *EthereumProvider) Decimals(token common.Address) (uint8, error) {
+	ret := _m.Called(token)
+
+	var r0 uint8
+	if rf, ok := ret.Get(0).(func(common.Address) uint8); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(uint8)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Symbol provides a mock function with given fields: token
+func (_m *EthereumProvider) Symbol(token common.Address) (string, error) {
+	ret := _m.Called(token)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(common.Address) string); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -2,10 +2,12 @@
 
 package mocks
 
+import big "math/big"
 import bson "gopkg.in/mgo.v2/bson"
 import common "github.com/ethereum/go-ethereum/common"
 
 import mock "github.com/stretchr/testify/mock"
+import time "time"
 import types "github.com/tomochain/dex-server/types"
 
 // TradeDao is an autogenerated mock type for the TradeDao type
@@ -342,6 +344,20 @@ func (_m *TradeDao) UpdateByHash(h common.Hash, t *types.Trade) error {
 	return r0
 }
 
+// UpdateTradeSettlementError provides a mock function with given fields: h, e
+func (_m *TradeDao) UpdateTradeSettlementError(h common.Hash, e *types.SettlementError) error {
+	ret := _m.Called(h, e)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Hash, *types.SettlementError) error); ok {
+		r0 = rf(h, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateTradeStatus provides a mock function with given fields: h, status
 func (_m *TradeDao) UpdateTradeStatus(h common.Hash, status string) error {
 	ret := _m.Called(h, status)
@@ -415,3 +431,134 @@ func (_m *TradeDao) UpdateTradeStatusesByOrderHashes(status string, hashes ...co
 
 	return r0, r1
 }
+
+// GetByStatus provides a mock function with given fields: status
+func (_m *TradeDao) GetByStatus(status string) ([]*types.Trade, error) {
+	ret := _m.Called(status)
+
+	var r0 []*types.Trade
+	if rf, ok := ret.Get(0).(func(string) []*types.Trade); ok {
+		r0 = rf(status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Trade)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserVolumes provides a mock function with given fields: a, quoteToken, since
+func (_m *TradeDao) GetUserVolumes(a common.Address, quoteToken common.Address, since time.Time) (map[common.Address]*big.Int, error) {
+	ret := _m.Called(a, quoteToken, since)
+
+	var r0 map[common.Address]*big.Int
+	if rf, ok := ret.Get(0).(func(common.Address, common.Address, time.Time) map[common.Address]*big.Int); ok {
+		r0 = rf(a, quoteToken, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[common.Address]*big.Int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, common.Address, time.Time) error); ok {
+		r1 = rf(a, quoteToken, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPage provides a mock function with given fields: f
+func (_m *TradeDao) GetPage(f *types.HistoryFilter) ([]*types.Trade, *types.Cursor, error) {
+	ret := _m.Called(f)
+
+	var r0 []*types.Trade
+	if rf, ok := ret.Get(0).(func(*types.HistoryFilter) []*types.Trade); ok {
+		r0 = rf(f)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Trade)
+		}
+	}
+
+	var r1 *types.Cursor
+	if rf, ok := ret.Get(1).(func(*types.HistoryFilter) *types.Cursor); ok {
+		r1 = rf(f)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*types.Cursor)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*types.HistoryFilter) error); ok {
+		r2 = rf(f)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetSortedTradesByUserAddress provides a mock function with given fields: a, limit
+func (_m *TradeDao) GetSortedTradesByUserAddress(a common.Address, limit ...int) ([]*types.Trade, error) {
+	_va := make([]interface{}, len(limit))
+	for _i := range limit {
+		_va[_i] = limit[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, a)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []*types.Trade
+	if rf, ok := ret.Get(0).(func(common.Address, ...int) []*types.Trade); ok {
+		r0 = rf(a, limit...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Trade)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, ...int) error); ok {
+		r1 = rf(a, limit...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindAndModify provides a mock function with given fields: h, t
+func (_m *TradeDao) FindAndModify(h common.Hash, t *types.Trade) (*types.Trade, error) {
+	ret := _m.Called(h, t)
+
+	var r0 *types.Trade
+	if rf, ok := ret.Get(0).(func(common.Hash, *types.Trade) *types.Trade); ok {
+		r0 = rf(h, t)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Trade)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash, *types.Trade) error); ok {
+		r1 = rf(h, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -285,3 +285,86 @@ func (_m *TradeService) UpdateTradeTxHash(tr *types.Trade, txHash common.Hash) e
 
 	return r0
 }
+
+// GetPage provides a mock function with given fields: f
+func (_m *TradeService) GetPage(f *types.HistoryFilter) ([]*types.Trade, *types.Cursor, error) {
+	ret := _m.Called(f)
+
+	var r0 []*types.Trade
+	if rf, ok := ret.Get(0).(func(*types.HistoryFilter) []*types.Trade); ok {
+		r0 = rf(f)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Trade)
+		}
+	}
+
+	var r1 *types.Cursor
+	if rf, ok := ret.Get(1).(func(*types.HistoryFilter) *types.Cursor); ok {
+		r1 = rf(f)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*types.Cursor)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*types.HistoryFilter) error); ok {
+		r2 = rf(f)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UpdateSuccessfulTrade provides a mock function with given fields: t
+func (_m *TradeService) UpdateSuccessfulTrade(t *types.Trade) (*types.Trade, error) {
+	ret := _m.Called(t)
+
+	var r0 *types.Trade
+	if rf, ok := ret.Get(0).(func(*types.Trade) *types.Trade); ok {
+		r0 = rf(t)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Trade)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.Trade) error); ok {
+		r1 = rf(t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePendingTrade provides a mock function with given fields: t, txh
+func (_m *TradeService) UpdatePendingTrade(t *types.Trade, txh common.Hash) (*types.Trade, error) {
+	ret := _m.Called(t, txh)
+
+	var r0 *types.Trade
+	if rf, ok := ret.Get(0).(func(*types.Trade, common.Hash) *types.Trade); ok {
+		r0 = rf(t, txh)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Trade)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.Trade, common.Hash) error); ok {
+		r1 = rf(t, txh)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Subscribe provides a mock function with given fields: c, bt, qt
+func (_m *TradeService) Subscribe(c *ws.Client, bt common.Address, qt common.Address) {
+	_m.Called(c, bt, qt)
+}
@@ -141,3 +141,17 @@ func (_m *WalletDao) GetOperatorWallets() ([]*types.Wallet, error) {
 
 	return r0, r1
 }
+
+// SetOperator provides a mock function with given fields: a, isOperator
+func (_m *WalletDao) SetOperator(a common.Address, isOperator bool) error {
+	ret := _m.Called(a, isOperator)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Address, bool) error); ok {
+		r0 = rf(a, isOperator)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
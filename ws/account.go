@@ -0,0 +1,31 @@
+package ws
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/types"
+)
+
+// AccountConnection is the list of the connections subscribed to the token balances of an account
+type AccountConnection []*Client
+
+// GetAccountConnections returns the connections subscribed to the token balances of an account
+func GetAccountConnections(a common.Address) AccountConnection {
+	return getHub().subscribers(topic(AccountChannel, a.Hex()))
+}
+
+// RegisterAccountConnection registers a connection to the token balances of an account.
+// It is called when a connection proves the ownership of the account in a SUBSCRIBE message
+func RegisterAccountConnection(a common.Address, c *Client) {
+	logger.Info("Registering new account connection")
+	getHub().subscribe(topic(AccountChannel, a.Hex()), c)
+}
+
+// UnregisterAccountConnections removes a connection from the account connections of all the accounts
+func UnregisterAccountConnections(c *Client) {
+	getHub().unsubscribeChannel(AccountChannel, c)
+}
+
+// SendAccountMessage sends a token balance update to the connections subscribed to an account
+func SendAccountMessage(msgType types.SubscriptionEvent, a common.Address, payload interface{}) {
+	getHub().broadcast(topic(AccountChannel, a.Hex()), newMessage(AccountChannel, msgType, payload))
+}
@@ -0,0 +1,43 @@
+package ws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/types"
+)
+
+func TestAccountMessages(t *testing.T) {
+	addr := common.HexToAddress("0x2")
+	other := common.HexToAddress("0x3")
+
+	c := NewClient(nil)
+	RegisterAccountConnection(addr, c)
+	RegisterOrderConnection(addr, c)
+
+	SendAccountMessage(types.UPDATE, other, "other")
+	SendAccountMessage(types.UPDATE, addr, "balance")
+
+	select {
+	case m := <-c.send:
+		if m.Channel != AccountChannel || m.Event.Payload != "balance" {
+			t.Errorf("Unexpected message %v", m)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Account message was not received")
+	}
+
+	UnregisterAccountConnections(c)
+
+	if len(GetAccountConnections(addr)) != 0 {
+		t.Errorf("Expected the account connection to be removed")
+	}
+
+	if len(GetOrderConnections(addr)) != 1 {
+		t.Errorf("Expected the order connection to be kept")
+	}
+
+	c.closeConnection()
+	waitFor(t, func() bool { return len(GetOrderConnections(addr)) == 0 }, "Closed client was not unregistered")
+}
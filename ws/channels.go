@@ -14,6 +14,7 @@ const (
 	TokenChannel        = "tokens"
 	OHLCVChannel        = "ohlcv"
 	DepositChannel      = "deposit"
+	AccountChannel      = "account"
 )
 
 var socketChannels map[string]func(interface{}, *Client)
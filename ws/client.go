@@ -2,32 +2,38 @@ package ws
 
 import (
 	"sync"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/websocket"
 	"github.com/tomochain/dex-server/types"
 )
 
+// clientSendQueueSize is the number of outbound messages queued for a client. A client that lets
+// its queue fill up is too slow to follow the updates and is disconnected
+const clientSendQueueSize = 256
+
 type Client struct {
 	*websocket.Conn
-	mu   sync.Mutex
-	send chan types.WebsocketMessage
+	send      chan types.WebsocketMessage
+	done      chan struct{}
+	closeOnce sync.Once
+	dropped   int32
+	auth      *types.Authentication
 }
 
-var unsubscribeHandlers map[*Client][]func(*Client)
-
 func NewClient(c *websocket.Conn) *Client {
-	conn := &Client{Conn: c, mu: sync.Mutex{}, send: make(chan types.WebsocketMessage)}
-
-	if unsubscribeHandlers == nil {
-		unsubscribeHandlers = make(map[*Client][]func(*Client))
-	}
-
-	if unsubscribeHandlers[conn] == nil {
-		unsubscribeHandlers[conn] = make([]func(*Client), 0)
+	return &Client{
+		Conn: c,
+		send: make(chan types.WebsocketMessage, clientSendQueueSize),
+		done: make(chan struct{}),
 	}
+}
 
-	return conn
+// Authentication returns the authentication of the websocket handshake of the client, or nil if
+// the connection is anonymous
+func (c *Client) Authentication() *types.Authentication {
+	return c.auth
 }
 
 // SendMessage constructs the message with proper structure to be sent over websocket
@@ -46,17 +52,54 @@ func (c *Client) SendMessage(channel string, msgType types.SubscriptionEvent, pa
 		Event:   e,
 	}
 
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.send <- m
+	c.enqueue(m)
 }
 
-func (c *Client) closeConnection() {
-	for _, unsub := range unsubscribeHandlers[c] {
-		go unsub(c)
+// enqueue adds a message to the outbound queue of the client without blocking. The client is
+// disconnected if its queue is full
+func (c *Client) enqueue(m types.WebsocketMessage) {
+	if c.isClosed() {
+		return
+	}
+
+	select {
+	case c.send <- m:
+	default:
+		h := getHub()
+		atomic.AddInt64(&h.droppedMessages, 1)
+
+		// the messages sent while the client is disconnected are dropped as well
+		if !atomic.CompareAndSwapInt32(&c.dropped, 0, 1) {
+			return
+		}
+
+		atomic.AddInt64(&h.droppedClients, 1)
+		logger.Warningf("Disconnecting slow websocket client after %v queued messages", clientSendQueueSize)
+
+		// the hub may be the caller, and closing the connection sends it a request
+		go c.closeConnection()
+	}
+}
+
+// isClosed returns true if the connection of the client was closed
+func (c *Client) isClosed() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
 	}
+}
 
-	c.Close()
+func (c *Client) closeConnection() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		getHub().unregister(c)
+
+		if c.Conn != nil {
+			c.Close()
+		}
+	})
 }
 
 func (c *Client) SendOrderErrorMessage(err error, h common.Hash) {
@@ -75,28 +118,16 @@ func (c *Client) SendOrderErrorMessage(err error, h common.Hash) {
 		Event:   e,
 	}
 
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.send <- m
+	c.enqueue(m)
 }
 
-// func (c *Client) SendDepositErrorMessage(err error, h common.Hash) {
-// 	p := map[string]interface{}{
-// 		"message": err.Error(),
-// 		"hash":    h.Hex(),
-// 	}
-
-// 	e := types.WebsocketEvent{
-// 		Type:    "ERROR",
-// 		Payload: p,
-// 	}
-
-// 	m := types.WebsocketMessage{
-// 		Channel: DepositChannel,
-// 		Event:   e,
-// 	}
-
-// 	c.mu.Lock()
-// 	defer c.mu.Unlock()
-// 	c.send <- m
-// }
+// newMessage returns a message of a channel
+func newMessage(channel string, msgType types.SubscriptionEvent, payload interface{}) types.WebsocketMessage {
+	return types.WebsocketMessage{
+		Channel: channel,
+		Event: types.WebsocketEvent{
+			Type:    msgType,
+			Payload: payload,
+		},
+	}
+}
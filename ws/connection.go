@@ -7,6 +7,7 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/tomochain/dex-server/types"
+	"github.com/tomochain/dex-server/utils/httputils"
 )
 
 const (
@@ -27,7 +28,8 @@ var upgrader = websocket.Upgrader{
 
 // ConnectionEndpoint is the the handleFunc function for websocket connections
 // It handles incoming websocket messages and routes the message according to
-// channel parameter in channelMessage
+// channel parameter in channelMessage. The credentials of the handshake are verified by the
+// authenticator middleware of the router, which refuses the invalid ones before the upgrade
 func ConnectionEndpoint(w http.ResponseWriter, r *http.Request) {
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
@@ -36,6 +38,8 @@ func ConnectionEndpoint(w http.ResponseWriter, r *http.Request) {
 	}
 
 	c := NewClient(conn)
+	c.auth = httputils.GetAuthentication(r)
+	getHub().register(c)
 	c.SetCloseHandler(closeHandler(c))
 
 	go readHandler(c)
@@ -83,10 +87,23 @@ func readHandler(c *Client) {
 			return
 		}
 
-		go socketChannels[msg.Channel](msg.Event, c)
+		go dispatch(socketChannels[msg.Channel], msg, c)
 	}
 }
 
+// dispatch runs the handler of a channel on a message. A handler panicking on a malformed payload
+// only fails the message instead of crashing the server
+func dispatch(handler func(interface{}, *Client), msg types.WebsocketMessage, c *Client) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Errorf("Handler of channel %v failed: %v", msg.Channel, r)
+			c.SendMessage(msg.Channel, types.ERROR, "Invalid payload")
+		}
+	}()
+
+	handler(msg.Event, c)
+}
+
 func writeHandler(c *Client) {
 	ticker := time.NewTicker(pingPeriod)
 	defer func() {
@@ -105,12 +122,13 @@ func writeHandler(c *Client) {
 				return
 			}
 
-		case m, ok := <-c.send:
+		case <-c.done:
 			c.SetWriteDeadline(time.Now().Add(writeWait))
-			if !ok {
-				c.WriteMessage(websocket.CloseMessage, []byte{})
-			}
+			c.WriteMessage(websocket.CloseMessage, []byte{})
+			return
 
+		case m := <-c.send:
+			c.SetWriteDeadline(time.Now().Add(writeWait))
 			logger.LogMessageOut(&m)
 			logger.Infof("%v", m.String())
 
@@ -136,5 +154,5 @@ func closeHandler(c *Client) func(code int, text string) error {
 // that connection are triggered.
 func RegisterConnectionUnsubscribeHandler(c *Client, fn func(*Client)) {
 	logger.Info("Registering a new unsubscribe handler")
-	unsubscribeHandlers[c] = append(unsubscribeHandlers[c], fn)
+	getHub().addUnsubscribeHandler(c, fn)
 }
@@ -0,0 +1,26 @@
+package ws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tomochain/dex-server/types"
+)
+
+func TestDispatchRecovers(t *testing.T) {
+	c := NewClient(nil)
+	handler := func(interface{}, *Client) {
+		panic("malformed payload")
+	}
+
+	dispatch(handler, types.WebsocketMessage{Channel: OrderChannel}, c)
+
+	select {
+	case m := <-c.send:
+		if m.Channel != OrderChannel || m.Event.Type != types.ERROR {
+			t.Errorf("Unexpected message %v", m)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Error message was not sent")
+	}
+}
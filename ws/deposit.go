@@ -5,45 +5,19 @@ import (
 	"github.com/tomochain/dex-server/types"
 )
 
-// DepositConn is websocket deposit connection struct
-// It holds the reference to connection and the channel of type DepositMessage
-
-// Send update directly to client based on wallet address
+// DepositConnection is the list of the connections following the deposits of a wallet address
 type DepositConnection []*Client
 
-var depositConnections map[string]DepositConnection
-
-// GetDepositConn returns the connection associated with an deposit ID
+// GetDepositConnections returns the connections following the deposits of a wallet address
 func GetDepositConnections(a common.Address) DepositConnection {
-	c := depositConnections[a.Hex()]
-	if c == nil {
-		logger.Warning("No connection found")
-		return nil
-	}
-
-	return depositConnections[a.Hex()]
+	return getHub().subscribers(topic(DepositChannel, a.Hex()))
 }
 
+// DepositSocketUnsubscribeHandler returns the handler removing a connection from the deposit
+// connections of a wallet address
 func DepositSocketUnsubscribeHandler(a common.Address) func(client *Client) {
 	return func(client *Client) {
-		logger.Info("In unsubscription handler")
-		depositConnection := depositConnections[a.Hex()]
-		if depositConnection == nil {
-			logger.Info("No subscriptions")
-		}
-
-		if depositConnection != nil {
-			logger.Info("%v connections before unsubscription", len(depositConnections[a.Hex()]))
-			for i, c := range depositConnection {
-				if client == c {
-					depositConnection = append(depositConnection[:i], depositConnection[i+1:]...)
-				}
-			}
-
-		}
-
-		depositConnections[a.Hex()] = depositConnection
-		logger.Info("%v connections after unsubscription", len(depositConnections[a.Hex()]))
+		getHub().unsubscribe(topic(DepositChannel, a.Hex()), client)
 	}
 }
 
@@ -51,36 +25,10 @@ func DepositSocketUnsubscribeHandler(a common.Address) func(client *Client) {
 // It is called whenever a message is recieved over deposit channel
 func RegisterDepositConnection(a common.Address, c *Client) {
 	logger.Info("Registering new deposit connection")
-
-	if depositConnections == nil {
-		depositConnections = make(map[string]DepositConnection)
-	}
-
-	if depositConnections[a.Hex()] == nil {
-		logger.Info("Registering a new deposit connection")
-		depositConnections[a.Hex()] = DepositConnection{c}
-		RegisterConnectionUnsubscribeHandler(c, DepositSocketUnsubscribeHandler(a))
-		logger.Info("Number of connections for this address: %v", len(depositConnections))
-	}
-
-	if depositConnections[a.Hex()] != nil {
-		if !isClientConnected(depositConnections[a.Hex()], c) {
-			logger.Info("Registering a new deposit connection")
-			depositConnections[a.Hex()] = append(depositConnections[a.Hex()], c)
-			RegisterConnectionUnsubscribeHandler(c, DepositSocketUnsubscribeHandler(a))
-			logger.Info("Number of connections for this address: %v", len(depositConnections))
-		}
-	}
+	getHub().subscribe(topic(DepositChannel, a.Hex()), c)
 }
 
+// SendDepositMessage sends a deposit update to the connections following a wallet address
 func SendDepositMessage(msgType types.SubscriptionEvent, a common.Address, payload interface{}) {
-	conn := GetDepositConnections(a)
-	if conn == nil {
-		logger.Infof("No connection found")
-		return
-	}
-
-	for _, c := range conn {
-		c.SendMessage(DepositChannel, msgType, payload)
-	}
+	getHub().broadcast(topic(DepositChannel, a.Hex()), newMessage(DepositChannel, msgType, payload))
 }
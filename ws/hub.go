@@ -0,0 +1,199 @@
+package ws
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/tomochain/dex-server/types"
+)
+
+// hubRequestQueueSize is the number of requests that can be queued before the callers of the hub block
+const hubRequestQueueSize = 1024
+
+// Hub owns the subscriptions of the websocket clients. All the subscription state is only read
+// and written by the hub goroutine, the other goroutines send it requests
+type Hub struct {
+	requests      chan func()
+	clients       map[*Client]bool
+	subscriptions map[string]map[*Client]bool
+	topics        map[*Client]map[string]bool
+	handlers      map[*Client][]func(*Client)
+
+	droppedClients  int64
+	droppedMessages int64
+}
+
+// HubMetrics are the counters of the websocket hub
+type HubMetrics struct {
+	Clients         int   `json:"clients"`
+	Subscriptions   int   `json:"subscriptions"`
+	DroppedClients  int64 `json:"droppedClients"`
+	DroppedMessages int64 `json:"droppedMessages"`
+}
+
+var hub *Hub
+var hubOnce sync.Once
+
+// getHub returns the hub of the websocket clients, starting it on first use
+func getHub() *Hub {
+	hubOnce.Do(func() {
+		hub = newHub()
+		go hub.run()
+	})
+
+	return hub
+}
+
+func newHub() *Hub {
+	return &Hub{
+		requests:      make(chan func(), hubRequestQueueSize),
+		clients:       make(map[*Client]bool),
+		subscriptions: make(map[string]map[*Client]bool),
+		topics:        make(map[*Client]map[string]bool),
+		handlers:      make(map[*Client][]func(*Client)),
+	}
+}
+
+// GetHubMetrics returns the number of connected clients and subscriptions, and the number of
+// clients disconnected and messages dropped because the clients did not read their messages
+func GetHubMetrics() HubMetrics {
+	h := getHub()
+	m := HubMetrics{}
+
+	h.do(func() {
+		m.Clients = len(h.clients)
+		for _, clients := range h.subscriptions {
+			m.Subscriptions += len(clients)
+		}
+	})
+
+	m.DroppedClients = atomic.LoadInt64(&h.droppedClients)
+	m.DroppedMessages = atomic.LoadInt64(&h.droppedMessages)
+	return m
+}
+
+func (h *Hub) run() {
+	for fn := range h.requests {
+		fn()
+	}
+}
+
+// do runs a function on the hub goroutine and waits for its completion. It must not be called
+// from the hub goroutine
+func (h *Hub) do(fn func()) {
+	done := make(chan struct{})
+	h.requests <- func() {
+		fn()
+		close(done)
+	}
+
+	<-done
+}
+
+func (h *Hub) register(c *Client) {
+	h.do(func() {
+		h.clients[c] = true
+	})
+}
+
+// unregister removes the subscriptions of a closed client and runs its unsubscribe handlers
+func (h *Hub) unregister(c *Client) {
+	h.requests <- func() {
+		for topic := range h.topics[c] {
+			h.removeSubscription(topic, c)
+		}
+
+		for _, fn := range h.handlers[c] {
+			go fn(c)
+		}
+
+		delete(h.clients, c)
+		delete(h.topics, c)
+		delete(h.handlers, c)
+	}
+}
+
+func (h *Hub) subscribe(topic string, c *Client) {
+	h.do(func() {
+		// a client closed before its subscription would never be unregistered
+		if c.isClosed() {
+			return
+		}
+
+		if h.subscriptions[topic] == nil {
+			h.subscriptions[topic] = make(map[*Client]bool)
+		}
+
+		if h.topics[c] == nil {
+			h.topics[c] = make(map[string]bool)
+		}
+
+		h.subscriptions[topic][c] = true
+		h.topics[c][topic] = true
+	})
+}
+
+func (h *Hub) unsubscribe(topic string, c *Client) {
+	h.do(func() {
+		h.removeSubscription(topic, c)
+	})
+}
+
+// unsubscribeChannel removes the subscriptions of a client to all the topics of a channel
+func (h *Hub) unsubscribeChannel(channel string, c *Client) {
+	h.do(func() {
+		for topic := range h.topics[c] {
+			if topicChannel(topic) == channel {
+				h.removeSubscription(topic, c)
+			}
+		}
+	})
+}
+
+func (h *Hub) addUnsubscribeHandler(c *Client, fn func(*Client)) {
+	h.do(func() {
+		h.handlers[c] = append(h.handlers[c], fn)
+	})
+}
+
+// subscribers returns the clients subscribed to a topic
+func (h *Hub) subscribers(topic string) []*Client {
+	clients := []*Client{}
+
+	h.do(func() {
+		for c := range h.subscriptions[topic] {
+			clients = append(clients, c)
+		}
+	})
+
+	return clients
+}
+
+// broadcast queues a message to the clients subscribed to a topic without waiting for them
+func (h *Hub) broadcast(topic string, m types.WebsocketMessage) {
+	h.requests <- func() {
+		for c := range h.subscriptions[topic] {
+			c.enqueue(m)
+		}
+	}
+}
+
+func (h *Hub) removeSubscription(topic string, c *Client) {
+	delete(h.subscriptions[topic], c)
+	if len(h.subscriptions[topic]) == 0 {
+		delete(h.subscriptions, topic)
+	}
+
+	delete(h.topics[c], topic)
+}
+
+// topic returns the key of the subscriptions to an id of a channel
+func topic(channel, id string) string {
+	return channel + "/" + id
+}
+
+// topicChannel returns the channel of a topic
+func topicChannel(topic string) string {
+	return strings.SplitN(topic, "/", 2)[0]
+}
@@ -0,0 +1,79 @@
+package ws
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/dex-server/types"
+)
+
+// waitFor polls a condition until it is true or a second elapsed
+func waitFor(t *testing.T, cond func() bool, msg string) {
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal(msg)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestHubDisconnectsSlowClients(t *testing.T) {
+	addr := common.HexToAddress("0x1")
+	slow := NewClient(nil)
+	fast := NewClient(nil)
+
+	RegisterOrderConnection(addr, slow)
+	RegisterOrderConnection(addr, fast)
+
+	dropped := GetHubMetrics().DroppedClients
+
+	// the fast client reads its messages, the slow one never does
+	go func() {
+		for range fast.send {
+		}
+	}()
+
+	for i := 0; i <= clientSendQueueSize; i++ {
+		SendOrderMessage(types.UPDATE, addr, i)
+	}
+
+	waitFor(t, func() bool { return len(GetOrderConnections(addr)) == 1 }, "Slow client was not disconnected")
+
+	if GetOrderConnections(addr)[0] != fast {
+		t.Errorf("Expected the fast client to stay subscribed")
+	}
+
+	if GetHubMetrics().DroppedClients != dropped+1 {
+		t.Errorf("Expected one dropped client, got %v", GetHubMetrics().DroppedClients-dropped)
+	}
+
+	fast.closeConnection()
+	waitFor(t, func() bool { return len(GetOrderConnections(addr)) == 0 }, "Closed client was not unregistered")
+}
+
+func TestHubConcurrentSubscriptions(t *testing.T) {
+	socket := GetTradeSocket()
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			c := NewClient(nil)
+			socket.Subscribe("pair", c)
+			socket.BroadcastMessage("pair", "trade")
+			socket.UnsubscribeChannel("pair", c)
+			c.closeConnection()
+		}()
+	}
+
+	wg.Wait()
+
+	waitFor(t, func() bool { return len(getHub().subscribers(topic(TradeChannel, "pair"))) == 0 }, "Subscriptions were not removed")
+}
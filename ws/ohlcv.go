@@ -5,28 +5,19 @@ import (
 	"github.com/tomochain/dex-server/types"
 )
 
-var ohlcvSocket *OHLCVSocket
+var ohlcvSocket = NewOHLCVSocket()
 
-// OHLCVSocket holds the map of subscribtions subscribed to pair channels
-// corresponding to the key/event they have subscribed to.
+// OHLCVSocket sends the ticks of the pairs to their subscribers. The subscriptions are held by the hub
 type OHLCVSocket struct {
-	subscriptions     map[string]map[*Client]bool
-	subscriptionsList map[*Client][]string
+	channel string
 }
 
 func NewOHLCVSocket() *OHLCVSocket {
-	return &OHLCVSocket{
-		subscriptions:     make(map[string]map[*Client]bool),
-		subscriptionsList: make(map[*Client][]string),
-	}
+	return &OHLCVSocket{OHLCVChannel}
 }
 
 // GetOHLCVSocket return singleton instance of PairSockets type struct
 func GetOHLCVSocket() *OHLCVSocket {
-	if ohlcvSocket == nil {
-		ohlcvSocket = NewOHLCVSocket()
-	}
-
 	return ohlcvSocket
 }
 
@@ -37,17 +28,7 @@ func (s *OHLCVSocket) Subscribe(channelID string, c *Client) error {
 		return errors.New("No connection found")
 	}
 
-	if s.subscriptions[channelID] == nil {
-		s.subscriptions[channelID] = make(map[*Client]bool)
-	}
-
-	s.subscriptions[channelID][c] = true
-
-	if s.subscriptionsList[c] == nil {
-		s.subscriptionsList[c] = []string{}
-	}
-
-	s.subscriptionsList[c] = append(s.subscriptionsList[c], channelID)
+	getHub().subscribe(topic(s.channel, channelID), c)
 
 	return nil
 }
@@ -70,31 +51,16 @@ func (s *OHLCVSocket) UnsubscribeHandler() func(c *Client) {
 // subscribed to. It can be called on unsubscription message from user or due to some other reason by
 // system
 func (s *OHLCVSocket) UnsubscribeChannel(channelID string, c *Client) {
-	if s.subscriptions[channelID][c] {
-		s.subscriptions[channelID][c] = false
-		delete(s.subscriptions[channelID], c)
-	}
+	getHub().unsubscribe(topic(s.channel, channelID), c)
 }
 
 func (s *OHLCVSocket) Unsubscribe(c *Client) {
-	channelIDs := s.subscriptionsList[c]
-	if channelIDs == nil {
-		return
-	}
-
-	for _, id := range s.subscriptionsList[c] {
-		s.UnsubscribeChannel(id, c)
-	}
+	getHub().unsubscribeChannel(s.channel, c)
 }
 
 // BroadcastOHLCV Message streams message to all the subscribtions subscribed to the pair
 func (s *OHLCVSocket) BroadcastOHLCV(channelID string, p interface{}) error {
-	for c, status := range s.subscriptions[channelID] {
-		if status {
-			s.SendUpdateMessage(c, p)
-		}
-	}
-
+	getHub().broadcast(topic(s.channel, channelID), newMessage(s.channel, types.UPDATE, p))
 	return nil
 }
 
@@ -5,81 +5,35 @@ import (
 	"github.com/tomochain/dex-server/types"
 )
 
-// OrderConn is websocket order connection struct
-// It holds the reference to connection and the channel of type OrderMessage
-
+// OrderConnection is the list of the connections subscribed to the private events of an account
 type OrderConnection []*Client
 
-var orderConnections map[string]OrderConnection
-
-// GetOrderConn returns the connection associated with an order ID
+// GetOrderConnections returns the connections subscribed to the private events of an account
 func GetOrderConnections(a common.Address) OrderConnection {
-	c := orderConnections[a.Hex()]
-	if c == nil {
-		logger.Warning("No connection found")
-		return nil
-	}
-
-	return orderConnections[a.Hex()]
+	return getHub().subscribers(topic(OrderChannel, a.Hex()))
 }
 
+// OrderSocketUnsubscribeHandler returns the handler unsubscribing a connection from the private
+// events of an account
 func OrderSocketUnsubscribeHandler(a common.Address) func(client *Client) {
 	return func(client *Client) {
-		logger.Info("In unsubscription handler")
-		orderConnection := orderConnections[a.Hex()]
-		if orderConnection == nil {
-			logger.Info("No subscriptions")
-		}
-
-		if orderConnection != nil {
-			logger.Info("%v connections before unsubscription", len(orderConnections[a.Hex()]))
-			for i, c := range orderConnection {
-				if client == c {
-					orderConnection = append(orderConnection[:i], orderConnection[i+1:]...)
-				}
-			}
-
-		}
-
-		orderConnections[a.Hex()] = orderConnection
-		logger.Info("%v connections after unsubscription", len(orderConnections[a.Hex()]))
+		getHub().unsubscribe(topic(OrderChannel, a.Hex()), client)
 	}
 }
 
-// RegisterOrderConnection registers a connection with and orderID.
-// It is called whenever a message is recieved over order channel
+// RegisterOrderConnection registers a connection to the private events of an account.
+// It is called when a connection proves the ownership of the account in a SUBSCRIBE message
 func RegisterOrderConnection(a common.Address, c *Client) {
 	logger.Info("Registering new order connection")
+	getHub().subscribe(topic(OrderChannel, a.Hex()), c)
+}
 
-	if orderConnections == nil {
-		orderConnections = make(map[string]OrderConnection)
-	}
-
-	if orderConnections[a.Hex()] == nil {
-		logger.Info("Registering a new order connection")
-		orderConnections[a.Hex()] = OrderConnection{c}
-		RegisterConnectionUnsubscribeHandler(c, OrderSocketUnsubscribeHandler(a))
-		logger.Info("Number of connections for this address: %v", len(orderConnections))
-	}
-
-	if orderConnections[a.Hex()] != nil {
-
-		if !isClientConnected(orderConnections[a.Hex()], c) {
-			logger.Info("Registering a new order connection")
-			orderConnections[a.Hex()] = append(orderConnections[a.Hex()], c)
-			RegisterConnectionUnsubscribeHandler(c, OrderSocketUnsubscribeHandler(a))
-			logger.Info("Number of connections for this address: %v", len(orderConnections))
-		}
-	}
+// UnregisterOrderConnections removes a connection from the order connections of all the accounts
+func UnregisterOrderConnections(c *Client) {
+	getHub().unsubscribeChannel(OrderChannel, c)
 }
 
+// SendOrderMessage sends a private event to the connections subscribed to an account
 func SendOrderMessage(msgType types.SubscriptionEvent, a common.Address, payload interface{}) {
-	conn := GetOrderConnections(a)
-	if conn == nil {
-		return
-	}
-
-	for _, c := range conn {
-		c.SendMessage(OrderChannel, msgType, payload)
-	}
+	getHub().broadcast(topic(OrderChannel, a.Hex()), newMessage(OrderChannel, msgType, payload))
 }
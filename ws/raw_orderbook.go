@@ -5,28 +5,20 @@ import (
 	"github.com/tomochain/dex-server/types"
 )
 
-var rawOrderBookSocket *RawOrderBookSocket
+var rawOrderBookSocket = NewRawOrderBookSocket()
 
-// RawOrderBookSocket holds the map of subscribtions subscribed to pair channels
-// corresponding to the key/event they have subscribed to.
+// RawOrderBookSocket sends the raw orderbook updates of the pairs to their subscribers. The
+// subscriptions are held by the hub
 type RawOrderBookSocket struct {
-	subscriptions     map[string]map[*Client]bool
-	subscriptionsList map[*Client][]string
+	channel string
 }
 
 func NewRawOrderBookSocket() *RawOrderBookSocket {
-	return &RawOrderBookSocket{
-		subscriptions:     make(map[string]map[*Client]bool),
-		subscriptionsList: make(map[*Client][]string),
-	}
+	return &RawOrderBookSocket{RawOrderBookChannel}
 }
 
 // GetRawOrderBookSocket return singleton instance of PairSockets type struct
 func GetRawOrderBookSocket() *RawOrderBookSocket {
-	if rawOrderBookSocket == nil {
-		rawOrderBookSocket = NewRawOrderBookSocket()
-	}
-
 	return rawOrderBookSocket
 }
 
@@ -38,17 +30,7 @@ func (s *RawOrderBookSocket) Subscribe(channelID string, c *Client) error {
 		return errors.New("No connection found")
 	}
 
-	if s.subscriptions[channelID] == nil {
-		s.subscriptions[channelID] = make(map[*Client]bool)
-	}
-
-	s.subscriptions[channelID][c] = true
-
-	if s.subscriptionsList[c] == nil {
-		s.subscriptionsList[c] = []string{}
-	}
-
-	s.subscriptionsList[c] = append(s.subscriptionsList[c], channelID)
+	getHub().subscribe(topic(s.channel, channelID), c)
 
 	return nil
 }
@@ -71,31 +53,16 @@ func (s *RawOrderBookSocket) UnsubscribeHandler() func(c *Client) {
 // subscribed to. It can be called on unsubscription message from user or due to some other reason by
 // system
 func (s *RawOrderBookSocket) UnsubscribeChannel(channelID string, c *Client) {
-	if s.subscriptions[channelID][c] {
-		s.subscriptions[channelID][c] = false
-		delete(s.subscriptions[channelID], c)
-	}
+	getHub().unsubscribe(topic(s.channel, channelID), c)
 }
 
 func (s *RawOrderBookSocket) Unsubscribe(c *Client) {
-	channelIDs := s.subscriptionsList[c]
-	if channelIDs == nil {
-		return
-	}
-
-	for _, id := range s.subscriptionsList[c] {
-		s.UnsubscribeChannel(id, c)
-	}
+	getHub().unsubscribeChannel(s.channel, c)
 }
 
 // BroadcastMessage streams message to all the subscribtions subscribed to the pair
 func (s *RawOrderBookSocket) BroadcastMessage(channelID string, p interface{}) error {
-	for c, status := range s.subscriptions[channelID] {
-		if status {
-			s.SendUpdateMessage(c, p)
-		}
-	}
-
+	getHub().broadcast(topic(s.channel, channelID), newMessage(s.channel, types.UPDATE, p))
 	return nil
 }
 
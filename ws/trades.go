@@ -5,27 +5,18 @@ import (
 	"github.com/tomochain/dex-server/types"
 )
 
-var tradeSocket *TradeSocket
+var tradeSocket = NewTradeSocket()
 
-// TradeSocket holds the map of connections subscribed to pair channels
-// corresponding to the key/event they have subscribed to.
+// TradeSocket sends the trades of the pairs to their subscribers. The subscriptions are held by the hub
 type TradeSocket struct {
-	subscriptions     map[string]map[*Client]bool
-	subscriptionsList map[*Client][]string
+	channel string
 }
 
 func NewTradeSocket() *TradeSocket {
-	return &TradeSocket{
-		subscriptions:     make(map[string]map[*Client]bool),
-		subscriptionsList: make(map[*Client][]string),
-	}
+	return &TradeSocket{TradeChannel}
 }
 
 func GetTradeSocket() *TradeSocket {
-	if tradeSocket == nil {
-		tradeSocket = NewTradeSocket()
-	}
-
 	return tradeSocket
 }
 
@@ -35,17 +26,7 @@ func (s *TradeSocket) Subscribe(channelID string, c *Client) error {
 		return errors.New("No connection found")
 	}
 
-	if s.subscriptions[channelID] == nil {
-		s.subscriptions[channelID] = make(map[*Client]bool)
-	}
-
-	s.subscriptions[channelID][c] = true
-
-	if s.subscriptionsList[c] == nil {
-		s.subscriptionsList[c] = []string{}
-	}
-
-	s.subscriptionsList[c] = append(s.subscriptionsList[c], channelID)
+	getHub().subscribe(topic(s.channel, channelID), c)
 
 	return nil
 }
@@ -65,32 +46,16 @@ func (s *TradeSocket) UnsubscribeHandler() func(c *Client) {
 
 // Unsubscribe removes a websocket connection from the trade channel updates
 func (s *TradeSocket) UnsubscribeChannel(channelID string, c *Client) {
-	if s.subscriptions[channelID][c] {
-		s.subscriptions[channelID][c] = false
-		delete(s.subscriptions[channelID], c)
-	}
+	getHub().unsubscribe(topic(s.channel, channelID), c)
 }
 
 func (s *TradeSocket) Unsubscribe(c *Client) {
-	channelIDs := s.subscriptionsList[c]
-	if channelIDs == nil {
-		return
-	}
-
-	for _, id := range s.subscriptionsList[c] {
-		s.UnsubscribeChannel(id, c)
-	}
+	getHub().unsubscribeChannel(s.channel, c)
 }
 
 // BroadcastMessage broadcasts trade message to all subscribed sockets
 func (s *TradeSocket) BroadcastMessage(channelID string, p interface{}) {
-	go func() {
-		for conn, active := range tradeSocket.subscriptions[channelID] {
-			if active {
-				s.SendUpdateMessage(conn, p)
-			}
-		}
-	}()
+	getHub().broadcast(topic(s.channel, channelID), newMessage(s.channel, types.UPDATE, p))
 }
 
 // SendMessage sends a websocket message on the trade channel